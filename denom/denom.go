@@ -0,0 +1,202 @@
+package denom
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+)
+
+// ROUNDING_MODE 는 표시 단위로 변환할 때 버려지는 자리를 처리하는 방식.
+type ROUNDING_MODE int
+
+const (
+	// ROUND_DOWN 은 버려지는 자리를 버린다.
+	ROUND_DOWN ROUNDING_MODE = iota
+	// ROUND_UP 은 버려지는 자리가 0 이 아니면 올린다.
+	ROUND_UP
+	// ROUND_HALF_UP 은 버려지는 자리가 절반 이상이면 올린다.
+	ROUND_HALF_UP
+	// ROUND_HALF_EVEN 은 버려지는 자리가 절반보다 크거나, 절반이면서 남는 마지막 자리가 홀수이면 올린다.
+	ROUND_HALF_EVEN
+)
+
+var roundingModeNames = map[ROUNDING_MODE]string{
+	ROUND_DOWN:      "ROUND_DOWN",
+	ROUND_UP:        "ROUND_UP",
+	ROUND_HALF_UP:   "ROUND_HALF_UP",
+	ROUND_HALF_EVEN: "ROUND_HALF_EVEN",
+}
+
+func (r ROUNDING_MODE) String() string {
+	if name, ok := roundingModeNames[r]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("ROUNDING_MODE(%d)", int(r))
+}
+
+const DECIMAL_SEPARATOR = "."
+
+// Denomination 은 기본 단위(mote) 와 표시 단위 사이의 변환 정보.
+//
+// 1 표시 단위는 10^Decimals mote 이다.
+type Denomination struct {
+	Decimals int
+	Symbol   string
+}
+
+// DEFAULT_DENOMINATION 은 balance, stake, reward 등의 기본 표시 단위.
+// integration 의 INITIAL_BALANCE, BASIC_FEE 와 같이 1 HDAC 은 10^18 mote 이다.
+var DEFAULT_DENOMINATION = Denomination{Decimals: 18, Symbol: "HDAC"}
+
+func NewDenomination(decimals int, symbol string) (Denomination, error) {
+	if decimals < 0 {
+		return Denomination{}, fmt.Errorf("Decimals must not be negative, but %d", decimals)
+	}
+	if strings.TrimSpace(symbol) != symbol || strings.Contains(symbol, " ") {
+		return Denomination{}, fmt.Errorf("Symbol must not contain spaces : %q", symbol)
+	}
+
+	return Denomination{Decimals: decimals, Symbol: symbol}, nil
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// Format 은 mote 를 소수점 아래 precision 자리의 표시 단위 문자열로 변환하는 함수.
+//
+// precision 이 Decimals 보다 작으면 mode 에 따라 반올림하고, 크면 0 을 붙인다.
+func (d Denomination) Format(motes storedvalue.U512, precision int, mode ROUNDING_MODE) (string, error) {
+	if precision < 0 {
+		return "", fmt.Errorf("Precision must not be negative, but %d", precision)
+	}
+	if _, ok := roundingModeNames[mode]; !ok {
+		return "", fmt.Errorf("Unknown rounding mode %s", mode)
+	}
+
+	value := motes.BigInt()
+	shift := d.Decimals - precision
+	if shift > 0 {
+		divisor := pow10(shift)
+		quotient, remainder := new(big.Int).QuoRem(value, divisor, new(big.Int))
+		if roundUp(quotient, remainder, divisor, mode) {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+		value = quotient
+	} else {
+		value.Mul(value, pow10(-shift))
+	}
+
+	digits := value.String()
+	if precision == 0 {
+		return digits, nil
+	}
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+
+	return digits[:len(digits)-precision] + DECIMAL_SEPARATOR + digits[len(digits)-precision:], nil
+}
+
+func roundUp(quotient, remainder, divisor *big.Int, mode ROUNDING_MODE) bool {
+	if remainder.Sign() == 0 {
+		return false
+	}
+
+	switch mode {
+	case ROUND_UP:
+		return true
+	case ROUND_HALF_UP, ROUND_HALF_EVEN:
+		cmp := new(big.Int).Lsh(remainder, 1).Cmp(divisor)
+		if mode == ROUND_HALF_EVEN && cmp == 0 {
+			return quotient.Bit(0) == 1
+		}
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+// FormatExact 는 mote 를 반올림 없이 표시 단위 문자열로 변환하는 함수. 소수점 아래 끝의 0 은 제거한다.
+func (d Denomination) FormatExact(motes storedvalue.U512) string {
+	res, _ := d.Format(motes, d.Decimals, ROUND_DOWN)
+	if strings.Contains(res, DECIMAL_SEPARATOR) {
+		res = strings.TrimRight(strings.TrimRight(res, "0"), DECIMAL_SEPARATOR)
+	}
+
+	return res
+}
+
+// WithSymbol 은 표시 단위 문자열 뒤에 symbol 을 붙이는 함수.
+func (d Denomination) WithSymbol(display string) string {
+	if d.Symbol == "" {
+		return display
+	}
+
+	return display + " " + d.Symbol
+}
+
+// Parse 는 사용자가 입력한 표시 단위 문자열을 mote 로 변환하는 함수.
+//
+// "1", "0.5", "1.25 HDAC" 과 같이 10진수 뒤에 공백 하나와 Symbol 을 붙일 수 있다.
+// 부호, 지수, 자리 구분자, 앞뒤 공백, "1." 또는 ".5" 와 같이 비어 있는 자리는 허용하지 않으며,
+// Decimals 보다 긴 소수 자리는 반올림하지 않고 error 이다.
+func (d Denomination) Parse(str string) (storedvalue.U512, error) {
+	display := str
+	if d.Symbol != "" {
+		display = strings.TrimSuffix(display, " "+d.Symbol)
+	}
+
+	parts := strings.Split(display, DECIMAL_SEPARATOR)
+	if len(parts) > 2 {
+		return storedvalue.U512{}, fmt.Errorf("Invalid amount %q : more than one decimal separator", str)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return storedvalue.U512{}, fmt.Errorf("Invalid amount %q : empty digits", str)
+		}
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return storedvalue.U512{}, fmt.Errorf("Invalid amount %q : unexpected character %q", str, c)
+			}
+		}
+	}
+
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+	if len(fraction) > d.Decimals {
+		return storedvalue.U512{}, fmt.Errorf("Invalid amount %q : at most %d decimal places, but %d", str, d.Decimals, len(fraction))
+	}
+
+	motes, err := storedvalue.ParseU512(parts[0] + fraction + strings.Repeat("0", d.Decimals-len(fraction)))
+	if err != nil {
+		return storedvalue.U512{}, fmt.Errorf("Invalid amount %q : %s", str, err.Error())
+	}
+
+	return motes, nil
+}
+
+func (d Denomination) MustParse(str string) storedvalue.U512 {
+	res, err := d.Parse(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// FormatMotes 는 ipc.Bond 의 stake 나 grpc.QueryBalance 등이 return 하는 10진수 mote 문자열을
+// 표시 단위 문자열로 변환하는 함수. grpc.QueryBalanceMotes 등의 U512 결과는 Format 을 사용한다.
+func (d Denomination) FormatMotes(motes string, precision int, mode ROUNDING_MODE) (string, error) {
+	value, err := storedvalue.ParseU512(motes)
+	if err != nil {
+		return "", err
+	}
+
+	return d.Format(value, precision, mode)
+}
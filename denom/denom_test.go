@@ -0,0 +1,96 @@
+package denom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	d := DEFAULT_DENOMINATION
+	values := []struct {
+		motes     string
+		precision int
+		mode      ROUNDING_MODE
+		expected  string
+	}{
+		{"50000000000000000000000", 2, ROUND_DOWN, "50000.00"},
+		{"100000000000000000", 18, ROUND_DOWN, "0.100000000000000000"},
+		{"100000000000000000", 0, ROUND_DOWN, "0"},
+		{"100000000000000000", 0, ROUND_UP, "1"},
+		{"1", 20, ROUND_DOWN, "0.00000000000000000100"},
+		{"0", 3, ROUND_UP, "0.000"},
+		{"1250000000000000000", 1, ROUND_DOWN, "1.2"},
+		{"1250000000000000000", 1, ROUND_UP, "1.3"},
+		{"1250000000000000000", 1, ROUND_HALF_UP, "1.3"},
+		{"1250000000000000000", 1, ROUND_HALF_EVEN, "1.2"},
+		{"1350000000000000000", 1, ROUND_HALF_EVEN, "1.4"},
+		{"1250000000000000001", 1, ROUND_HALF_EVEN, "1.3"},
+		{"1249999999999999999", 1, ROUND_HALF_UP, "1.2"},
+		{"999999999999999999", 2, ROUND_HALF_UP, "1.00"},
+	}
+	for _, v := range values {
+		res, err := d.Format(storedvalue.MustParseU512(v.motes), v.precision, v.mode)
+		assert.NoError(t, err, v.motes)
+		assert.Equal(t, v.expected, res, "%s %d %s", v.motes, v.precision, v.mode)
+
+		res, err = d.FormatMotes(v.motes, v.precision, v.mode)
+		assert.NoError(t, err)
+		assert.Equal(t, v.expected, res)
+	}
+
+	_, err := d.Format(storedvalue.U512{}, -1, ROUND_DOWN)
+	assert.Error(t, err)
+	_, err = d.Format(storedvalue.U512{}, 2, ROUNDING_MODE(9))
+	assert.Error(t, err)
+	_, err = d.FormatMotes("-1", 2, ROUND_DOWN)
+	assert.Error(t, err)
+
+	assert.Equal(t, "50000", d.FormatExact(storedvalue.MustParseU512("50000000000000000000000")))
+	assert.Equal(t, "0.1", d.FormatExact(storedvalue.MustParseU512("100000000000000000")))
+	assert.Equal(t, "0", d.FormatExact(storedvalue.U512{}))
+	assert.Equal(t, "0.1 HDAC", d.WithSymbol("0.1"))
+
+	noDecimals, err := NewDenomination(0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", noDecimals.FormatExact(storedvalue.MustParseU512("1000")))
+	assert.Equal(t, "1000", noDecimals.WithSymbol("1000"))
+	_, err = NewDenomination(-1, "A")
+	assert.Error(t, err)
+	_, err = NewDenomination(2, "A B")
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	d := DEFAULT_DENOMINATION
+	values := map[string]string{
+		"50000":                "50000000000000000000000",
+		"0.1":                  "100000000000000000",
+		"0.1 HDAC":             "100000000000000000",
+		"1.000000000000000001": "1000000000000000001",
+		"0":                    "0",
+	}
+	for str, expected := range values {
+		motes, err := d.Parse(str)
+		assert.NoError(t, err, str)
+		assert.Equal(t, expected, motes.String(), str)
+	}
+
+	for _, str := range []string{"", ".", "1.", ".5", "-1", "+1", "1e18", "1,000", " 1", "1 ", "1.2.3",
+		"0.1HDAC", "0.1 DAC", "1.0000000000000000001", "１"} {
+		_, err := d.Parse(str)
+		assert.Error(t, err, str)
+	}
+
+	// U512 범위를 벗어나면 error 이다.
+	_, err := d.Parse("1" + strings.Repeat("0", 140))
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { d.MustParse("abc") })
+	for _, motes := range []string{"1", "123456789", "50000000000000000000000"} {
+		u := storedvalue.MustParseU512(motes)
+		assert.True(t, u.Equal(d.MustParse(d.FormatExact(u))), motes)
+	}
+}
@@ -3,7 +3,6 @@ package grpc
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -20,11 +19,6 @@ const (
 	STR_MINT = "mint"
 	STR_POS  = "pos"
 
-	STR_ADDRESS = "address"
-	STR_UREF    = "uref"
-	STR_HASH    = "hash"
-	STR_LOCAL   = "local"
-
 	ACTION_PREFIX_STAKE  = 1
 	ACTION_PREFIX_VOTING = 2
 	ACTION_PREFIX_VOTED  = 3
@@ -38,16 +32,26 @@ var (
 )
 
 // Connect 은 Casperlabs의 Execution Engine의 unix socket으로 연결하는 함수.
-func Connect(path string) ipc.ExecutionEngineServiceClient {
+func Connect(path string) (ipc.ExecutionEngineServiceClient, error) {
 	path = `unix:////` + path
 
-	conn, e := grpc.Dial(path, grpc.WithInsecure())
-	if e != nil {
-		panic(e)
+	conn, err := grpc.Dial(path, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
 	}
 
 	client := ipc.NewExecutionEngineServiceClient(conn)
 
+	return client, nil
+}
+
+// MustConnect 는 Connect 와 같으나 연결에 실패하면 panic 하는 함수.
+func MustConnect(path string) ipc.ExecutionEngineServiceClient {
+	client, err := Connect(path)
+	if err != nil {
+		panic(err)
+	}
+
 	return client
 }
 
@@ -115,27 +119,148 @@ func Commit(client ipc.ExecutionEngineServiceClient,
 
 // Query 는 특정 state 에서 해당 Key의 path에 대한 정보를 조회해주는 함수.
 //
-// State hash, Key type, Key Data, path를 파라미터로 받아
+// State hash, Key, path를 파라미터로 받아
 // Query 후 결과를 return 해준다.
 func Query(client ipc.ExecutionEngineServiceClient,
 	stateHash []byte,
-	keyType string,
-	keyData []byte,
+	key storedvalue.Key,
 	path []string,
 	protocolVersion *state.ProtocolVersion) (result []byte, errMessage string) {
+	return queryKey(client, stateHash, key.ToStateValue(), path, protocolVersion)
+}
+
+// QueryAddress 는 account address 또는 dapp address 에서 path에 대한 정보를 조회해주는 함수.
+//
+// account address는 account key로, dapp address는 prefix를 제외한 hash key로 Query 한다.
+func QueryAddress(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address storedvalue.Address,
+	path []string,
+	protocolVersion *state.ProtocolVersion) (result []byte, errMessage string) {
+
+	key, err := address.Key()
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	return Query(client, stateHash, key, path, protocolVersion)
+}
+
+// QueryCLValue 는 Query 결과인 CLValue 를 v 가 가리키는 Go 값으로 decode 해주는 함수.
+//
+// CL type과 Go type의 대응은 storedvalue.CLValue.Decode 를 따른다.
+// Query 결과는 formats 에서 protocolVersion 에 해당하는 형식의 StoredValue 로 decode 한다.
+func QueryCLValue(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	key storedvalue.Key,
+	path []string,
+	protocolVersion *state.ProtocolVersion,
+	formats storedvalue.StoredValueFormatTable,
+	v interface{}) (errMessage string) {
+
+	format, errMessage := formatForProtocolVersion(protocolVersion, formats)
+	if errMessage != "" {
+		return errMessage
+	}
+
+	return QueryCLValueWithFormat(client, stateHash, key, path, protocolVersion, format, v)
+}
+
+// QueryCLValueWithFormat 은 protocol version 과 관계없이 format 형식으로 decode 하는 QueryCLValue.
+func QueryCLValueWithFormat(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	key storedvalue.Key,
+	path []string,
+	protocolVersion *state.ProtocolVersion,
+	format storedvalue.STORED_VALUE_FORMAT,
+	v interface{}) (errMessage string) {
+
+	res, errMessage := Query(client, stateHash, key, path, protocolVersion)
+	if errMessage != "" {
+		return errMessage
+	}
+
+	return decodeStoredCLValue(res, format, v)
+}
+
+// QueryStoredValue 는 Query 결과를 formats 에서 protocolVersion 에 해당하는 형식의 StoredValue 로 decode 해주는 함수.
+func QueryStoredValue(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	key storedvalue.Key,
+	path []string,
+	protocolVersion *state.ProtocolVersion,
+	formats storedvalue.StoredValueFormatTable) (storedValue storedvalue.StoredValue, errMessage string) {
+
+	format, errMessage := formatForProtocolVersion(protocolVersion, formats)
+	if errMessage != "" {
+		return storedValue, errMessage
+	}
+
+	return QueryStoredValueWithFormat(client, stateHash, key, path, protocolVersion, format)
+}
+
+// QueryStoredValueWithFormat 은 protocol version 과 관계없이 format 형식으로 decode 하는 QueryStoredValue.
+func QueryStoredValueWithFormat(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	key storedvalue.Key,
+	path []string,
+	protocolVersion *state.ProtocolVersion,
+	format storedvalue.STORED_VALUE_FORMAT) (storedValue storedvalue.StoredValue, errMessage string) {
+
+	res, errMessage := Query(client, stateHash, key, path, protocolVersion)
+	if errMessage != "" {
+		return storedValue, errMessage
+	}
+
+	return decodeStoredValue(res, format)
+}
+
+func formatForProtocolVersion(protocolVersion *state.ProtocolVersion,
+	formats storedvalue.StoredValueFormatTable) (format storedvalue.STORED_VALUE_FORMAT, errMessage string) {
+	format, err := storedvalue.FormatForProtocolVersion(
+		storedvalue.NewProtocolVersion(protocolVersion.GetMajor(), protocolVersion.GetMinor(), protocolVersion.GetPatch()), formats)
+	if err != nil {
+		return format, err.Error()
+	}
+
+	return format, ""
+}
+
+func decodeStoredValue(res []byte, format storedvalue.STORED_VALUE_FORMAT) (storedValue storedvalue.StoredValue, errMessage string) {
+	storedValue, err, pos := storedValue.FromBytesWithFormat(res, format)
+	if err != nil {
+		return storedValue, err.Error()
+	}
+	if pos != len(res) {
+		return storedValue, fmt.Sprintf("Stored value has %d trailing bytes", len(res)-pos)
+	}
+
+	return storedValue, errMessage
+}
 
-	var key *state.Key
-	switch keyType {
-	case STR_ADDRESS:
-		key = &state.Key{Value: &state.Key_Address_{Address: &state.Key_Address{Account: keyData}}}
-	case STR_LOCAL:
-		key = &state.Key{Value: &state.Key_Local_{Local: &state.Key_Local{Hash: keyData}}}
-	case STR_UREF:
-		key = &state.Key{Value: &state.Key_Uref{Uref: &state.Key_URef{Uref: keyData}}}
-	case STR_HASH:
-		key = &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: keyData}}}
+func decodeStoredCLValue(res []byte, format storedvalue.STORED_VALUE_FORMAT, v interface{}) (errMessage string) {
+	storedValue, errMessage := decodeStoredValue(res, format)
+	if errMessage != "" {
+		return errMessage
+	}
+	if storedValue.Type != storedvalue.TYPE_CL_VALUE {
+		return fmt.Sprintf("Stored value type %d is not a CLValue", storedValue.Type)
 	}
 
+	err := storedValue.ClValue.Decode(v)
+	if err != nil {
+		return err.Error()
+	}
+
+	return ""
+}
+
+func queryKey(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	key *state.Key,
+	path []string,
+	protocolVersion *state.ProtocolVersion) (result []byte, errMessage string) {
+
 	r, err := client.Query(
 		context.TODO(),
 		&ipc.QueryRequest{
@@ -232,197 +357,117 @@ func Upgrade(client ipc.ExecutionEngineServiceClient,
 
 // QueryBalance 는 address의 balance를 조회할 때 사용하는 함수.
 //
+// balance는 10진수 mote 문자열이며, 값으로 다루려면 QueryBalanceMotes 를 사용한다.
+func QueryBalance(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	return motesToString(QueryBalanceMotes(client, stateHash, address, protocolVersion))
+}
+
+// QueryBalanceMotes 는 address의 balance를 mote 단위의 U512 로 조회하는 함수.
+//
 // 조회할 state hash와 address를 파라미터로 받아, key를 address로 Query한다.
 // name key에서 name이 mint인 uref를 추출하여 hex string로 변환하고 purse Id를 abi로 변환한 후 hex string으로 변환하여 붙인다.
 // 해당 값을 blake2b256을 하면 local bytes 값이 추출된다. 이 값을 key를 local로 하여 Query한다.
-// 받아온 uref값을 Key로 하여 Query하면 BigInt 형태의 blanace를 return 해준다.
+// 받아온 uref값을 Key로 하여 Query하면 U512 형태의 blanace를 return 해준다.
+// local key는 STORED_VALUE_FORMAT_V1 에만 있으므로 결과는 V1 형식으로 decode 한다.
 //
 // TODO we might be able to merge query balance-like functions.
-func QueryBalance(client ipc.ExecutionEngineServiceClient,
+func QueryBalanceMotes(client ipc.ExecutionEngineServiceClient,
 	stateHash []byte,
 	address []byte,
-	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
-
-	res, errMessage := Query(client, stateHash, STR_ADDRESS, address, []string{}, protocolVersion)
-	if errMessage != "" {
-		return balance, errMessage
-	}
-
-	var storedValue storedvalue.StoredValue
-	storedValue, err, _ := storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
-	}
-	account := storedValue.Account
-	purseID := account.MainPurse.GetAddress()
-	var mintUref []byte
-	for _, namedKey := range account.NamedKeys {
-		if namedKey.Name == STR_MINT {
-			mintUref = namedKey.Key.Uref.Address
-			break
-		}
-	}
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
 
-	localBytes := util.MakeLocalKey(mintUref, purseID)
-
-	res, errMessage = Query(client, stateHash, STR_LOCAL, localBytes, []string{}, protocolVersion)
-	if errMessage != "" {
-		return balance, errMessage
-	}
-
-	storedValue, err, _ = storedValue.FromBytes(res)
+	key, err := storedvalue.NewAccountKey(address)
 	if err != nil {
 		return balance, err.Error()
 	}
-	uref := storedValue.ClValue.ToStateValues().GetKey().GetUref().GetUref()
-
-	res, errMessage = Query(client, stateHash, STR_UREF, uref, []string{}, protocolVersion)
+	res, errMessage := Query(client, stateHash, key, []string{}, protocolVersion)
 	if errMessage != "" {
 		return balance, errMessage
 	}
 
-	storedValue, err, _ = storedValue.FromBytes(res)
-	balance = storedValue.ClValue.ToStateValues().GetBigInt().GetValue()
-
-	return balance, errMessage
-}
-
-func QueryCommission(client ipc.ExecutionEngineServiceClient,
-	stateHash []byte,
-	address []byte,
-	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
-
-	res, errMessage := Query(client, stateHash, STR_ADDRESS, SYSTEM_ACCOUNT, []string{}, protocolVersion)
+	storedValue, errMessage := decodeStoredValue(res, storedvalue.STORED_VALUE_FORMAT_V1)
 	if errMessage != "" {
 		return balance, errMessage
 	}
-
-	var storedValue storedvalue.StoredValue
-	storedValue, err, _ := storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
+	if storedValue.Type != storedvalue.TYPE_ACCOUNT {
+		return balance, fmt.Sprintf("Stored value type %d is not an Account", storedValue.Type)
 	}
 	account := storedValue.Account
-	var popUref []byte
-	for _, namedKey := range account.NamedKeys {
-		if namedKey.Name == STR_POS {
-			popUref = namedKey.Key.Uref.Address
-			break
-		}
-	}
-
-	localBytes := append([]byte{PREFIX_COMMISSION}, address...)
-	localRes := make([]byte, storedvalue.SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(localRes, uint32(len(localBytes)))
-	localRes = append(localRes, localBytes...)
-
-	local := util.MakeLocalKey(popUref, localRes)
-
-	res, errMessage = Query(client, stateHash, STR_LOCAL, local, []string{}, protocolVersion)
+	var purseID [storedvalue.ADDRESS_LENGTH]byte
+	copy(purseID[:], account.MainPurse.GetAddress())
+	mintUref, errMessage := namedKeyURef(account.NamedKeys, STR_MINT)
 	if errMessage != "" {
 		return balance, errMessage
 	}
 
-	storedValue, err, _ = storedValue.FromBytes(res)
+	localKey, err := util.MakeLocalStateKey(mintUref, purseID)
 	if err != nil {
 		return balance, err.Error()
 	}
 
-	balance = storedValue.ClValue.ToStateValues().GetBigInt().GetValue()
-
-	return balance, errMessage
-}
-
-func QueryReward(client ipc.ExecutionEngineServiceClient,
-	stateHash []byte,
-	address []byte,
-	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
-
-	res, errMessage := Query(client, stateHash, STR_ADDRESS, SYSTEM_ACCOUNT, []string{}, protocolVersion)
+	res, errMessage = queryKey(client, stateHash, localKey, []string{}, protocolVersion)
 	if errMessage != "" {
 		return balance, errMessage
 	}
 
-	var storedValue storedvalue.StoredValue
-	storedValue, err, _ := storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
-	}
-	account := storedValue.Account
-	var popUref []byte
-	for _, namedKey := range account.NamedKeys {
-		if namedKey.Name == STR_POS {
-			popUref = namedKey.Key.Uref.Address
-			break
-		}
-	}
-
-	localBytes := append([]byte{PREFIX_REWARD}, address...)
-	localRes := make([]byte, storedvalue.SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(localRes, uint32(len(localBytes)))
-	localRes = append(localRes, localBytes...)
-
-	local := util.MakeLocalKey(popUref, localRes)
-
-	res, errMessage = Query(client, stateHash, STR_LOCAL, local, []string{}, protocolVersion)
+	var purseKey storedvalue.Key
+	errMessage = decodeStoredCLValue(res, storedvalue.STORED_VALUE_FORMAT_V1, &purseKey)
 	if errMessage != "" {
 		return balance, errMessage
 	}
 
-	storedValue, err, _ = storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
+	if purseKey.KeyID() != storedvalue.KEY_ID_UREF {
+		return balance, fmt.Sprintf("Purse key %s is not a uref key", purseKey)
 	}
 
-	balance = storedValue.ClValue.ToStateValues().GetBigInt().GetValue()
+	errMessage = QueryCLValueWithFormat(client, stateHash, purseKey, []string{}, protocolVersion, storedvalue.STORED_VALUE_FORMAT_V1, &balance)
 
 	return balance, errMessage
 }
 
-func QueryStake(client ipc.ExecutionEngineServiceClient,
+func QueryCommission(client ipc.ExecutionEngineServiceClient,
 	stateHash []byte,
 	address []byte,
 	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	return motesToString(QueryCommissionMotes(client, stateHash, address, protocolVersion))
+}
 
-	res, errMessage := Query(client, stateHash, STR_ADDRESS, SYSTEM_ACCOUNT, []string{}, protocolVersion)
-	if errMessage != "" {
-		return balance, errMessage
-	}
-
-	var storedValue storedvalue.StoredValue
-	storedValue, err, _ := storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
-	}
-	account := storedValue.Account
-	var popUref []byte
-	for _, namedKey := range account.NamedKeys {
-		if namedKey.Name == STR_POS {
-			popUref = namedKey.Key.Uref.Address
-			break
-		}
-	}
-
-	localBytes := append([]byte{ACTION_PREFIX_STAKE}, address...)
-	localRes := make([]byte, storedvalue.SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(localRes, uint32(len(localBytes)))
-	localRes = append(localRes, localBytes...)
-
-	local := util.MakeLocalKey(popUref, localRes)
+func QueryCommissionMotes(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
+	return queryPosLocalMotes(client, stateHash, PREFIX_COMMISSION, address, protocolVersion)
+}
 
-	res, errMessage = Query(client, stateHash, STR_LOCAL, local, []string{}, protocolVersion)
-	if errMessage != "" {
-		return balance, errMessage
-	}
+func QueryReward(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	return motesToString(QueryRewardMotes(client, stateHash, address, protocolVersion))
+}
 
-	storedValue, err, _ = storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
-	}
+func QueryRewardMotes(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
+	return queryPosLocalMotes(client, stateHash, PREFIX_REWARD, address, protocolVersion)
+}
 
-	balance = storedValue.ClValue.ToStateValues().GetBigInt().GetValue()
+func QueryStake(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	return motesToString(QueryStakeMotes(client, stateHash, address, protocolVersion))
+}
 
-	return balance, errMessage
+func QueryStakeMotes(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
+	return queryPosLocalMotes(client, stateHash, ACTION_PREFIX_STAKE, address, protocolVersion)
 }
 
 // dapp
@@ -430,91 +475,97 @@ func QueryVoted(client ipc.ExecutionEngineServiceClient,
 	stateHash []byte,
 	address []byte,
 	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	return motesToString(QueryVotedMotes(client, stateHash, address, protocolVersion))
+}
 
-	res, errMessage := Query(client, stateHash, STR_ADDRESS, SYSTEM_ACCOUNT, []string{}, protocolVersion)
-	if errMessage != "" {
-		return balance, errMessage
-	}
-
-	var storedValue storedvalue.StoredValue
-	storedValue, err, _ := storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
-	}
-	account := storedValue.Account
-	var popUref []byte
-	for _, namedKey := range account.NamedKeys {
-		if namedKey.Name == STR_POS {
-			popUref = namedKey.Key.Uref.Address
-			break
-		}
-	}
+func QueryVotedMotes(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
+	return queryPosLocalMotes(client, stateHash, ACTION_PREFIX_VOTED, address, protocolVersion)
+}
 
-	localBytes := append([]byte{ACTION_PREFIX_VOTED}, address...)
-	localRes := make([]byte, storedvalue.SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(localRes, uint32(len(localBytes)))
-	localRes = append(localRes, localBytes...)
+// voter
+func QueryVoting(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	return motesToString(QueryVotingMotes(client, stateHash, address, protocolVersion))
+}
 
-	local := util.MakeLocalKey(popUref, localRes)
+func QueryVotingMotes(client ipc.ExecutionEngineServiceClient,
+	stateHash []byte,
+	address []byte,
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
+	return queryPosLocalMotes(client, stateHash, ACTION_PREFIX_VOTING, address, protocolVersion)
+}
 
-	res, errMessage = Query(client, stateHash, STR_LOCAL, local, []string{}, protocolVersion)
+// motesToString 은 *Motes 조회 결과를 기존 Query 함수의 10진수 문자열 결과로 바꾸는 함수. error 이면 빈 문자열이다.
+func motesToString(motes storedvalue.U512, errMessage string) (string, string) {
 	if errMessage != "" {
-		return balance, errMessage
-	}
-
-	storedValue, err, _ = storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
+		return "", errMessage
 	}
 
-	balance = storedValue.ClValue.ToStateValues().GetBigInt().GetValue()
-
-	return balance, errMessage
+	return motes.String(), errMessage
 }
 
-// voter
-func QueryVoting(client ipc.ExecutionEngineServiceClient,
+// queryPosLocalMotes 는 PoS contract의 local storage에서 prefix와 address로 저장된 U512 값을 조회하는 함수.
+//
+// system account의 named key에서 pos uref를 seed로 하고, prefix byte와 address를 붙인 byte list를 key로 한다.
+// QueryBalanceMotes 와 같이 local key를 사용하므로 결과는 V1 형식으로 decode 한다.
+func queryPosLocalMotes(client ipc.ExecutionEngineServiceClient,
 	stateHash []byte,
+	prefix byte,
 	address []byte,
-	protocolVersion *state.ProtocolVersion) (balance string, errMessage string) {
+	protocolVersion *state.ProtocolVersion) (balance storedvalue.U512, errMessage string) {
 
-	res, errMessage := Query(client, stateHash, STR_ADDRESS, SYSTEM_ACCOUNT, []string{}, protocolVersion)
+	key, err := storedvalue.NewAccountKey(SYSTEM_ACCOUNT)
+	if err != nil {
+		return balance, err.Error()
+	}
+	res, errMessage := Query(client, stateHash, key, []string{}, protocolVersion)
 	if errMessage != "" {
 		return balance, errMessage
 	}
 
-	var storedValue storedvalue.StoredValue
-	storedValue, err, _ := storedValue.FromBytes(res)
-	if err != nil {
-		return balance, err.Error()
+	storedValue, errMessage := decodeStoredValue(res, storedvalue.STORED_VALUE_FORMAT_V1)
+	if errMessage != "" {
+		return balance, errMessage
 	}
-	account := storedValue.Account
-	var popUref []byte
-	for _, namedKey := range account.NamedKeys {
-		if namedKey.Name == STR_POS {
-			popUref = namedKey.Key.Uref.Address
-			break
-		}
+	if storedValue.Type != storedvalue.TYPE_ACCOUNT {
+		return balance, fmt.Sprintf("Stored value type %d is not an Account", storedValue.Type)
 	}
-
-	localBytes := append([]byte{ACTION_PREFIX_VOTING}, address...)
-	localRes := make([]byte, storedvalue.SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(localRes, uint32(len(localBytes)))
-	localRes = append(localRes, localBytes...)
-
-	local := util.MakeLocalKey(popUref, localRes)
-
-	res, errMessage = Query(client, stateHash, STR_LOCAL, local, []string{}, protocolVersion)
+	account := storedValue.Account
+	posUref, errMessage := namedKeyURef(account.NamedKeys, STR_POS)
 	if errMessage != "" {
 		return balance, errMessage
 	}
 
-	storedValue, err, _ = storedValue.FromBytes(res)
+	localKey, err := util.MakeLocalStateKey(posUref, append([]byte{prefix}, address...))
 	if err != nil {
 		return balance, err.Error()
 	}
 
-	balance = storedValue.ClValue.ToStateValues().GetBigInt().GetValue()
+	res, errMessage = queryKey(client, stateHash, localKey, []string{}, protocolVersion)
+	if errMessage != "" {
+		return balance, errMessage
+	}
+
+	errMessage = decodeStoredCLValue(res, storedvalue.STORED_VALUE_FORMAT_V1, &balance)
 
 	return balance, errMessage
 }
+
+// namedKeyURef 는 named keys에서 name 인 uref key의 URef를 찾는 함수.
+func namedKeyURef(namedKeys storedvalue.NamedKeys, name string) (uref storedvalue.URef, errMessage string) {
+	key, ok := namedKeys.Get(name)
+	if !ok {
+		return uref, fmt.Sprintf("Named key %s is not found", name)
+	}
+	uref, ok = key.URef()
+	if !ok {
+		return uref, fmt.Sprintf("Named key %s is not a uref key : %s", name, key)
+	}
+
+	return uref, errMessage
+}
@@ -3,55 +3,68 @@ package integration
 import (
 	"testing"
 
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/denom"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/grpc"
-	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCustomContractCounter(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 
 	// counterDefine
-	rootStateHash, _ = RunCounterDefine(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunCounterDefine(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
 
 	// query
-	storedValue := RunQuery(client, rootStateHash, "address", GENESIS_ADDRESS, []string{"counter", "count"}, protocolVersion)
-	assert.Equal(t, int32(0), storedValue.ClValue.ToStateValues().GetIntValue())
+	storedValue := MustRunQuery(client, rootStateHash, GENESIS_KEY, []string{"counter", "count"}, protocolVersion)
+	value, err := storedValue.ClValue.ToStateValues()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), value.GetIntValue())
 
 	// First counter call
-	rootStateHash, _ = RunCounterCall(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunCounterCall(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
 
 	// query
-	storedValue = RunQuery(client, rootStateHash, "address", GENESIS_ADDRESS, []string{"counter", "count"}, protocolVersion)
-	assert.Equal(t, int32(1), storedValue.ClValue.ToStateValues().GetIntValue())
+	storedValue = MustRunQuery(client, rootStateHash, GENESIS_KEY, []string{"counter", "count"}, protocolVersion)
+	value, err = storedValue.ClValue.ToStateValues()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), value.GetIntValue())
 
 	// Second counter call
-	rootStateHash, _ = RunCounterCall(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunCounterCall(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
 
 	// query
-	storedValue = RunQuery(client, rootStateHash, "address", GENESIS_ADDRESS, []string{"counter", "count"}, protocolVersion)
-	assert.Equal(t, int32(2), storedValue.ClValue.ToStateValues().GetIntValue())
+	storedValue = MustRunQuery(client, rootStateHash, GENESIS_KEY, []string{"counter", "count"}, protocolVersion)
+	value, err = storedValue.ClValue.ToStateValues()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), value.GetIntValue())
 }
 
 func TestTransferToAccount(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	amount := "900000000000000000"
 
-	rootStateHash, _ = RunTransferToAccount(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunTransferToAccount(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
 
 	queryResult, errMessage := grpc.QueryBalance(client, rootStateHash, ADDRESS1, protocolVersion)
 	assert.Equal(t, amount, queryResult)
 	assert.Equal(t, "", errMessage)
+
+	balance, errMessage := grpc.QueryBalanceMotes(client, rootStateHash, ADDRESS1, protocolVersion)
+	assert.Equal(t, "", errMessage)
+	display, err := denom.DEFAULT_DENOMINATION.Format(balance, 4, denom.ROUND_DOWN)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.9000", display)
 }
 
 func TestBondAndUnbond(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	bondAmount := "10000000000000000"
 
 	// bond
-	rootStateHash, bonds := RunBond(client, rootStateHash, GENESIS_ADDRESS, bondAmount, proxyHash, protocolVersion)
+	rootStateHash, bonds := MustRunBond(client, rootStateHash, GENESIS_ADDRESS, bondAmount, proxyHash, protocolVersion)
 	assert.Equal(t, "1000000000000000000", bonds[0].GetStake().GetValue())
 
 	stakeAmount, errMsg := grpc.QueryStake(client, rootStateHash, GENESIS_ADDRESS, protocolVersion)
@@ -63,8 +76,8 @@ func TestBondAndUnbond(t *testing.T) {
 
 	// unbond
 	unbondAmount := "10000000000000000"
-	rootStateHash, bonds = RunUnbond(client, rootStateHash, GENESIS_ADDRESS, unbondAmount, proxyHash, protocolVersion)
-	rootStateHash, bonds = RunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
+	rootStateHash, bonds = MustRunUnbond(client, rootStateHash, GENESIS_ADDRESS, unbondAmount, proxyHash, protocolVersion)
+	rootStateHash, bonds = MustRunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
 	assert.Equal(t, "1000000000000000000", bonds[0].GetStake().GetValue())
 
 	stakeAmount, errMsg = grpc.QueryStake(client, rootStateHash, GENESIS_ADDRESS, protocolVersion)
@@ -74,33 +87,33 @@ func TestBondAndUnbond(t *testing.T) {
 }
 
 func TestDelegate(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	amount := "100"
 
-	rootStateHash, bonds := RunBond(client, rootStateHash, GENESIS_ADDRESS, amount, proxyHash, protocolVersion)
-	rootStateHash, bonds = RunDelegate(client, rootStateHash, GENESIS_ADDRESS, GENESIS_ADDRESS, amount, proxyHash, protocolVersion)
+	rootStateHash, bonds := MustRunBond(client, rootStateHash, GENESIS_ADDRESS, amount, proxyHash, protocolVersion)
+	rootStateHash, bonds = MustRunDelegate(client, rootStateHash, GENESIS_ADDRESS, GENESIS_ADDRESS, amount, proxyHash, protocolVersion)
 	assert.Equal(t, "1000000000000000100", bonds[0].GetStake().GetValue())
 
-	storedValue := RunQuery(client, rootStateHash, "address", SYSTEM_ACCOUNT, []string{"pos"}, protocolVersion)
+	storedValue := MustRunQuery(client, rootStateHash, SYSTEM_ACCOUNT_KEY, []string{"pos"}, protocolVersion)
 	delegators := storedValue.Contract.NamedKeys.GetDelegateFromValidator(GENESIS_ADDRESS)
 	assert.Equal(t, 1, len(delegators))
 	assert.Equal(t, "1000000000000000100", delegators[GENESIS_ADDRESS_HEX])
 }
 
 func TestDelegateFromAnotherAddress(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	amount := "10000000000000000000"
 	delegateAmount := "1000000000000000"
 
-	rootStateHash, _ = RunTransferToAccount(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunTransferToAccount(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
 	balance, errMessage := grpc.QueryBalance(client, rootStateHash, ADDRESS1, protocolVersion)
 	assert.Equal(t, amount, balance)
 	assert.Equal(t, "", errMessage)
 
-	rootStateHash, _ = RunBond(client, rootStateHash, ADDRESS1, delegateAmount, proxyHash, protocolVersion)
-	rootStateHash, _ = RunDelegate(client, rootStateHash, ADDRESS1, GENESIS_ADDRESS, delegateAmount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunBond(client, rootStateHash, ADDRESS1, delegateAmount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunDelegate(client, rootStateHash, ADDRESS1, GENESIS_ADDRESS, delegateAmount, proxyHash, protocolVersion)
 
-	storedValue := RunQuery(client, rootStateHash, "address", SYSTEM_ACCOUNT, []string{"pos"}, protocolVersion)
+	storedValue := MustRunQuery(client, rootStateHash, SYSTEM_ACCOUNT_KEY, []string{"pos"}, protocolVersion)
 	delegators := storedValue.Contract.NamedKeys.GetDelegateFromValidator(GENESIS_ADDRESS)
 	assert.Equal(t, 2, len(delegators))
 	assert.Equal(t, "1000000000000000000", delegators[GENESIS_ADDRESS_HEX])
@@ -108,28 +121,28 @@ func TestDelegateFromAnotherAddress(t *testing.T) {
 }
 
 func TestUndelegation(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	amount := "100"
 
-	rootStateHash, bonds := RunUndelegate(client, rootStateHash, GENESIS_ADDRESS, GENESIS_ADDRESS, amount, proxyHash, protocolVersion)
-	rootStateHash, bonds = RunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
+	rootStateHash, bonds := MustRunUndelegate(client, rootStateHash, GENESIS_ADDRESS, GENESIS_ADDRESS, amount, proxyHash, protocolVersion)
+	rootStateHash, bonds = MustRunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
 	assert.Equal(t, 1, len(bonds))
 }
 
 func TestRedelegation(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	amount := "100"
 
-	rootStateHash, bonds := RunRedelegate(client, rootStateHash, GENESIS_ADDRESS, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
-	rootStateHash, bonds = RunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
+	rootStateHash, bonds := MustRunRedelegate(client, rootStateHash, GENESIS_ADDRESS, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
+	rootStateHash, bonds = MustRunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
 	assert.Equal(t, 2, len(bonds))
 }
 
 func TestVoteAndUnvote(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	voteAmount := "123"
 
-	rootStateHash, _ = RunVote(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, voteAmount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunVote(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, voteAmount, proxyHash, protocolVersion)
 
 	votedAmount, errMsg := grpc.QueryVoted(client, rootStateHash, ADDRESS1_DAPP, protocolVersion)
 	if errMsg != "" {
@@ -144,7 +157,7 @@ func TestVoteAndUnvote(t *testing.T) {
 	assert.Equal(t, voteAmount, votingAmount)
 
 	unvoteAmount := "23"
-	rootStateHash, _ = RunUnvote(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, unvoteAmount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunUnvote(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, unvoteAmount, proxyHash, protocolVersion)
 	votedAmount, errMsg = grpc.QueryVoted(client, rootStateHash, ADDRESS1_DAPP, protocolVersion)
 	if errMsg != "" {
 		panic(errMsg)
@@ -159,20 +172,20 @@ func TestVoteAndUnvote(t *testing.T) {
 }
 
 func TestVoteMoreAccount(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
-	address2 := util.DecodeHexString("03170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314")
-	address3 := util.DecodeHexString("f0f84944e0ccfa9e67383e6a448291787d208c8e46adc849f714078663d1dd36")
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	address2 := storedvalue.MustParseAddress("03170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314")
+	address3 := storedvalue.MustParseAddress("f0f84944e0ccfa9e67383e6a448291787d208c8e46adc849f714078663d1dd36")
 
-	address2_dapp := util.DecodeHexString("0103170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314")
-	address3_dapp := util.DecodeHexString("01f0f84944e0ccfa9e67383e6a448291787d208c8e46adc849f714078663d1dd36")
+	address2_dapp := storedvalue.MustParseAddress("0103170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314")
+	address3_dapp := storedvalue.MustParseAddress("01f0f84944e0ccfa9e67383e6a448291787d208c8e46adc849f714078663d1dd36")
 
 	amount1 := "100"
 	amount2 := "200"
 	amount3 := "300"
 
-	rootStateHash, _ = RunVote(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount1, proxyHash, protocolVersion)
-	rootStateHash, _ = RunVote(client, rootStateHash, GENESIS_ADDRESS, address2, amount2, proxyHash, protocolVersion)
-	rootStateHash, _ = RunVote(client, rootStateHash, GENESIS_ADDRESS, address3, amount3, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunVote(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount1, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunVote(client, rootStateHash, GENESIS_ADDRESS, address2, amount2, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunVote(client, rootStateHash, GENESIS_ADDRESS, address3, amount3, proxyHash, protocolVersion)
 
 	address1DappVoterAmount, errMsg := grpc.QueryVoted(client, rootStateHash, ADDRESS1_DAPP, protocolVersion)
 	if errMsg != "" {
@@ -199,14 +212,14 @@ func TestVoteMoreAccount(t *testing.T) {
 }
 
 func TestStepAndClaim(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 	amount := "1000000000000000000"
 	stakeAmount := "100000000000000000"
 
         // delegate from ADDRESS1 to GENESIS_ADDRESS
-	rootStateHash, _ = RunTransferToAccount(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
-	rootStateHash, _ = RunBond(client, rootStateHash, ADDRESS1, stakeAmount, proxyHash, protocolVersion)
-	rootStateHash, _ = RunDelegate(client, rootStateHash, ADDRESS1, GENESIS_ADDRESS, stakeAmount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunTransferToAccount(client, rootStateHash, GENESIS_ADDRESS, ADDRESS1, amount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunBond(client, rootStateHash, ADDRESS1, stakeAmount, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunDelegate(client, rootStateHash, ADDRESS1, GENESIS_ADDRESS, stakeAmount, proxyHash, protocolVersion)
 	beforeAddress1Amount, errMessage := grpc.QueryBalance(client, rootStateHash, ADDRESS1, protocolVersion)
 	assert.Equal(t, "", errMessage)
 	beforeGenesisAmount, errMessage := grpc.QueryBalance(client, rootStateHash, GENESIS_ADDRESS, protocolVersion)
@@ -214,7 +227,7 @@ func TestStepAndClaim(t *testing.T) {
 
 	// run step 10 times
 	for i := 0; i < 10; i++ {
-		rootStateHash, _ = RunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
+		rootStateHash, _ = MustRunStep(client, rootStateHash, SYSTEM_ACCOUNT, proxyHash, protocolVersion)
 	}
 	afterStepAddress1Amount, errMessage := grpc.QueryBalance(client, rootStateHash, ADDRESS1, protocolVersion)
 	assert.Equal(t, "", errMessage)
@@ -235,7 +248,7 @@ func TestStepAndClaim(t *testing.T) {
 	assert.NotEqual(t, "", step10GenesisAddressCommission)
 
 	// claim Commission
-	rootStateHash, _ = RunClaimCommission(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunClaimCommission(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
 	afterClaimCommissionAddress1Amount, errMessage := grpc.QueryBalance(client, rootStateHash, ADDRESS1, protocolVersion)
 	assert.Equal(t, "", errMessage)
 	assert.Equal(t, beforeAddress1Amount, afterClaimCommissionAddress1Amount)
@@ -244,8 +257,8 @@ func TestStepAndClaim(t *testing.T) {
 	assert.NotEqual(t, beforeGenesisAmount, afterClaimCommissionGenesisAmount)
 
 	// claim Reward
-	rootStateHash, _ = RunClaimReward(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
-	rootStateHash, _ = RunClaimReward(client, rootStateHash, ADDRESS1, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunClaimReward(client, rootStateHash, GENESIS_ADDRESS, proxyHash, protocolVersion)
+	rootStateHash, _ = MustRunClaimReward(client, rootStateHash, ADDRESS1, proxyHash, protocolVersion)
 	afterClaimRewardAddress1Amount, errMessage := grpc.QueryBalance(client, rootStateHash, ADDRESS1, protocolVersion)
 	assert.Equal(t, "", errMessage)
 	assert.NotEqual(t, afterClaimCommissionAddress1Amount, afterClaimRewardAddress1Amount)
@@ -270,13 +283,13 @@ func xTestImport(t *testing.T) {
 	genesisAccounts := []*ipc.ChainSpec_GenesisAccount{
 		{
 			PublicKey:    GENESIS_ADDRESS,
-			Balance:      &state.BigInt{Value: INITIAL_BALANCE, BitWidth: 512},
-			BondedAmount: &state.BigInt{Value: INITIAL_BOND_AMOUNT, BitWidth: 512},
+			Balance:      storedvalue.MustParseU512(INITIAL_BALANCE).ToStateBigInt(),
+			BondedAmount: storedvalue.MustParseU512(INITIAL_BOND_AMOUNT).ToStateBigInt(),
 		},
 		{
 			PublicKey:    ADDRESS1,
-			Balance:      &state.BigInt{Value: INITIAL_BALANCE, BitWidth: 512},
-			BondedAmount: &state.BigInt{Value: INITIAL_BOND_AMOUNT, BitWidth: 512},
+			Balance:      storedvalue.MustParseU512(INITIAL_BALANCE).ToStateBigInt(),
+			BondedAmount: storedvalue.MustParseU512(INITIAL_BOND_AMOUNT).ToStateBigInt(),
 		},
 	}
 
@@ -286,9 +299,9 @@ func xTestImport(t *testing.T) {
 	REWARD_FROM_GENESIS_ADDR := "123"
 	COMMISSION_FROM_GENSIS_ADDR := "456"
 
-	client, rootStateHash, _, protocolVersion := InitalRunGenensis(genesisAccounts)
+	client, rootStateHash, _, protocolVersion := MustInitalRunGenensis(genesisAccounts)
 
-	storedValue := RunQuery(client, rootStateHash, "address", SYSTEM_ACCOUNT, []string{"pos"}, protocolVersion)
+	storedValue := MustRunQuery(client, rootStateHash, SYSTEM_ACCOUNT_KEY, []string{"pos"}, protocolVersion)
 
 	genesisAddressDelegateInfo := storedValue.Contract.NamedKeys.GetDelegateFromDelegator(GENESIS_ADDRESS)
 	assert.Equal(t, 2, len(genesisAddressDelegateInfo))
@@ -306,11 +319,12 @@ func xTestImport(t *testing.T) {
 }
 
 func TestStandardPayment(t *testing.T) {
-	client, rootStateHash, proxyHash, protocolVersion := InitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
+	client, rootStateHash, proxyHash, protocolVersion := MustInitalRunGenensis(DEFAULT_GENESIS_ACCOUNT)
 
-	paymentStr := GetPaymentArgsJson(BASIC_FEE)
+	paymentStr, err := GetPaymentArgsJson(BASIC_FEE)
+	assert.NoError(t, err)
 
-	rootStateHash, _ = RunExecute(client, rootStateHash, GENESIS_ADDRESS, util.HASH, proxyHash, paymentStr, proxyHash, "1000000000000000000", protocolVersion)
+	rootStateHash, _ = MustRunExecute(client, rootStateHash, GENESIS_ADDRESS, util.HASH, proxyHash, paymentStr, proxyHash, "1000000000000000000", protocolVersion)
 
 	queryResult, errMessage := grpc.QueryBalance(client, rootStateHash, GENESIS_ADDRESS, protocolVersion)
 	assert.Equal(t, "49998900000000000000000", queryResult)
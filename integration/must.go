@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/util"
+)
+
+// 아래 Must* 함수들은 test에서 사용하기 위해 error 대신 panic 하는 wrapper 함수이다.
+
+func MustInitalRunGenensis(genesisAccounts []*ipc.ChainSpec_GenesisAccount) (ipc.ExecutionEngineServiceClient, []byte, []byte, *state.ProtocolVersion) {
+	client, rootStateHash, proxyHash, protocolVersion, err := InitalRunGenensis(genesisAccounts)
+	if err != nil {
+		panic(err)
+	}
+
+	return client, rootStateHash, proxyHash, protocolVersion
+}
+
+func MustRunCounterDefine(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunCounterDefine(client, stateHash, runAddress, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunCounterCall(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunCounterCall(client, stateHash, runAddress, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunTransferToAccount(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	toAddress []byte, amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunTransferToAccount(client, stateHash, runAddress, toAddress, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunBond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunBond(client, stateHash, runAddress, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunUnbond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunUnbond(client, stateHash, runAddress, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunDelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	validator []byte, amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunDelegate(client, stateHash, runAddress, validator, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunUndelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	validator []byte, amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunUndelegate(client, stateHash, runAddress, validator, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunRedelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	srcValidator []byte, destValidator []byte, amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunRedelegate(client, stateHash, runAddress, srcValidator, destValidator, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunVote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	hash []byte, amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunVote(client, stateHash, runAddress, hash, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunUnvote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	hash []byte, amount string,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunUnvote(client, stateHash, runAddress, hash, amount, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunStep(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunStep(client, stateHash, runAddress, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunClaimCommission(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunClaimCommission(client, stateHash, runAddress, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunClaimReward(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunClaimReward(client, stateHash, runAddress, proxyHash, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunExecute(client ipc.ExecutionEngineServiceClient, stateHash []byte,
+	fromAddress []byte,
+	sessionType util.ContractType, sessionData []byte, sessionArgsStr string,
+	proxyHash []byte, fee string,
+	protocolVersion *state.ProtocolVersion) ([]byte, []*ipc.Bond) {
+	stateHash, bonds, err := RunExecute(client, stateHash, fromAddress, sessionType, sessionData, sessionArgsStr, proxyHash, fee, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return stateHash, bonds
+}
+
+func MustRunQuery(client ipc.ExecutionEngineServiceClient, stateHash []byte, key storedvalue.Key, path []string, protocolVersion *state.ProtocolVersion) storedvalue.StoredValue {
+	storedValue, err := RunQuery(client, stateHash, key, path, protocolVersion)
+	if err != nil {
+		panic(err)
+	}
+
+	return storedValue
+}
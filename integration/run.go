@@ -3,10 +3,12 @@ package integration
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/denom"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/grpc"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -34,18 +36,21 @@ const (
 
 var (
 	SYSTEM_ACCOUNT  = make([]byte, 32)
-	GENESIS_ADDRESS = util.DecodeHexString(GENESIS_ADDRESS_HEX)
-	ADDRESS1        = util.DecodeHexString(ADDRESS1_HEX)
-	ADDRESS1_DAPP   = util.DecodeHexString(ADDRESS1_DAPP_HEX)
-	DAPP_HASH       = util.DecodeHexString(DAPP_HASH_HEX)
+	GENESIS_ADDRESS = storedvalue.MustParseAddress(GENESIS_ADDRESS_HEX)
+	ADDRESS1        = storedvalue.MustParseAddress(ADDRESS1_HEX)
+	ADDRESS1_DAPP   = storedvalue.MustParseAddress(ADDRESS1_DAPP_HEX)
+	DAPP_HASH       = storedvalue.MustParseAddress(DAPP_HASH_HEX)
+
+	SYSTEM_ACCOUNT_KEY = storedvalue.MustParseKey("account-" + hex.EncodeToString(SYSTEM_ACCOUNT))
+	GENESIS_KEY        = storedvalue.MustParseKey("account-" + GENESIS_ADDRESS_HEX)
 
 	DEFAULT_GENESIS_ACCOUNT = []*ipc.ChainSpec_GenesisAccount{{
 		PublicKey:    GENESIS_ADDRESS,
-		Balance:      &state.BigInt{Value: INITIAL_BALANCE, BitWidth: 512},
-		BondedAmount: &state.BigInt{Value: INITIAL_BOND_AMOUNT, BitWidth: 512}}}
+		Balance:      storedvalue.MustParseU512(INITIAL_BALANCE).ToStateBigInt(),
+		BondedAmount: storedvalue.MustParseU512(INITIAL_BOND_AMOUNT).ToStateBigInt()}}
 )
 
-func GetPaymentArgsJson(fee string) string {
+func GetPaymentArgsJson(fee string) (string, error) {
 	paymentArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -63,17 +68,16 @@ func GetPaymentArgsJson(fee string) string {
 						U512: &state.CLValueInstance_U512{
 							Value: fee}}}}}}
 
-	paymentArgsStr, err := util.DeployArgsToJsonString(paymentArgs)
-	if err != nil {
-		panic(err)
-	}
-	return paymentArgsStr
+	return util.DeployArgsToJsonString(paymentArgs)
 }
 
-func InitalRunGenensis(genesisAccounts []*ipc.ChainSpec_GenesisAccount) (ipc.ExecutionEngineServiceClient, []byte, []byte, *state.ProtocolVersion) {
+func InitalRunGenensis(genesisAccounts []*ipc.ChainSpec_GenesisAccount) (
+	client ipc.ExecutionEngineServiceClient, rootStateHash []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion, err error) {
 	// Init variable
-	emptyStateHash := util.DecodeHexString(util.StrEmptyStateHash)
-	rootStateHash := emptyStateHash
+	rootStateHash, err = util.DecodeHexString(util.StrEmptyStateHash)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
 	costs := map[string]uint32{
 		"regular":            1,
@@ -87,11 +91,14 @@ func InitalRunGenensis(genesisAccounts []*ipc.ChainSpec_GenesisAccount) (ipc.Exe
 		"opcodes-multiplier": 3,
 		"opcodes-divisor":    8}
 
-	protocolVersion := storedvalue.NewProtocolVersion(1, 0, 0).ToStateValue()
+	protocolVersion = storedvalue.NewProtocolVersion(1, 0, 0).ToStateValue()
 
 	// Connect to ee sock.
 	socketPath := os.Getenv("HOME") + `/.casperlabs/.casper-node.sock`
-	client := grpc.Connect(socketPath)
+	client, err = grpc.Connect(socketPath)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
 	// run genesis
 	println(`RunGenesis`)
@@ -99,12 +106,12 @@ func InitalRunGenensis(genesisAccounts []*ipc.ChainSpec_GenesisAccount) (ipc.Exe
 		CHAIN_NAME, genesisAccounts, protocolVersion, costs,
 		"./contracts/hdac_mint_install.wasm", "./contracts/pop_install.wasm", "./contracts/standard_payment_install.wasm")
 	if err != nil {
-		panic(err)
+		return nil, nil, nil, nil, err
 	}
 
 	response, err := grpc.RunGenesis(client, genesisConfig)
 	if err != nil {
-		panic(err)
+		return nil, nil, nil, nil, err
 	}
 
 	switch response.GetResult().(type) {
@@ -112,42 +119,51 @@ func InitalRunGenensis(genesisAccounts []*ipc.ChainSpec_GenesisAccount) (ipc.Exe
 		rootStateHash = response.GetSuccess().GetPoststateHash()
 		// effects = response.GetSuccess().GetEffect().GetTransformMap()
 	case *ipc.GenesisResponse_FailedDeploy:
-		panic(response.GetFailedDeploy().GetMessage())
+		return nil, nil, nil, nil, errors.New(response.GetFailedDeploy().GetMessage())
 	}
 
-	queryResult10, errMessage := grpc.Query(client, rootStateHash, "address", SYSTEM_ACCOUNT, []string{}, protocolVersion)
+	queryResult10, errMessage := grpc.Query(client, rootStateHash, SYSTEM_ACCOUNT_KEY, []string{}, protocolVersion)
 	if errMessage != "" {
-		panic(errMessage)
+		return nil, nil, nil, nil, errors.New(errMessage)
 	}
 	var storedValue storedvalue.StoredValue
-	storedValue.FromBytes(queryResult10)
 	storedValue, err, _ = storedValue.FromBytes(queryResult10)
 	if err != nil {
-		panic(err)
+		return nil, nil, nil, nil, err
 	}
 
-	proxyHash := storedValue.Account.NamedKeys[0].Key.Hash
+	// system account의 named keys 중 hash key는 proxy contract 뿐이다. (mint, pos는 uref key)
+	hashKeys := storedValue.Account.NamedKeys.FilterByKeyID(storedvalue.KEY_ID_HASH)
+	if len(hashKeys) != 1 {
+		return nil, nil, nil, nil, fmt.Errorf("System account has %d hash named keys, but expected only the proxy", len(hashKeys))
+	}
+	proxyHash = hashKeys[0].Key.Address()
 	println("Proxy hash : " + util.EncodeToHexString(proxyHash))
-	println(errMessage)
 
-	return client, rootStateHash, proxyHash, protocolVersion
+	return client, rootStateHash, proxyHash, protocolVersion, nil
 }
 
-func RunCounterDefine(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
-	counterDefineCode := util.LoadWasmFile("./contracts/counter_define.wasm")
+func RunCounterDefine(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
+	counterDefineCode, err := util.LoadWasmFile("./contracts/counter_define.wasm")
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return RunExecute(client, stateHash, runAddress, util.WASM, counterDefineCode, "", proxyHash, BASIC_FEE, protocolVersion)
 }
 
-func RunCounterCall(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
-	counterCallCode := util.LoadWasmFile("./contracts/counter_call.wasm")
+func RunCounterCall(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte, proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
+	counterCallCode, err := util.LoadWasmFile("./contracts/counter_call.wasm")
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return RunExecute(client, stateHash, runAddress, util.WASM, counterCallCode, "", proxyHash, BASIC_FEE, protocolVersion)
 }
 
 func RunTransferToAccount(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	toAddress []byte, amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -173,7 +189,7 @@ func RunTransferToAccount(client ipc.ExecutionEngineServiceClient, stateHash []b
 							Value: amount}}}}}}
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, BASIC_FEE, protocolVersion)
@@ -181,7 +197,7 @@ func RunTransferToAccount(client ipc.ExecutionEngineServiceClient, stateHash []b
 
 func RunBond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -201,7 +217,7 @@ func RunBond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddre
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, BASIC_FEE, protocolVersion)
@@ -209,7 +225,7 @@ func RunBond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddre
 
 func RunUnbond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -232,7 +248,7 @@ func RunUnbond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAdd
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, BASIC_FEE, protocolVersion)
@@ -240,7 +256,7 @@ func RunUnbond(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAdd
 
 func RunDelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	validator []byte, amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -267,7 +283,7 @@ func RunDelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runA
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
@@ -275,7 +291,7 @@ func RunDelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runA
 
 func RunUndelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	validator []byte, amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -305,7 +321,7 @@ func RunUndelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, ru
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
@@ -313,7 +329,7 @@ func RunUndelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, ru
 
 func RunRedelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	srcValidator []byte, destValidator []byte, amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -349,7 +365,7 @@ func RunRedelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, ru
 										Value: amount}}}}}}}}}
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
@@ -357,7 +373,7 @@ func RunRedelegate(client ipc.ExecutionEngineServiceClient, stateHash []byte, ru
 
 func RunVote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	hash []byte, amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -384,7 +400,7 @@ func RunVote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddre
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
@@ -392,7 +408,7 @@ func RunVote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddre
 
 func RunUnvote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
 	hash []byte, amount string,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -422,14 +438,14 @@ func RunUnvote(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAdd
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
 }
 
 func RunStep(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 
 	res, err := client.Step(
 		context.TODO(),
@@ -440,20 +456,20 @@ func RunStep(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddre
 		},
 	)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	stateHash, bonds, errMessage := grpc.Commit(client, res.GetSuccess().GetPostStateHash(), res.GetSuccess().GetEffect().TransformMap, protocolVersion)
 	if errMessage != "" {
-		panic(errMessage)
+		return nil, nil, errors.New(errMessage)
 	}
 	printCommitResult(stateHash, bonds)
 
-	return stateHash, bonds
+	return stateHash, bonds, nil
 }
 
 func RunClaimCommission(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -465,14 +481,14 @@ func RunClaimCommission(client ipc.ExecutionEngineServiceClient, stateHash []byt
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
 }
 
 func RunClaimReward(client ipc.ExecutionEngineServiceClient, stateHash []byte, runAddress []byte,
-	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
+	proxyHash []byte, protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
 	sessionArgs := []*consensus.Deploy_Arg{
 		&consensus.Deploy_Arg{
 			Name: "method",
@@ -484,7 +500,7 @@ func RunClaimReward(client ipc.ExecutionEngineServiceClient, stateHash []byte, r
 
 	sessionArgsStr, err := util.DeployArgsToJsonString(sessionArgs)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	return RunExecute(client, stateHash, runAddress, util.HASH, proxyHash, sessionArgsStr, proxyHash, ADVANCED_FEE, protocolVersion)
@@ -494,42 +510,52 @@ func RunExecute(client ipc.ExecutionEngineServiceClient, stateHash []byte,
 	fromAddress []byte,
 	sessionType util.ContractType, sessionData []byte, sessionArgsStr string,
 	proxyHash []byte, fee string,
-	protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond) {
-	timestamp := time.Now().Unix()
+	protocolVersion *state.ProtocolVersion) (resultStateHash []byte, bonds []*ipc.Bond, err error) {
+	now := time.Now()
+	timestamp := now.UnixNano() / int64(time.Millisecond)
 
-	paymentArgsStr := GetPaymentArgsJson(fee)
+	paymentArgsStr, err := GetPaymentArgsJson(fee)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	deploy, _ := util.MakeDeploy(fromAddress, sessionType, sessionData, sessionArgsStr, util.HASH, proxyHash, paymentArgsStr, uint64(10), timestamp, CHAIN_NAME)
+	_, deploy, err := util.NewDeployBuilder(fromAddress, CHAIN_NAME).
+		WithSessionJson(sessionType, sessionData, sessionArgsStr).
+		WithPaymentJson(util.HASH, proxyHash, paymentArgsStr).
+		WithGasPrice(uint64(10)).
+		WithTimestamp(now).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
 	deploys := util.MakeInitDeploys()
 	deploys = util.AddDeploy(deploys, deploy)
 
 	res, err := grpc.Execute(client, stateHash, timestamp, deploys, protocolVersion)
+	if err != nil {
+		return nil, nil, err
+	}
 	effect, err := executeErrorHandler(res)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	stateHash, bonds, errMessage := grpc.Commit(client, stateHash, effect, protocolVersion)
 	if errMessage != "" {
-		panic(errMessage)
+		return nil, nil, errors.New(errMessage)
 	}
 	printCommitResult(stateHash, bonds)
 
-	return stateHash, bonds
+	return stateHash, bonds, nil
 }
 
-func RunQuery(client ipc.ExecutionEngineServiceClient, stateHash []byte, types string, value []byte, path []string, protocolVersion *state.ProtocolVersion) storedvalue.StoredValue {
-	var storedValue storedvalue.StoredValue
-	queryResult, errMessage := grpc.Query(client, stateHash, types, value, path, protocolVersion)
+func RunQuery(client ipc.ExecutionEngineServiceClient, stateHash []byte, key storedvalue.Key, path []string, protocolVersion *state.ProtocolVersion) (storedvalue.StoredValue, error) {
+	storedValue, errMessage := grpc.QueryStoredValue(client, stateHash, key, path, protocolVersion, storedvalue.DefaultStoredValueFormatTable())
 	if errMessage != "" {
-		panic(errMessage)
-	}
-	storedValue, err, _ := storedValue.FromBytes(queryResult)
-	if err != nil {
-		panic(err)
+		return storedValue, errors.New(errMessage)
 	}
 
-	return storedValue
+	return storedValue, nil
 }
 
 func executeErrorHandler(r *ipc.ExecuteResponse) (effects []*transforms.TransformEntry, err error) {
@@ -558,7 +584,11 @@ func executeErrorHandler(r *ipc.ExecuteResponse) (effects []*transforms.Transfor
 func printCommitResult(stateHash []byte, bonds []*ipc.Bond) {
 	println("State hash : " + hex.EncodeToString(stateHash))
 	for _, bond := range bonds {
-		println(hex.EncodeToString(bond.ValidatorPublicKey) + " : " + bond.GetStake().GetValue())
+		stake := bond.GetStake().GetValue()
+		if display, err := denom.DEFAULT_DENOMINATION.FormatMotes(stake, 4, denom.ROUND_DOWN); err == nil {
+			stake += " (" + denom.DEFAULT_DENOMINATION.WithSymbol(display) + ")"
+		}
+		println(hex.EncodeToString(bond.ValidatorPublicKey) + " : " + stake)
 	}
 	println()
 }
@@ -2,7 +2,6 @@ package storedvalue
 
 import (
 	"encoding/binary"
-	"fmt"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 )
@@ -18,11 +17,11 @@ const (
 )
 
 type Account struct {
-	PublicKey        []byte
-	NamedKeys        NamedKeys
-	MainPurse        URef
-	AssociatedKeys   []AssociatedKey
-	ActionThresholds ActionThresholds
+	PublicKey        []byte           `json:"public_key"`
+	NamedKeys        NamedKeys        `json:"named_keys"`
+	MainPurse        URef             `json:"main_purse"`
+	AssociatedKeys   []AssociatedKey  `json:"associated_keys"`
+	ActionThresholds ActionThresholds `json:"action_thresholds"`
 }
 
 func NewAccount(publicKey []byte, namedKeys NamedKeys, purseId URef, associatedKeys []AssociatedKey, actionThresholds ActionThresholds) Account {
@@ -36,44 +35,44 @@ func NewAccount(publicKey []byte, namedKeys NamedKeys, purseId URef, associatedK
 }
 
 func (a Account) FromBytes(src []byte) (account Account, err error, pos int) {
+	return accountFromBytes(src, STORED_VALUE_FORMAT_V1)
+}
+
+func accountFromBytes(src []byte, format STORED_VALUE_FORMAT) (account Account, err error, pos int) {
 	pos = 0
+	if err := checkLength(src, pos, ADDRESS_LENGTH, "Account public key"); err != nil {
+		return Account{}, err, pos
+	}
 	publicKey := src[pos:ADDRESS_LENGTH]
 	pos += ADDRESS_LENGTH
 
 	// NamedKeys
-	namedKeys := NamedKeys{}
-	namedKeysSizeBytes := src[pos : pos+SIZE_LENGTH]
-	pos += SIZE_LENGTH
-	namedKeysSize := int(binary.LittleEndian.Uint32(namedKeysSizeBytes))
-	for i := 0; i < namedKeysSize; i++ {
-		var namedKey NamedKey
-		namedKey, err, length := namedKey.FromBytes(src[pos:])
-		if err != nil {
-			return Account{}, err, pos
-		}
-		pos += length
-
-		namedKeys = append(namedKeys, namedKey)
+	namedKeys, err, length := namedKeysFromBytes(src[pos:], format)
+	if err != nil {
+		return Account{}, shiftDecodeError(err, pos), pos
 	}
+	pos += length
 
 	// Purse ID
 	var purseID URef
-	purseID, err, length := purseID.FromBytes(src[pos:])
+	purseID, err, length = purseID.FromBytes(src[pos:])
 	if err != nil {
-		return Account{}, err, pos
+		return Account{}, shiftDecodeError(err, pos), pos
 	}
 	pos += length
 
 	// Associate Key
 	associatedKeys := []AssociatedKey{}
-	associatedKeysSizeBytes := src[pos : pos+SIZE_LENGTH]
+	associateKeySize, err := sizeFromBytes(src, pos, "AssociatedKeys")
+	if err != nil {
+		return Account{}, err, pos
+	}
 	pos += SIZE_LENGTH
-	associateKeySize := int(binary.LittleEndian.Uint32(associatedKeysSizeBytes))
 	for i := 0; i < associateKeySize; i++ {
 		var associatedKey AssociatedKey
 		associatedKey, err, length := associatedKey.FromBytes(src[pos:])
 		if err != nil {
-			return Account{}, err, pos
+			return Account{}, shiftDecodeError(err, pos), pos
 		}
 		pos += length
 
@@ -84,7 +83,7 @@ func (a Account) FromBytes(src []byte) (account Account, err error, pos int) {
 	var actionThresholds ActionThresholds
 	actionThresholds, err, length = actionThresholds.FromBytes(src[pos:])
 	if err != nil {
-		return Account{}, err, pos
+		return Account{}, shiftDecodeError(err, pos), pos
 	}
 	pos += length
 
@@ -92,19 +91,15 @@ func (a Account) FromBytes(src []byte) (account Account, err error, pos int) {
 }
 
 func (a Account) ToBytes() []byte {
-	res := a.PublicKey
+	res := append([]byte{}, a.PublicKey...)
 
-	namedKeysLengthBytes := make([]byte, SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(namedKeysLengthBytes, uint32(len(a.NamedKeys)))
-	res = append(res, namedKeysLengthBytes...)
-	for _, namedKey := range a.NamedKeys {
-		res = append(res, namedKey.ToBytes()...)
-	}
+	res = append(res, a.NamedKeys.ToBytes()...)
 
 	res = append(res, a.MainPurse.ToBytes()...)
 
 	associatedKeysLengthBytes := make([]byte, SIZE_LENGTH)
 	binary.LittleEndian.PutUint32(associatedKeysLengthBytes, uint32(len(a.AssociatedKeys)))
+	res = append(res, associatedKeysLengthBytes...)
 	for _, associatedKey := range a.AssociatedKeys {
 		res = append(res, associatedKey.ToBytes()...)
 	}
@@ -140,7 +135,7 @@ func (a Account) FromStateValue(state *state.Account) (Account, error) {
 		var namedKey NamedKey
 		namedKey, err := namedKey.FromStateValue(stateNamedKey)
 		if err != nil {
-			return Account{}, nil
+			return Account{}, err
 		}
 		namedKeys = append(namedKeys, namedKey)
 	}
@@ -156,7 +151,7 @@ func (a Account) FromStateValue(state *state.Account) (Account, error) {
 		namedKeys,
 		NewURef(state.GetMainPurse().GetUref(), state.GetMainPurse().GetAccessRights()),
 		associatedKeys,
-		NewActionThresholds(state.ActionThresholds.GetDeploymentThreshold(), state.ActionThresholds.GetKeyManagementThreshold())), nil
+		NewActionThresholds(state.GetActionThresholds().GetDeploymentThreshold(), state.GetActionThresholds().GetKeyManagementThreshold())), nil
 }
 
 type AssociatedKey struct {
@@ -174,8 +169,8 @@ func NewAssociatedKey(publicKey []byte, weight uint32) AssociatedKey {
 func (a AssociatedKey) FromBytes(src []byte) (associatedKey AssociatedKey, err error, pos int) {
 	pos = 0
 
-	if len(src) < ASSOCIATED_KEY_LENGTH+ASSOCIATED_KEY_WEIGHT_LENGTH {
-		return AssociatedKey{}, fmt.Errorf("AssociatedKey more than %d, but %d", ASSOCIATED_KEY_LENGTH+ASSOCIATED_KEY_WEIGHT_LENGTH, len(src)), pos
+	if err := checkLength(src, pos, ASSOCIATED_KEY_SERIALIZED_LENGTH, "AssociatedKey"); err != nil {
+		return AssociatedKey{}, err, pos
 	}
 
 	publicKey := src[pos:ASSOCIATED_KEY_LENGTH]
@@ -187,13 +182,10 @@ func (a AssociatedKey) FromBytes(src []byte) (associatedKey AssociatedKey, err e
 }
 
 func (a AssociatedKey) ToBytes() []byte {
-	res := a.PublicKey
+	res := make([]byte, 0, ASSOCIATED_KEY_SERIALIZED_LENGTH)
+	res = append(res, a.PublicKey...)
 
-	weightBytes := make([]byte, SIZE_LENGTH)
-	binary.BigEndian.PutUint32(weightBytes, a.Weight)
-
-	res = append(res, weightBytes...)
-	return res
+	return append(res, byte(a.Weight))
 }
 
 func (a AssociatedKey) ToStateValue() *state.Account_AssociatedKey {
@@ -204,7 +196,7 @@ func (a AssociatedKey) ToStateValue() *state.Account_AssociatedKey {
 }
 
 type ActionThresholds struct {
-	DeploymentThreshold    uint32 `json:"deployment_threshold`
+	DeploymentThreshold    uint32 `json:"deployment_threshold"`
 	KeyManagementThreshold uint32 `json:"key_management_threshold"`
 }
 
@@ -217,8 +209,8 @@ func NewActionThresholds(deploymentThreshold uint32, keyManagementThreshold uint
 
 func (a ActionThresholds) FromBytes(src []byte) (actionThresholds ActionThresholds, err error, pos int) {
 	pos = 0
-	if len(src) < ACTION_THRESHOLD_DEPLOYMENT_LENGTH+ACTION_THRESHOLD_KEY_MANAGEMENT_LENGTH {
-		return ActionThresholds{}, fmt.Errorf("ActionThresholds more than %d, but %d", ACTION_THRESHOLD_DEPLOYMENT_LENGTH+ACTION_THRESHOLD_KEY_MANAGEMENT_LENGTH, len(src)), pos
+	if err := checkLength(src, pos, ACTION_THRESHOLD_DEPLOYMENT_LENGTH+ACTION_THRESHOLD_KEY_MANAGEMENT_LENGTH, "ActionThresholds"); err != nil {
+		return ActionThresholds{}, err, pos
 	}
 
 	deployment := uint32(src[pos])
@@ -27,10 +27,10 @@ func TestAccountAbi(t *testing.T) {
 		account.NamedKeys[0].Name)
 	assert.Equal(t,
 		mintAddressBytes,
-		account.NamedKeys[0].Key.Uref.Address)
+		account.NamedKeys[0].Key.Address())
 	assert.Equal(t,
 		state.Key_URef_READ,
-		account.NamedKeys[0].Key.Uref.AccessRights)
+		account.NamedKeys[0].Key.AccessRights())
 
 	posAddressBytes, err := hex.DecodeString("bb0d91b8604970a269bf96ac55de5fa416135e2837d88a0bac938e2eca2d0fe2")
 	assert.NoError(t, err)
@@ -39,10 +39,10 @@ func TestAccountAbi(t *testing.T) {
 		account.NamedKeys[1].Name)
 	assert.Equal(t,
 		posAddressBytes,
-		account.NamedKeys[1].Key.Uref.Address)
+		account.NamedKeys[1].Key.Address())
 	assert.Equal(t,
 		state.Key_URef_READ,
-		account.NamedKeys[1].Key.Uref.AccessRights)
+		account.NamedKeys[1].Key.AccessRights())
 
 	purseIdAddress, err := hex.DecodeString("2efe91034583b378b4b9ffcc62b642650f5d455c4665f4206168ed0637ff7a70")
 	assert.NoError(t, err)
@@ -0,0 +1,249 @@
+package storedvalue
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+)
+
+const (
+	// DAPP_ADDRESS_PREFIX 는 dapp(contract) hash 앞에 붙는 1 byte prefix.
+	DAPP_ADDRESS_PREFIX = 0x01
+	DAPP_ADDRESS_LENGTH = ADDRESS_LENGTH + 1
+)
+
+type ADDRESS_TYPE int
+
+const (
+	ADDRESS_TYPE_INVALID ADDRESS_TYPE = iota
+	ADDRESS_TYPE_ACCOUNT
+	ADDRESS_TYPE_DAPP
+)
+
+// BECH32_ACCOUNT_PREFIX, BECH32_DAPP_PREFIX 는 String, ParseAddress 에서 사용하는 hdac 의 bech32 prefix.
+//
+// 다른 prefix 를 쓰는 network 는 Bech32String, ParseAddressWithPrefix 에 prefix 를 넘긴다.
+const (
+	BECH32_ACCOUNT_PREFIX = "hdac"
+	BECH32_DAPP_PREFIX    = "hdacdapp"
+)
+
+// Address 는 account address(32 bytes) 또는 dapp hash(prefix 0x01 + 32 bytes)를 나타내는 type.
+type Address []byte
+
+// NewAccountAddress 는 32 bytes public key로 account Address를 만드는 함수.
+func NewAccountAddress(publicKey []byte) (Address, error) {
+	address := Address(publicKey)
+	if !address.IsAccount() {
+		return nil, fmt.Errorf("Account address length %d, but %d", ADDRESS_LENGTH, len(publicKey))
+	}
+
+	return address, nil
+}
+
+// NewDappAddress 는 32 bytes contract hash에 prefix를 붙여 dapp Address를 만드는 함수.
+func NewDappAddress(hash []byte) (Address, error) {
+	if len(hash) != ADDRESS_LENGTH {
+		return nil, fmt.Errorf("Dapp hash length %d, but %d", ADDRESS_LENGTH, len(hash))
+	}
+
+	return Address(append([]byte{DAPP_ADDRESS_PREFIX}, hash...)), nil
+}
+
+// AddressFromHex 는 hex 문자열을 Address로 변환하는 함수.
+func AddressFromHex(str string) (Address, error) {
+	res, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	address := Address(res)
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// AddressFromBase64 는 base64 문자열을 Address로 변환하는 함수.
+func AddressFromBase64(str string) (Address, error) {
+	res, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+
+	address := Address(res)
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// AddressFromBech32 는 bech32 문자열을 checksum과 prefix를 검증하여 Address로 변환하는 함수.
+//
+// account address는 BECH32_ACCOUNT_PREFIX, dapp address는 BECH32_DAPP_PREFIX 를 가져야 한다.
+func AddressFromBech32(str string) (Address, error) {
+	return AddressFromBech32WithPrefix(str, BECH32_ACCOUNT_PREFIX, BECH32_DAPP_PREFIX)
+}
+
+// AddressFromBech32WithPrefix 는 account address는 accountPrefix, dapp address는 dappPrefix 를 검증하는 AddressFromBech32.
+func AddressFromBech32WithPrefix(str string, accountPrefix string, dappPrefix string) (Address, error) {
+	hrp, res, err := Bech32Decode(str)
+	if err != nil {
+		return nil, err
+	}
+
+	address := Address(res)
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+	if expected := address.bech32Prefix(accountPrefix, dappPrefix); hrp != expected {
+		return nil, fmt.Errorf("Bech32 prefix %q, but %q", expected, hrp)
+	}
+
+	return address, nil
+}
+
+// ParseAddress 는 hex, bech32, base64 중 하나의 형식인 문자열을 Address로 변환하는 함수.
+func ParseAddress(str string) (Address, error) {
+	return ParseAddressWithPrefix(str, BECH32_ACCOUNT_PREFIX, BECH32_DAPP_PREFIX)
+}
+
+// ParseAddressWithPrefix 는 bech32 문자열의 prefix 로 accountPrefix, dappPrefix 를 사용하는 ParseAddress.
+func ParseAddressWithPrefix(str string, accountPrefix string, dappPrefix string) (Address, error) {
+	if address, err := AddressFromHex(str); err == nil {
+		return address, nil
+	}
+	if strings.ContainsRune(str, BECH32_SEPARATOR) {
+		if address, err := AddressFromBech32WithPrefix(str, accountPrefix, dappPrefix); err == nil {
+			return address, nil
+		}
+	}
+	if address, err := AddressFromBase64(str); err == nil {
+		return address, nil
+	}
+
+	return nil, fmt.Errorf("Invalid address : %q", str)
+}
+
+// MustParseAddress 는 ParseAddress 와 같으나 실패하면 panic 하는 함수.
+func MustParseAddress(str string) Address {
+	address, err := ParseAddress(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return address
+}
+
+// Type 은 길이와 prefix로 address 종류를 판별하는 함수.
+func (a Address) Type() ADDRESS_TYPE {
+	switch {
+	case len(a) == ADDRESS_LENGTH:
+		return ADDRESS_TYPE_ACCOUNT
+	case len(a) == DAPP_ADDRESS_LENGTH && a[0] == DAPP_ADDRESS_PREFIX:
+		return ADDRESS_TYPE_DAPP
+	default:
+		return ADDRESS_TYPE_INVALID
+	}
+}
+
+func (a Address) IsAccount() bool {
+	return a.Type() == ADDRESS_TYPE_ACCOUNT
+}
+
+func (a Address) IsDapp() bool {
+	return a.Type() == ADDRESS_TYPE_DAPP
+}
+
+func (a Address) Validate() error {
+	if a.Type() == ADDRESS_TYPE_INVALID {
+		return fmt.Errorf("Address length %d or %d with prefix 0x%02x, but %d",
+			ADDRESS_LENGTH, DAPP_ADDRESS_LENGTH, DAPP_ADDRESS_PREFIX, len(a))
+	}
+
+	return nil
+}
+
+// Hash 는 dapp address의 prefix를 제외한 32 bytes hash를 return 하며, account address는 그대로 return 한다.
+func (a Address) Hash() []byte {
+	if a.IsDapp() {
+		return a[1:]
+	}
+
+	return a
+}
+
+func (a Address) Bytes() []byte {
+	return []byte(a)
+}
+
+func (a Address) Hex() string {
+	return hex.EncodeToString(a)
+}
+
+func (a Address) Base64() string {
+	return base64.StdEncoding.EncodeToString(a)
+}
+
+// Bech32 는 주어진 prefix로 bech32 문자열을 만드는 함수.
+func (a Address) Bech32(prefix string) (string, error) {
+	return Bech32Encode(prefix, a)
+}
+
+// String 은 address 종류에 맞는 hdac prefix의 bech32 문자열을 return 하며, 잘못된 address는 hex로 표시한다.
+func (a Address) String() string {
+	return a.Bech32String(BECH32_ACCOUNT_PREFIX, BECH32_DAPP_PREFIX)
+}
+
+// Bech32String 은 account address는 accountPrefix, dapp address는 dappPrefix 로 bech32 문자열을 만드는 함수.
+// 잘못된 address는 hex로 표시한다.
+func (a Address) Bech32String(accountPrefix string, dappPrefix string) string {
+	if a.Validate() != nil {
+		return a.Hex()
+	}
+	str, err := a.Bech32(a.bech32Prefix(accountPrefix, dappPrefix))
+	if err != nil {
+		return a.Hex()
+	}
+
+	return str
+}
+
+func (a Address) Equal(other Address) bool {
+	return string(a) == string(other)
+}
+
+// Key 는 account address는 account key, dapp address는 prefix를 제외한 hash key로 변환하는 함수.
+func (a Address) Key() (Key, error) {
+	switch a.Type() {
+	case ADDRESS_TYPE_ACCOUNT:
+		return NewAccountKey(a.Bytes())
+	case ADDRESS_TYPE_DAPP:
+		return NewHashKey(a.Hash())
+	default:
+		return Key{}, a.Validate()
+	}
+}
+
+// ToStateKey 는 account address는 Key_Address, dapp address는 Key_Hash 로 변환하는 함수.
+func (a Address) ToStateKey() (*state.Key, error) {
+	key, err := a.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	return key.ToStateValue(), nil
+}
+
+func (a Address) bech32Prefix(accountPrefix string, dappPrefix string) string {
+	if a.IsDapp() {
+		return dappPrefix
+	}
+
+	return accountPrefix
+}
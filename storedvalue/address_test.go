@@ -0,0 +1,144 @@
+package storedvalue
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccountHex = "93236a9263d2ac6198c5ed211774c745d5dc62a910cb84276f8a7c4959208915"
+	testDappHex    = "0193236a9263d2ac6198c5ed211774c745d5dc62a910cb84276f8a7c4959208915"
+)
+
+func TestBech32Vectors(t *testing.T) {
+	valid := []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+	}
+	for _, str := range valid {
+		hrp, data, err := Bech32Decode(str)
+		assert.NoError(t, err, str)
+
+		encoded, err := Bech32Encode(hrp, data)
+		assert.NoError(t, err, str)
+		assert.Equal(t, strings.ToLower(str), encoded)
+	}
+
+	invalid := []string{
+		"A1G7SGD8",
+		"10a06t8",
+		"1qzzfhee",
+		"a12UEL5L",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx",
+	}
+	for _, str := range invalid {
+		_, _, err := Bech32Decode(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestAddressType(t *testing.T) {
+	account, err := AddressFromHex(testAccountHex)
+	assert.NoError(t, err)
+	assert.True(t, account.IsAccount())
+	assert.Equal(t, testAccountHex, account.Hex())
+
+	dapp, err := AddressFromHex(testDappHex)
+	assert.NoError(t, err)
+	assert.True(t, dapp.IsDapp())
+	assert.Equal(t, []byte(account), dapp.Hash())
+
+	newDapp, err := NewDappAddress(account)
+	assert.NoError(t, err)
+	assert.True(t, newDapp.Equal(dapp))
+
+	_, err = AddressFromHex("02" + testAccountHex)
+	assert.Error(t, err)
+	_, err = AddressFromHex(testAccountHex[2:])
+	assert.Error(t, err)
+	_, err = NewAccountAddress(dapp)
+	assert.Error(t, err)
+}
+
+func TestAddressFormats(t *testing.T) {
+	for _, str := range []string{testAccountHex, testDappHex} {
+		address := MustParseAddress(str)
+
+		fromBase64, err := AddressFromBase64(address.Base64())
+		assert.NoError(t, err)
+		assert.True(t, address.Equal(fromBase64))
+
+		bech32Str := address.String()
+		assert.True(t, strings.HasPrefix(bech32Str, address.bech32Prefix(BECH32_ACCOUNT_PREFIX, BECH32_DAPP_PREFIX)+"1"))
+
+		fromBech32, err := AddressFromBech32(bech32Str)
+		assert.NoError(t, err)
+		assert.True(t, address.Equal(fromBech32))
+
+		parsed, err := ParseAddress(bech32Str)
+		assert.NoError(t, err)
+		assert.True(t, address.Equal(parsed))
+
+		parsed, err = ParseAddress(address.Base64())
+		assert.NoError(t, err)
+		assert.True(t, address.Equal(parsed))
+
+		// 다른 prefix, 잘못된 checksum
+		otherPrefix, err := address.Bech32("other")
+		assert.NoError(t, err)
+		_, err = AddressFromBech32(otherPrefix)
+		assert.Error(t, err)
+
+		// 다른 network 의 prefix
+		otherNetwork := address.Bech32String("friday", "fridaydapp")
+		assert.True(t, strings.HasPrefix(otherNetwork, address.bech32Prefix("friday", "fridaydapp")+"1"))
+		parsed, err = ParseAddressWithPrefix(otherNetwork, "friday", "fridaydapp")
+		assert.NoError(t, err)
+		assert.True(t, address.Equal(parsed))
+		_, err = AddressFromBech32WithPrefix(bech32Str, "friday", "fridaydapp")
+		assert.Error(t, err)
+		_, err = ParseAddress(otherNetwork)
+		assert.Error(t, err)
+
+		broken := bech32Str[:len(bech32Str)-1] + "q"
+		if broken == bech32Str {
+			broken = bech32Str[:len(bech32Str)-1] + "p"
+		}
+		_, err = AddressFromBech32(broken)
+		assert.Error(t, err)
+	}
+
+	_, err := ParseAddress("not an address")
+	assert.Error(t, err)
+}
+
+func TestAddressToStateKey(t *testing.T) {
+	key, err := MustParseAddress(testAccountHex).ToStateKey()
+	assert.NoError(t, err)
+	assert.Equal(t, testAccountHex, hex.EncodeToString(key.GetAddress().GetAccount()))
+
+	key, err = MustParseAddress(testDappHex).ToStateKey()
+	assert.NoError(t, err)
+	assert.Equal(t, testAccountHex, hex.EncodeToString(key.GetHash().GetHash()))
+
+	_, err = Address{1, 2, 3}.ToStateKey()
+	assert.Error(t, err)
+}
+
+func TestAddressKey(t *testing.T) {
+	key, err := MustParseAddress(testAccountHex).Key()
+	assert.NoError(t, err)
+	assert.Equal(t, "account-"+testAccountHex, key.String())
+
+	key, err = MustParseAddress(testDappHex).Key()
+	assert.NoError(t, err)
+	assert.Equal(t, "hash-"+testAccountHex, key.String())
+
+	_, err = Address{1, 2, 3}.Key()
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package storedvalue
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// AuthorizationStatus 는 signer public key들의 weight 합과 account의 action threshold를 비교한 결과.
+//
+// Weight 는 AssociatedKeys 에 있는 signer의 weight 합이며, 같은 key는 한번만 더한다.
+// UnknownKeys 는 AssociatedKeys 에 없는 signer 목록이며 입력 순서를 따른다.
+type AuthorizationStatus struct {
+	Weight           uint32
+	ActionThresholds ActionThresholds
+	UnknownKeys      [][]byte
+}
+
+// Authorize 는 signers 의 weight 합과 AssociatedKeys 에 없는 key를 계산하는 함수.
+func (a Account) Authorize(signers [][]byte) AuthorizationStatus {
+	status := AuthorizationStatus{ActionThresholds: a.ActionThresholds}
+
+	counted := map[string]bool{}
+	for _, signer := range signers {
+		if counted[string(signer)] {
+			continue
+		}
+		counted[string(signer)] = true
+
+		weight, ok := a.AssociatedKeyWeight(signer)
+		if !ok {
+			status.UnknownKeys = append(status.UnknownKeys, signer)
+			continue
+		}
+		status.Weight += weight
+	}
+
+	return status
+}
+
+// AssociatedKeyWeight 는 public key의 weight를 return 하는 함수. AssociatedKeys 에 없으면 ok는 false 이다.
+func (a Account) AssociatedKeyWeight(publicKey []byte) (weight uint32, ok bool) {
+	for _, associatedKey := range a.AssociatedKeys {
+		if bytes.Equal(associatedKey.PublicKey, publicKey) {
+			return associatedKey.Weight, true
+		}
+	}
+
+	return 0, false
+}
+
+// CanDeploy 는 deploy를 실행할 수 있는지 return 하는 함수.
+//
+// Execution Engine은 associated key가 아닌 signer가 하나라도 있으면 weight와 관계없이 deploy를 거부한다.
+func (s AuthorizationStatus) CanDeploy() bool {
+	return s.CheckDeployment() == nil
+}
+
+// CanManageKeys 는 associated key와 action threshold를 변경할 수 있는지 return 하는 함수.
+func (s AuthorizationStatus) CanManageKeys() bool {
+	return s.CheckKeyManagement() == nil
+}
+
+// CheckDeployment 는 deploy를 실행할 수 없으면 그 이유를 error로 return 하는 함수.
+func (s AuthorizationStatus) CheckDeployment() error {
+	return s.check("deployment", s.ActionThresholds.DeploymentThreshold)
+}
+
+// CheckKeyManagement 는 key management를 할 수 없으면 그 이유를 error로 return 하는 함수.
+func (s AuthorizationStatus) CheckKeyManagement() error {
+	return s.check("key management", s.ActionThresholds.KeyManagementThreshold)
+}
+
+func (s AuthorizationStatus) check(action string, threshold uint32) error {
+	if len(s.UnknownKeys) > 0 {
+		unknownKeys := make([]string, len(s.UnknownKeys))
+		for i, unknownKey := range s.UnknownKeys {
+			unknownKeys[i] = hex.EncodeToString(unknownKey)
+		}
+		return fmt.Errorf("Unknown keys for %s : %s", action, strings.Join(unknownKeys, ", "))
+	}
+	if s.Weight < threshold {
+		return fmt.Errorf("Insufficient weight for %s : %d, but threshold %d", action, s.Weight, threshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package storedvalue
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountAuthorize(t *testing.T) {
+	alice := bytes.Repeat([]byte{1}, ADDRESS_LENGTH)
+	bob := bytes.Repeat([]byte{2}, ADDRESS_LENGTH)
+	carol := bytes.Repeat([]byte{3}, ADDRESS_LENGTH)
+	account := NewAccount(alice, NamedKeys{}, URef{},
+		[]AssociatedKey{NewAssociatedKey(alice, 1), NewAssociatedKey(bob, 2)},
+		NewActionThresholds(2, 3))
+
+	weight, ok := account.AssociatedKeyWeight(bob)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), weight)
+	_, ok = account.AssociatedKeyWeight(carol)
+	assert.False(t, ok)
+
+	status := account.Authorize([][]byte{alice})
+	assert.Equal(t, uint32(1), status.Weight)
+	assert.False(t, status.CanDeploy())
+	assert.False(t, status.CanManageKeys())
+	assert.EqualError(t, status.CheckDeployment(), "Insufficient weight for deployment : 1, but threshold 2")
+
+	// 같은 key는 한번만 더한다.
+	status = account.Authorize([][]byte{bob, bob})
+	assert.Equal(t, uint32(2), status.Weight)
+	assert.True(t, status.CanDeploy())
+	assert.False(t, status.CanManageKeys())
+	assert.EqualError(t, status.CheckKeyManagement(), "Insufficient weight for key management : 2, but threshold 3")
+
+	status = account.Authorize([][]byte{alice, bob})
+	assert.Equal(t, uint32(3), status.Weight)
+	assert.NoError(t, status.CheckDeployment())
+	assert.NoError(t, status.CheckKeyManagement())
+
+	// associated key가 아닌 signer가 있으면 weight가 충분해도 거부된다.
+	status = account.Authorize([][]byte{alice, carol, bob, carol})
+	assert.Equal(t, uint32(3), status.Weight)
+	assert.Equal(t, [][]byte{carol}, status.UnknownKeys)
+	assert.False(t, status.CanDeploy())
+	assert.False(t, status.CanManageKeys())
+	assert.EqualError(t, status.CheckDeployment(),
+		"Unknown keys for deployment : 0303030303030303030303030303030303030303030303030303030303030303")
+
+	status = account.Authorize(nil)
+	assert.Equal(t, uint32(0), status.Weight)
+	assert.False(t, status.CanDeploy())
+}
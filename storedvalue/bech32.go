@@ -0,0 +1,152 @@
+package storedvalue
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bech32 (BIP-173) encoding 에 필요한 상수와 함수들.
+
+const (
+	BECH32_SEPARATOR       = '1'
+	BECH32_CHECKSUM_LENGTH = 6
+	BECH32_MAX_LENGTH      = 90
+
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var bech32Generator = []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, value := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(value)
+		for idx, generator := range bech32Generator {
+			if (top>>uint(idx))&1 == 1 {
+				chk ^= generator
+			}
+		}
+	}
+
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	res := make([]byte, 0, len(hrp)*2+1)
+	for idx := 0; idx < len(hrp); idx++ {
+		res = append(res, hrp[idx]>>5)
+	}
+	res = append(res, 0)
+	for idx := 0; idx < len(hrp); idx++ {
+		res = append(res, hrp[idx]&31)
+	}
+
+	return res
+}
+
+func bech32Checksum(hrp string, data []byte) []byte {
+	values := append(bech32HrpExpand(hrp), data...)
+	values = append(values, make([]byte, BECH32_CHECKSUM_LENGTH)...)
+	polymod := bech32Polymod(values) ^ 1
+
+	res := make([]byte, BECH32_CHECKSUM_LENGTH)
+	for idx := range res {
+		res[idx] = byte((polymod >> uint(5*(5-idx))) & 31)
+	}
+
+	return res
+}
+
+// Bech32Encode 는 hrp(human readable part)와 byte array를 bech32 문자열로 변환하는 함수.
+func Bech32Encode(hrp string, src []byte) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("Bech32 prefix is empty")
+	}
+	for idx := 0; idx < len(hrp); idx++ {
+		if hrp[idx] < 33 || hrp[idx] > 126 || (hrp[idx] >= 'A' && hrp[idx] <= 'Z') {
+			return "", fmt.Errorf("Invalid bech32 prefix character %q", hrp[idx])
+		}
+	}
+
+	data, err := convertBits(src, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	data = append(data, bech32Checksum(hrp, data)...)
+
+	var builder strings.Builder
+	builder.WriteString(hrp)
+	builder.WriteByte(BECH32_SEPARATOR)
+	for _, value := range data {
+		builder.WriteByte(bech32Charset[value])
+	}
+
+	return builder.String(), nil
+}
+
+// Bech32Decode 는 bech32 문자열을 검증하여 hrp와 byte array로 변환하는 함수.
+func Bech32Decode(str string) (hrp string, res []byte, err error) {
+	if len(str) > BECH32_MAX_LENGTH {
+		return "", nil, fmt.Errorf("Bech32 length less than %d, but %d", BECH32_MAX_LENGTH, len(str))
+	}
+	if strings.ToLower(str) != str && strings.ToUpper(str) != str {
+		return "", nil, fmt.Errorf("Bech32 string has mixed case")
+	}
+	str = strings.ToLower(str)
+
+	separatorPos := strings.LastIndexByte(str, BECH32_SEPARATOR)
+	if separatorPos < 1 || separatorPos+BECH32_CHECKSUM_LENGTH+1 > len(str) {
+		return "", nil, fmt.Errorf("Invalid bech32 separator position")
+	}
+	hrp = str[:separatorPos]
+
+	data := make([]byte, 0, len(str)-separatorPos-1)
+	for idx := separatorPos + 1; idx < len(str); idx++ {
+		value := strings.IndexByte(bech32Charset, str[idx])
+		if value < 0 {
+			return "", nil, fmt.Errorf("Invalid bech32 character %q at %d", str[idx], idx)
+		}
+		data = append(data, byte(value))
+	}
+
+	if bech32Polymod(append(bech32HrpExpand(hrp), data...)) != 1 {
+		return "", nil, fmt.Errorf("Invalid bech32 checksum")
+	}
+
+	res, err = convertBits(data[:len(data)-BECH32_CHECKSUM_LENGTH], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hrp, res, nil
+}
+
+func convertBits(src []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+	res := []byte{}
+
+	for _, value := range src {
+		if uint32(value)>>fromBits != 0 {
+			return nil, fmt.Errorf("Invalid %d bit value %d", fromBits, value)
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			res = append(res, byte(acc>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			res = append(res, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, fmt.Errorf("Invalid bech32 padding")
+	}
+
+	return res, nil
+}
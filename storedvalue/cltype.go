@@ -0,0 +1,355 @@
+package storedvalue
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+)
+
+// MAX_CL_TYPE_DEPTH 는 decoder 와 parser 가 허용하는 inner type 의 최대 중첩 깊이.
+const MAX_CL_TYPE_DEPTH = 64
+
+// CLType 은 CL type 의 재귀적인 표현.
+//
+// Inner 는 serialize 순서의 inner type 들이다.
+// Option, List, FixedList 는 1개, Result 는 ok 와 err, Map 은 key 와 value,
+// Tuple1-3 은 원소마다 1개이다.
+// Length 는 FixedList 에서만 사용한다.
+type CLType struct {
+	Tag    CL_TYPE_TAG `json:"tag"`
+	Inner  []CLType    `json:"inner,omitempty"`
+	Length uint32      `json:"length,omitempty"`
+}
+
+func NewSimpleCLType(tag CL_TYPE_TAG) CLType {
+	return CLType{Tag: tag}
+}
+
+func NewOptionCLType(inner CLType) CLType {
+	return CLType{Tag: TAG_OPTION, Inner: []CLType{inner}}
+}
+
+func NewListCLType(inner CLType) CLType {
+	return CLType{Tag: TAG_LIST, Inner: []CLType{inner}}
+}
+
+func NewFixedListCLType(inner CLType, length uint32) CLType {
+	return CLType{Tag: TAG_FIXED_LIST, Inner: []CLType{inner}, Length: length}
+}
+
+func NewResultCLType(ok CLType, err CLType) CLType {
+	return CLType{Tag: TAG_RESULT, Inner: []CLType{ok, err}}
+}
+
+func NewMapCLType(key CLType, value CLType) CLType {
+	return CLType{Tag: TAG_MAP, Inner: []CLType{key, value}}
+}
+
+// NewTupleCLType 은 1 ~ 3 개의 원소 type 으로 Tuple1, Tuple2, Tuple3 type 을 만드는 함수.
+func NewTupleCLType(elements ...CLType) (CLType, error) {
+	if len(elements) < 1 || len(elements) > 3 {
+		return CLType{}, fmt.Errorf("Tuple must have 1 to 3 elements, but %d", len(elements))
+	}
+
+	return CLType{Tag: TAG_TUPLE1 + CL_TYPE_TAG(len(elements)-1), Inner: elements}, nil
+}
+
+// innerCount 는 tag 가 가지는 inner type 의 개수를 return 하는 함수. 알 수 없는 tag 는 -1 이다.
+func (c CLType) innerCount() int {
+	switch c.Tag {
+	case TAG_OPTION, TAG_LIST, TAG_FIXED_LIST, TAG_TUPLE1:
+		return 1
+	case TAG_RESULT, TAG_MAP, TAG_TUPLE2:
+		return 2
+	case TAG_TUPLE3:
+		return 3
+	default:
+		if c.Tag < TAG_BOOL || c.Tag > TAG_ANY {
+			return -1
+		}
+		return 0
+	}
+}
+
+// isZeroSized 는 type 의 값이 0 byte 로 serialize 되는지 확인하는 함수.
+// Unit, 빈 [u8; 0] 과 그런 type 들의 tuple 이 해당한다.
+func (c CLType) isZeroSized() bool {
+	switch c.Tag {
+	case TAG_UNIT:
+		return true
+	case TAG_FIXED_LIST:
+		return len(c.Inner) == 1 && c.Inner[0].Tag == TAG_U8 && c.Length == 0
+	case TAG_TUPLE1, TAG_TUPLE2, TAG_TUPLE3:
+		for _, inner := range c.Inner {
+			if !inner.isZeroSized() {
+				return false
+			}
+		}
+		return len(c.Inner) > 0
+	default:
+		return false
+	}
+}
+
+// Validate 는 모든 tag 가 알려진 tag 이고 올바른 개수의 inner type 을 가지는지 검사하는 함수.
+func (c CLType) Validate() error {
+	count := c.innerCount()
+	if count < 0 {
+		return fmt.Errorf("Unknown CLType tag %d", c.Tag)
+	}
+	if len(c.Inner) != count {
+		return fmt.Errorf("CLType tag %d must have %d inner types, but %d", c.Tag, count, len(c.Inner))
+	}
+	for _, inner := range c.Inner {
+		if err := inner.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c CLType) FromBytes(src []byte) (clType CLType, err error, pos int) {
+	return clTypeFromBytes(src, STORED_VALUE_FORMAT_V1, 0)
+}
+
+func (c CLType) ToBytes() []byte {
+	res := []byte{byte(c.Tag)}
+	for _, inner := range c.Inner {
+		res = append(res, inner.ToBytes()...)
+	}
+
+	if c.Tag == TAG_FIXED_LIST {
+		lengthBytes := make([]byte, UINT32_LENGTH)
+		binary.LittleEndian.PutUint32(lengthBytes, c.Length)
+		res = append(res, lengthBytes...)
+	}
+
+	return res
+}
+
+func (c CLType) ToStateValue() (*state.CLType, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	inners := []*state.CLType{}
+	for _, inner := range c.Inner {
+		stateInner, err := inner.ToStateValue()
+		if err != nil {
+			return nil, err
+		}
+		inners = append(inners, stateInner)
+	}
+
+	switch c.Tag {
+	case TAG_OPTION:
+		return &state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{Inner: inners[0]}}}, nil
+	case TAG_LIST:
+		return &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: inners[0]}}}, nil
+	case TAG_FIXED_LIST:
+		return &state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{Inner: inners[0], Len: c.Length}}}, nil
+	case TAG_RESULT:
+		return &state.CLType{Variants: &state.CLType_ResultType{ResultType: &state.CLType_Result{Ok: inners[0], Err: inners[1]}}}, nil
+	case TAG_MAP:
+		return &state.CLType{Variants: &state.CLType_MapType{MapType: &state.CLType_Map{Key: inners[0], Value: inners[1]}}}, nil
+	case TAG_TUPLE1:
+		return &state.CLType{Variants: &state.CLType_Tuple1Type{Tuple1Type: &state.CLType_Tuple1{Type0: inners[0]}}}, nil
+	case TAG_TUPLE2:
+		return &state.CLType{Variants: &state.CLType_Tuple2Type{Tuple2Type: &state.CLType_Tuple2{Type0: inners[0], Type1: inners[1]}}}, nil
+	case TAG_TUPLE3:
+		return &state.CLType{Variants: &state.CLType_Tuple3Type{Tuple3Type: &state.CLType_Tuple3{Type0: inners[0], Type1: inners[1], Type2: inners[2]}}}, nil
+	case TAG_ANY:
+		return &state.CLType{Variants: &state.CLType_AnyType{AnyType: &state.CLType_Any{}}}, nil
+	default:
+		return simpleCLType(state.CLType_Simple(c.Tag)), nil
+	}
+}
+
+func (c CLType) FromStateValue(clType *state.CLType) (CLType, error) {
+	return clTypeFromStateValue(clType, 0)
+}
+
+func clTypeFromStateValue(clType *state.CLType, depth int) (CLType, error) {
+	if depth > MAX_CL_TYPE_DEPTH {
+		return CLType{}, fmt.Errorf("CLType is nested deeper than %d", MAX_CL_TYPE_DEPTH)
+	}
+
+	fromInners := func(tag CL_TYPE_TAG, stateInners ...*state.CLType) (CLType, error) {
+		res := CLType{Tag: tag}
+		for _, stateInner := range stateInners {
+			inner, err := clTypeFromStateValue(stateInner, depth+1)
+			if err != nil {
+				return CLType{}, err
+			}
+			res.Inner = append(res.Inner, inner)
+		}
+		return res, nil
+	}
+
+	switch clType.GetVariants().(type) {
+	case *state.CLType_SimpleType:
+		if clType.GetSimpleType() > state.CLType_UREF {
+			return CLType{}, fmt.Errorf("Unknown CLType simple type %d", clType.GetSimpleType())
+		}
+		return NewSimpleCLType(CL_TYPE_TAG(clType.GetSimpleType())), nil
+	case *state.CLType_OptionType:
+		return fromInners(TAG_OPTION, clType.GetOptionType().GetInner())
+	case *state.CLType_ListType:
+		return fromInners(TAG_LIST, clType.GetListType().GetInner())
+	case *state.CLType_FixedListType:
+		res, err := fromInners(TAG_FIXED_LIST, clType.GetFixedListType().GetInner())
+		if err != nil {
+			return CLType{}, err
+		}
+		res.Length = clType.GetFixedListType().GetLen()
+		return res, nil
+	case *state.CLType_ResultType:
+		return fromInners(TAG_RESULT, clType.GetResultType().GetOk(), clType.GetResultType().GetErr())
+	case *state.CLType_MapType:
+		return fromInners(TAG_MAP, clType.GetMapType().GetKey(), clType.GetMapType().GetValue())
+	case *state.CLType_Tuple1Type:
+		return fromInners(TAG_TUPLE1, clType.GetTuple1Type().GetType0())
+	case *state.CLType_Tuple2Type:
+		return fromInners(TAG_TUPLE2, clType.GetTuple2Type().GetType0(), clType.GetTuple2Type().GetType1())
+	case *state.CLType_Tuple3Type:
+		return fromInners(TAG_TUPLE3, clType.GetTuple3Type().GetType0(), clType.GetTuple3Type().GetType1(), clType.GetTuple3Type().GetType2())
+	case *state.CLType_AnyType:
+		return NewSimpleCLType(TAG_ANY), nil
+	default:
+		return CLType{}, fmt.Errorf("CLType data is invalid.")
+	}
+}
+
+var clTypeNames = map[CL_TYPE_TAG]string{
+	TAG_BOOL: "Bool", TAG_I32: "I32", TAG_I64: "I64", TAG_U8: "U8", TAG_U32: "U32", TAG_U64: "U64",
+	TAG_U128: "U128", TAG_U256: "U256", TAG_U512: "U512", TAG_UNIT: "Unit", TAG_STRING: "String",
+	TAG_KEY: "Key", TAG_UREF: "URef", TAG_OPTION: "Option", TAG_LIST: "List", TAG_FIXED_LIST: "FixedList",
+	TAG_RESULT: "Result", TAG_MAP: "Map", TAG_TUPLE1: "Tuple1", TAG_TUPLE2: "Tuple2", TAG_TUPLE3: "Tuple3",
+	TAG_ANY: "Any"}
+
+// String 은 type 을 EE 의 이름 형식으로 표현하는 함수. 예) "Map<String, List<Key>>"
+func (c CLType) String() string {
+	name, ok := clTypeNames[c.Tag]
+	if !ok {
+		name = fmt.Sprintf("Unknown(%d)", c.Tag)
+	}
+	if len(c.Inner) == 0 {
+		return name
+	}
+
+	inners := []string{}
+	for _, inner := range c.Inner {
+		inners = append(inners, inner.String())
+	}
+	if c.Tag == TAG_FIXED_LIST {
+		inners = append(inners, fmt.Sprint(c.Length))
+	}
+
+	return name + "<" + strings.Join(inners, ", ") + ">"
+}
+
+// ParseCLType 은 CLType.String 의 형식을 parse 하는 함수. 예) "Map<String, List<Key>>", "FixedList<U8, 32>"
+func ParseCLType(str string) (CLType, error) {
+	parser := &clTypeParser{src: str}
+	clType, err := parser.parseType(0)
+	if err != nil {
+		return CLType{}, err
+	}
+	parser.skipSpaces()
+	if parser.pos != len(parser.src) {
+		return CLType{}, fmt.Errorf("Unexpected %q at %d in CLType %q", parser.src[parser.pos:], parser.pos, str)
+	}
+
+	return clType, nil
+}
+
+type clTypeParser struct {
+	src string
+	pos int
+}
+
+func (p *clTypeParser) skipSpaces() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// word 는 다음 type 이름 또는 숫자를 읽는 함수.
+func (p *clTypeParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.src) && (unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos]))) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *clTypeParser) expect(c byte) error {
+	p.skipSpaces()
+	if p.pos >= len(p.src) || p.src[p.pos] != c {
+		return fmt.Errorf("Expected %q at %d in CLType %q", c, p.pos, p.src)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *clTypeParser) parseType(depth int) (CLType, error) {
+	start := p.pos
+	if depth > MAX_CL_TYPE_DEPTH {
+		return CLType{}, fmt.Errorf("CLType nested deeper than %d at %d in CLType %q", MAX_CL_TYPE_DEPTH, start, p.src)
+	}
+	name := p.word()
+
+	clType := CLType{Tag: -1}
+	for tag, tagName := range clTypeNames {
+		if tagName == name {
+			clType.Tag = tag
+			break
+		}
+	}
+	if clType.Tag < 0 {
+		return CLType{}, fmt.Errorf("Unknown CLType %q at %d", name, start)
+	}
+
+	count := clType.innerCount()
+	if count == 0 {
+		return clType, nil
+	}
+
+	if err := p.expect('<'); err != nil {
+		return CLType{}, err
+	}
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			if err := p.expect(','); err != nil {
+				return CLType{}, err
+			}
+		}
+		inner, err := p.parseType(depth + 1)
+		if err != nil {
+			return CLType{}, err
+		}
+		clType.Inner = append(clType.Inner, inner)
+	}
+	if clType.Tag == TAG_FIXED_LIST {
+		if err := p.expect(','); err != nil {
+			return CLType{}, err
+		}
+		lengthStart := p.pos
+		length, err := strconv.ParseUint(p.word(), 10, 32)
+		if err != nil {
+			return CLType{}, fmt.Errorf("Invalid FixedList length at %d in CLType %q", lengthStart, p.src)
+		}
+		clType.Length = uint32(length)
+	}
+	if err := p.expect('>'); err != nil {
+		return CLType{}, err
+	}
+
+	return clType, nil
+}
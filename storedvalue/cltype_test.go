@@ -0,0 +1,127 @@
+package storedvalue
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLTypeNested(t *testing.T) {
+	tuple, err := NewTupleCLType(NewOptionCLType(NewSimpleCLType(TAG_U64)), NewSimpleCLType(TAG_STRING))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		clType CLType
+		bytes  []byte
+		str    string
+	}{
+		{NewOptionCLType(NewListCLType(NewSimpleCLType(TAG_U8))), []byte{13, 14, 3}, "Option<List<U8>>"},
+		{NewMapCLType(NewSimpleCLType(TAG_STRING), NewListCLType(NewSimpleCLType(TAG_KEY))), []byte{17, 10, 14, 11}, "Map<String, List<Key>>"},
+		{tuple, []byte{19, 13, 5, 10}, "Tuple2<Option<U64>, String>"},
+		{NewFixedListCLType(NewSimpleCLType(TAG_U8), 32), []byte{15, 3, 32, 0, 0, 0}, "FixedList<U8, 32>"},
+		{NewResultCLType(NewSimpleCLType(TAG_UNIT), NewSimpleCLType(TAG_STRING)), []byte{16, 9, 10}, "Result<Unit, String>"},
+		{NewSimpleCLType(TAG_ANY), []byte{21}, "Any"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.bytes, testCase.clType.ToBytes())
+		assert.Equal(t, testCase.str, testCase.clType.String())
+
+		var clType CLType
+		clType, err, pos := clType.FromBytes(append(testCase.bytes, 0xff))
+		assert.NoError(t, err)
+		assert.Equal(t, len(testCase.bytes), pos)
+		assert.Equal(t, testCase.clType, clType)
+
+		stateType, err := clType.ToStateValue()
+		assert.NoError(t, err)
+		stateBytes, err := CLTypeToBytes(stateType)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.bytes, stateBytes)
+
+		fromState, err := clType.FromStateValue(stateType)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.clType, fromState)
+	}
+}
+
+func TestCLTypeError(t *testing.T) {
+	var clType CLType
+	for _, src := range [][]byte{{}, {22}, {13}, {17, 10}, {15, 3, 1, 0}} {
+		_, err, _ := clType.FromBytes(src)
+		assert.Error(t, err)
+	}
+
+	_, err := NewTupleCLType()
+	assert.Error(t, err)
+	_, err = CLType{Tag: TAG_LIST}.ToStateValue()
+	assert.Error(t, err)
+}
+
+func TestCLTypeDepth(t *testing.T) {
+	nested := func(depth int) []byte {
+		return append(bytes.Repeat([]byte{byte(TAG_OPTION)}, depth), byte(TAG_UNIT))
+	}
+
+	var clType CLType
+	clType, err, pos := clType.FromBytes(nested(MAX_CL_TYPE_DEPTH))
+	assert.NoError(t, err)
+	assert.Equal(t, MAX_CL_TYPE_DEPTH+1, pos)
+
+	_, err = ParseCLType(clType.String())
+	assert.NoError(t, err)
+	stateType, err := clType.ToStateValue()
+	assert.NoError(t, err)
+	_, err = clType.FromStateValue(stateType)
+	assert.NoError(t, err)
+
+	deepType := NewOptionCLType(clType)
+	_, err = ParseCLType(deepType.String())
+	assert.Error(t, err)
+	stateType, err = deepType.ToStateValue()
+	assert.NoError(t, err)
+	_, err = clType.FromStateValue(stateType)
+	assert.Error(t, err)
+
+	// 4MiB 의 Option tag 도 stack 을 넘치지 않고 깊이 제한에서 멈춰야 한다.
+	_, err, _ = clType.FromBytes(nested(4 << 20))
+	assert.Equal(t, &DecodeError{Offset: MAX_CL_TYPE_DEPTH + 1, Msg: "CLType is nested deeper than 64"}, err)
+
+	var clValue CLValue
+	_, err, _ = clValue.FromBytes(append([]byte{0, 0, 0, 0}, nested(4<<20)...))
+	assert.Equal(t, &DecodeError{Offset: SIZE_LENGTH + MAX_CL_TYPE_DEPTH + 1, Msg: "CLType is nested deeper than 64"}, err)
+	_, err, _ = clValueFromBytes(append([]byte{0, 0, 0, 0}, nested(4<<20)...), STORED_VALUE_FORMAT_V2)
+	assert.Equal(t, &DecodeError{Offset: SIZE_LENGTH + MAX_CL_TYPE_DEPTH + 1, Msg: "CLType is nested deeper than 64"}, err)
+}
+
+func TestCLValueNestedFromBytes(t *testing.T) {
+	// Some(vec![1, 2]) : Option<List<U8>> 다음에 다른 값이 이어지는 경우
+	src := []byte{
+		7, 0, 0, 0,
+		1, 2, 0, 0, 0, 1, 2,
+		13, 14, 3,
+		0xff}
+
+	var clValue CLValue
+	clValue, err, pos := clValue.FromBytes(src)
+	assert.NoError(t, err)
+	assert.Equal(t, len(src)-1, pos)
+	assert.Equal(t, NewOptionCLType(NewListCLType(NewSimpleCLType(TAG_U8))), clValue.Type)
+	assert.Equal(t, src[:pos], clValue.ToBytes())
+	value, err := clValue.ToCLInstanceValue()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, value.GetOptionValue().GetValue().GetBytesValue())
+}
+
+func TestFixedListCLValueToBytes(t *testing.T) {
+	clValue := NewClValue([]byte{1, 2, 3}, NewFixedListCLType(NewSimpleCLType(TAG_U8), 3))
+	assert.Equal(t, []byte{3, 0, 0, 0, 1, 2, 3, 15, 3, 3, 0, 0, 0}, clValue.ToBytes())
+
+	instance, err, _ := CLValueInstanceFromBytes(clValue.ToBytes())
+	assert.NoError(t, err)
+	stateType, err := clValue.Type.ToStateValue()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(stateType, instance.GetClType()))
+}
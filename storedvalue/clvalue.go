@@ -3,6 +3,7 @@ package storedvalue
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -47,176 +48,124 @@ const (
 )
 
 type CLValue struct {
-	Bytes []byte        `json:"bytes"`
-	Tags  []CL_TYPE_TAG `json:"tags"`
+	Bytes []byte `json:"bytes"`
+	Type  CLType `json:"cl_type"`
 }
 
-func NewClValue(bytes []byte, tags []CL_TYPE_TAG) CLValue {
+func NewClValue(bytes []byte, clType CLType) CLValue {
 	return CLValue{
 		Bytes: bytes,
-		Tags:  tags,
+		Type:  clType,
 	}
 }
 
 func (c CLValue) FromBytes(src []byte) (clValue CLValue, err error, pos int) {
-	valueLength := int(binary.LittleEndian.Uint32(src[:SIZE_LENGTH]))
+	valueLength, err := sizeFromBytes(src, pos, "CLValue")
+	if err != nil {
+		return CLValue{}, err, pos
+	}
 	pos = SIZE_LENGTH
 
+	if err := checkLength(src, pos, valueLength, "CLValue"); err != nil {
+		return CLValue{}, err, pos
+	}
 	serializedValue := src[pos : pos+valueLength]
 	pos += valueLength
 	clValue.Bytes = serializedValue
 
-	switch CL_TYPE_TAG(src[pos]) {
-	case TAG_BOOL:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_I32:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_I64:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_U8:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_U32:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_U64:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_U128:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_U256:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_U512:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_UNIT:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_STRING:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_KEY:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_UREF:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
-	case TAG_OPTION:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH
-	case TAG_LIST:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH
-	case TAG_FIXED_LIST:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH
-		pos += UINT32_LENGTH
-	case TAG_RESULT:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH]), CL_TYPE_TAG(src[pos+TAG_LENGTH+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH + TAG_LENGTH
-	case TAG_MAP:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH]), CL_TYPE_TAG(src[pos+TAG_LENGTH+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH + TAG_LENGTH
-	case TAG_TUPLE1:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH
-	case TAG_TUPLE2:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH]), CL_TYPE_TAG(src[pos+TAG_LENGTH+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH + TAG_LENGTH + TAG_LENGTH
-	case TAG_TUPLE3:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos]), CL_TYPE_TAG(src[pos+TAG_LENGTH]), CL_TYPE_TAG(src[pos+TAG_LENGTH+TAG_LENGTH]), CL_TYPE_TAG(src[pos+TAG_LENGTH+TAG_LENGTH+TAG_LENGTH])}
-		pos += TAG_LENGTH + TAG_LENGTH + TAG_LENGTH + TAG_LENGTH + TAG_LENGTH
-	case TAG_ANY:
-		clValue.Tags = []CL_TYPE_TAG{CL_TYPE_TAG(src[pos])}
-		pos += TAG_LENGTH
+	var clType CLType
+	clType, err, length := clType.FromBytes(src[pos:])
+	if err != nil {
+		return CLValue{}, shiftDecodeError(err, pos), pos
 	}
+	pos += length
+	clValue.Type = clType
 
 	return clValue, nil, pos
 }
 
 func (c CLValue) ToBytes() []byte {
-	res := make([]byte, 4)
+	res := make([]byte, SIZE_LENGTH)
 	binary.LittleEndian.PutUint32(res, uint32(len(c.Bytes)))
 	res = append(res, c.Bytes...)
-
-	for _, tag := range c.Tags {
-		res = append(res, byte(tag))
-	}
-
-	if c.Tags[TAG_INDEX] == TAG_FIXED_LIST {
-		res = append(res, res[:SIZE_LENGTH]...)
-	}
+	res = append(res, c.Type.ToBytes()...)
 
 	return res
 }
 
-func (c CLValue) ToStateValues() *state.Value {
-	value := &state.Value{}
-	switch c.Tags[TAG_INDEX] {
+// ToStateValues converts the value to the legacy state.Value.
+//
+// state.Value has no representation for Bool, Option, Result, Map, Tuple1, Tuple3,
+// Tuple2 other than (String, Key) and lists other than List<I32>, List<U32>, List<String> and List<U8>,
+// so those types return an error. Use ToCLInstanceValue for them.
+func (c CLValue) ToStateValues() (*state.Value, error) {
+	instanceValue, err := c.ToCLInstanceValue()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Type.Tag {
 	case TAG_I32:
-		value = &state.Value{Value: &state.Value_IntValue{IntValue: int32(binary.LittleEndian.Uint32(c.Bytes))}}
-	case TAG_I64:
-		value = &state.Value{Value: &state.Value_LongValue{LongValue: binary.LittleEndian.Uint64(c.Bytes)}}
+		return &state.Value{Value: &state.Value_IntValue{IntValue: instanceValue.GetI32()}}, nil
 	case TAG_U8:
+		return &state.Value{Value: &state.Value_IntValue{IntValue: instanceValue.GetU8()}}, nil
 	case TAG_U32:
-		value = &state.Value{Value: &state.Value_IntValue{IntValue: int32(binary.LittleEndian.Uint32(c.Bytes))}}
+		return &state.Value{Value: &state.Value_IntValue{IntValue: int32(instanceValue.GetU32())}}, nil
+	case TAG_I64:
+		return &state.Value{Value: &state.Value_LongValue{LongValue: uint64(instanceValue.GetI64())}}, nil
 	case TAG_U64:
-		value = &state.Value{Value: &state.Value_LongValue{LongValue: binary.LittleEndian.Uint64(c.Bytes)}}
+		return &state.Value{Value: &state.Value_LongValue{LongValue: instanceValue.GetU64()}}, nil
 	case TAG_U128:
-		value = &state.Value{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: fromByteToBigInt(c.Bytes).String(), BitWidth: 128}}}
+		return &state.Value{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: instanceValue.GetU128().GetValue(), BitWidth: 128}}}, nil
 	case TAG_U256:
-		value = &state.Value{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: fromByteToBigInt(c.Bytes).String(), BitWidth: 256}}}
+		return &state.Value{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: instanceValue.GetU256().GetValue(), BitWidth: 256}}}, nil
 	case TAG_U512:
-		value = &state.Value{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: fromByteToBigInt(c.Bytes).String(), BitWidth: 512}}}
+		return &state.Value{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: instanceValue.GetU512().GetValue(), BitWidth: 512}}}, nil
+	case TAG_UNIT:
+		return &state.Value{Value: &state.Value_Unit{Unit: &state.Unit{}}}, nil
 	case TAG_STRING:
-		value = &state.Value{Value: &state.Value_StringValue{StringValue: string(c.Bytes)}}
+		return &state.Value{Value: &state.Value_StringValue{StringValue: instanceValue.GetStrValue()}}, nil
 	case TAG_KEY:
-		var key Key
-		key, _, _ = key.FromBytes(c.Bytes)
-		value = &state.Value{Value: &state.Value_Key{Key: key.ToStateValue()}}
+		return &state.Value{Value: &state.Value_Key{Key: instanceValue.GetKey()}}, nil
 	case TAG_UREF:
-		var uref URef
-		uref, _, _ = uref.FromBytes(c.Bytes)
-		value = &state.Value{Value: &state.Value_Key{Key: &state.Key{Value: &state.Key_Uref{Uref: uref.ToStateValue()}}}}
-	case TAG_LIST:
-		length := int(binary.LittleEndian.Uint32(c.Bytes[:SIZE_LENGTH]))
-		pos := length
-		switch c.Tags[TAG_LENGTH] {
+		return &state.Value{Value: &state.Value_Key{Key: &state.Key{Value: &state.Key_Uref{Uref: instanceValue.GetUref()}}}}, nil
+	case TAG_LIST, TAG_FIXED_LIST:
+		if c.Type.Inner[0].Tag == TAG_U8 {
+			return &state.Value{Value: &state.Value_BytesValue{BytesValue: instanceValue.GetBytesValue()}}, nil
+		}
+		if c.Type.Tag != TAG_LIST {
+			break
+		}
+
+		values := instanceValue.GetListValue().GetValues()
+		switch c.Type.Inner[0].Tag {
 		case TAG_I32, TAG_U32:
-			var values []int32
-			for i := 0; i < length; i++ {
-				val := int32(binary.LittleEndian.Uint32(c.Bytes[pos : pos+INT32_LENGTH]))
-				values = append(values, val)
-				pos += INT32_LENGTH
+			intValues := []int32{}
+			for _, value := range values {
+				if c.Type.Inner[0].Tag == TAG_I32 {
+					intValues = append(intValues, value.GetI32())
+				} else {
+					intValues = append(intValues, int32(value.GetU32()))
+				}
 			}
-
-			value = &state.Value{Value: &state.Value_IntList{
-				IntList: &state.IntList{Values: values},
-			}}
+			return &state.Value{Value: &state.Value_IntList{IntList: &state.IntList{Values: intValues}}}, nil
 		case TAG_STRING:
-			var values []string
-			for i := 0; i < length; i++ {
-				strLength := binary.LittleEndian.Uint32(c.Bytes[pos : pos+SIZE_LENGTH])
-				pos += SIZE_LENGTH
-				values = append(values, string(c.Bytes[pos:strLength]))
-				pos += int(strLength)
+			strValues := []string{}
+			for _, value := range values {
+				strValues = append(strValues, value.GetStrValue())
 			}
-
-			value = &state.Value{Value: &state.Value_StringList{
-				StringList: &state.StringList{Values: values},
-			}}
-		case TAG_U8:
-			value = &state.Value{Value: &state.Value_BytesValue{BytesValue: c.Bytes}}
+			return &state.Value{Value: &state.Value_StringList{StringList: &state.StringList{Values: strValues}}}, nil
+		}
+	case TAG_TUPLE2:
+		if c.Type.Inner[0].Tag == TAG_STRING && c.Type.Inner[1].Tag == TAG_KEY {
+			tuple := instanceValue.GetTuple2Value()
+			return &state.Value{Value: &state.Value_NamedKey{NamedKey: &state.NamedKey{
+				Name: tuple.GetValue_1().GetStrValue(),
+				Key:  tuple.GetValue_2().GetKey()}}}, nil
 		}
-	case TAG_FIXED_LIST:
-		value = &state.Value{Value: &state.Value_BytesValue{BytesValue: c.Bytes}}
 	}
-	return value
+
+	return nil, fmt.Errorf("%s can not be converted to state.Value", c.Type)
 }
 
 func (c CLValue) FromStateValue(value *state.Value) (CLValue, error) {
@@ -226,10 +175,10 @@ func (c CLValue) FromStateValue(value *state.Value) (CLValue, error) {
 		binary.LittleEndian.PutUint32(res, uint32(value.GetIntValue()))
 
 		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_I32}
+		c.Type = NewSimpleCLType(TAG_I32)
 	case *state.Value_BytesValue:
 		c.Bytes = value.GetBytesValue()
-		c.Tags = []CL_TYPE_TAG{TAG_FIXED_LIST, TAG_U8}
+		c.Type = NewFixedListCLType(NewSimpleCLType(TAG_U8), uint32(len(c.Bytes)))
 	case *state.Value_IntList:
 		res := make([]byte, SIZE_LENGTH)
 		binary.LittleEndian.PutUint32(res, uint32(len(value.GetIntList().GetValues())))
@@ -241,18 +190,16 @@ func (c CLValue) FromStateValue(value *state.Value) (CLValue, error) {
 		}
 
 		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_LIST, TAG_I32}
+		c.Type = NewListCLType(NewSimpleCLType(TAG_I32))
 	case *state.Value_StringValue:
 		res := make([]byte, SIZE_LENGTH)
 		binary.LittleEndian.PutUint32(res, uint32(len(value.GetStringValue())))
 		res = append(res, []byte(value.GetStringValue())...)
 
 		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_STRING}
-	case *state.Value_Account:
-		// stored value...
-	case *state.Value_Contract:
-		// stored value...
+		c.Type = NewSimpleCLType(TAG_STRING)
+	case *state.Value_Account, *state.Value_Contract:
+		return CLValue{}, errors.New("Account and Contract are stored values, not CLValue.")
 	case *state.Value_StringList:
 		res := make([]byte, SIZE_LENGTH)
 		binary.LittleEndian.PutUint32(res, uint32(len(value.GetStringList().GetValues())))
@@ -266,54 +213,54 @@ func (c CLValue) FromStateValue(value *state.Value) (CLValue, error) {
 		}
 
 		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_LIST, TAG_STRING}
+		c.Type = NewListCLType(NewSimpleCLType(TAG_STRING))
 	case *state.Value_NamedKey:
-		var namedKey NamedKey
-		namedKey, err := namedKey.FromStateValue(value.GetNamedKey())
+		keyBytes, err := stateKeyToBytes(value.GetNamedKey().GetKey())
 		if err != nil {
 			return CLValue{}, err
 		}
+		name := value.GetNamedKey().GetName()
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(name)))
+		res = append(res, []byte(name)...)
 
-		c.Bytes = namedKey.ToBytes()
-		c.Tags = []CL_TYPE_TAG{TAG_TUPLE2, TAG_STRING, TAG_KEY}
+		c.Bytes = append(res, keyBytes...)
+		c.Type = CLType{Tag: TAG_TUPLE2, Inner: []CLType{NewSimpleCLType(TAG_STRING), NewSimpleCLType(TAG_KEY)}}
 	case *state.Value_BigInt:
-		bigIntValue, ok := new(big.Int).SetString(value.GetBigInt().GetValue(), 10)
-		if !ok {
-			return CLValue{}, errors.New("Bigint data is invalid.")
-		}
-		bytes := reverseBytes(bigIntValue.Bytes())
-		res := []byte{byte(len(bytes))}
-
-		switch value.GetBigInt().GetBitWidth() {
+		bitWidth := int(value.GetBigInt().GetBitWidth())
+		switch bitWidth {
 		case 128:
-			c.Tags = []CL_TYPE_TAG{TAG_U128}
+			c.Type = NewSimpleCLType(TAG_U128)
 		case 256:
-			c.Tags = []CL_TYPE_TAG{TAG_U256}
+			c.Type = NewSimpleCLType(TAG_U256)
 		case 512:
-			c.Tags = []CL_TYPE_TAG{TAG_U512}
+			c.Type = NewSimpleCLType(TAG_U512)
 		default:
 			return CLValue{}, errors.New("Bigint data is invalid.")
 		}
 
-		c.Bytes = append(res, bytes...)
+		bytes, err := bigIntStringToBytes(value.GetBigInt().GetValue(), bitWidth)
+		if err != nil {
+			return CLValue{}, err
+		}
+		c.Bytes = bytes
 	case *state.Value_Key:
-		var key Key
-		key, err := key.FromStateValue(value.GetKey())
+		keyBytes, err := stateKeyToBytes(value.GetKey())
 		if err != nil {
 			return CLValue{}, err
 		}
 
-		c.Bytes = key.ToBytes()
-		c.Tags = []CL_TYPE_TAG{TAG_KEY}
+		c.Bytes = keyBytes
+		c.Type = NewSimpleCLType(TAG_KEY)
 	case *state.Value_Unit:
 		c.Bytes = []byte{}
-		c.Tags = []CL_TYPE_TAG{TAG_UNIT}
+		c.Type = NewSimpleCLType(TAG_UNIT)
 	case *state.Value_LongValue:
 		res := make([]byte, LONG_LENGTH)
 		binary.LittleEndian.PutUint64(res, value.GetLongValue())
 		c.Bytes = res
 
-		c.Tags = []CL_TYPE_TAG{TAG_I64}
+		c.Type = NewSimpleCLType(TAG_I64)
 	default:
 		return CLValue{}, errors.New("ClValue data is invalid.")
 	}
@@ -321,364 +268,89 @@ func (c CLValue) FromStateValue(value *state.Value) (CLValue, error) {
 	return c, nil
 }
 
-func (c CLValue) FromCLValueInstanceValue(value *state.CLValueInstance_Value) (CLValue, error) {
-	switch value.GetValue().(type) {
-	case *state.CLValueInstance_Value_I32:
-		res := make([]byte, INT32_LENGTH)
-		binary.LittleEndian.PutUint32(res, uint32(value.GetI32()))
-		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_I32}
-	case *state.CLValueInstance_Value_I64:
-		res := make([]byte, LONG_LENGTH)
-		binary.LittleEndian.PutUint64(res, uint64(value.GetI64()))
-		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_I64}
-	case *state.CLValueInstance_Value_U8:
-		c.Bytes = []byte{byte(value.GetU8())}
-		c.Tags = []CL_TYPE_TAG{TAG_U8}
-	case *state.CLValueInstance_Value_U32:
-		res := make([]byte, INT32_LENGTH)
-		binary.LittleEndian.PutUint32(res, uint32(value.GetU32()))
-		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_U32}
-	case *state.CLValueInstance_Value_U64:
-		res := make([]byte, LONG_LENGTH)
-		binary.LittleEndian.PutUint64(res, uint64(value.GetU64()))
-		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_U64}
-	case *state.CLValueInstance_Value_U128:
-		bigIntValue, ok := new(big.Int).SetString(value.GetU512().GetValue(), 10)
-		if !ok {
-			return CLValue{}, errors.New("Bigint data is invalid.")
-		}
-		bytes := reverseBytes(bigIntValue.Bytes())
-		res := []byte{byte(len(bytes))}
-		c.Tags = []CL_TYPE_TAG{TAG_U128}
-		c.Bytes = append(res, bytes...)
-	case *state.CLValueInstance_Value_U256:
-		bigIntValue, ok := new(big.Int).SetString(value.GetU512().GetValue(), 10)
-		if !ok {
-			return CLValue{}, errors.New("Bigint data is invalid.")
-		}
-		bytes := reverseBytes(bigIntValue.Bytes())
-		res := []byte{byte(len(bytes))}
-		c.Tags = []CL_TYPE_TAG{TAG_U256}
-		c.Bytes = append(res, bytes...)
-	case *state.CLValueInstance_Value_U512:
-		bigIntValue, ok := new(big.Int).SetString(value.GetU512().GetValue(), 10)
-		if !ok {
-			return CLValue{}, errors.New("Bigint data is invalid.")
-		}
-		bytes := reverseBytes(bigIntValue.Bytes())
-		res := []byte{byte(len(bytes))}
-		c.Tags = []CL_TYPE_TAG{TAG_U512}
-		c.Bytes = append(res, bytes...)
-	case *state.CLValueInstance_Value_StrValue:
-		res := make([]byte, SIZE_LENGTH)
-		binary.LittleEndian.PutUint32(res, uint32(len(value.GetStrValue())))
-		res = append(res, []byte(value.GetStrValue())...)
-		c.Bytes = res
-		c.Tags = []CL_TYPE_TAG{TAG_STRING}
-	case *state.CLValueInstance_Value_Key:
-		var key Key
-		key, err := key.FromStateValue(value.GetKey())
-		if err != nil {
-			return CLValue{}, err
-		}
-		c.Bytes = key.ToBytes()
-		c.Tags = []CL_TYPE_TAG{TAG_KEY}
-	case *state.CLValueInstance_Value_Uref:
-		var uref URef
-		uref, err := uref.FromStateValue(value.GetUref())
-		if err != nil {
-			return CLValue{}, err
-		}
-		c.Bytes = uref.ToBytes()
-		c.Tags = []CL_TYPE_TAG{TAG_UREF}
-	case *state.CLValueInstance_Value_OptionValue:
-		clValue, err := c.FromCLValueInstanceValue(value.GetOptionValue().GetValue())
-		if err != nil {
-			return CLValue{}, err
-		}
-
-		res := make([]byte, OPTION_SIZE_LENGTH)
-		if len(clValue.Bytes) > 0 {
-			res[0] = 1
-			res = append(res, clValue.Bytes...)
-		}
-		c.Bytes = res
-		c.Tags = append([]CL_TYPE_TAG{TAG_OPTION}, clValue.Tags...)
-	case *state.CLValueInstance_Value_ListValue:
-		c.Tags = []CL_TYPE_TAG{TAG_LIST}
-		res := make([]byte, SIZE_LENGTH)
-		binary.LittleEndian.PutUint32(res, uint32(len(value.GetListValue().GetValues())))
-		for idx, val := range value.GetListValue().GetValues() {
-			clValue, err := c.FromCLValueInstanceValue(val)
-			if err != nil {
-				return CLValue{}, err
-			}
-
-			res = append(res, clValue.Bytes...)
-			if idx == 0 {
-				c.Tags = append(c.Tags, clValue.Tags...)
-			}
-		}
-		c.Bytes = res
-	case *state.CLValueInstance_Value_FixedListValue:
-		c.Tags = []CL_TYPE_TAG{TAG_FIXED_LIST}
-		res := make([]byte, SIZE_LENGTH)
-		binary.LittleEndian.PutUint32(res, uint32(len(value.GetListValue().GetValues())))
-		for idx, val := range value.GetFixedListValue().GetValues() {
-			clValue, err := c.FromCLValueInstanceValue(val)
-			if err != nil {
-				return CLValue{}, err
-			}
-
-			res = append(res, clValue.Bytes...)
-			if idx == 0 {
-				c.Tags = append(c.Tags, clValue.Tags...)
-			}
-		}
-		c.Bytes = res
-	case *state.CLValueInstance_Value_MapValue:
-		c.Tags = []CL_TYPE_TAG{TAG_MAP}
-		res := make([]byte, SIZE_LENGTH)
-		binary.LittleEndian.PutUint32(res, uint32(len(value.GetMapValue().GetValues())))
-		for idx, val := range value.GetMapValue().GetValues() {
-			clValueKey, err := c.FromCLValueInstanceValue(val.GetKey())
-			if err != nil {
-				return CLValue{}, err
-			}
-			c.Bytes = append(res, clValueKey.Bytes...)
-
-			clValueValue, err := c.FromCLValueInstanceValue(val.GetValue())
-			if err != nil {
-				return CLValue{}, err
-			}
-			c.Bytes = append(res, clValueValue.Bytes...)
-
-			if idx == 0 {
-				c.Tags = append(c.Tags, clValueKey.Tags...)
-				c.Tags = append(c.Tags, clValueValue.Tags...)
-			}
-		}
-	case *state.CLValueInstance_Value_Unit:
-	case *state.CLValueInstance_Value_ResultValue:
-	case *state.CLValueInstance_Value_Tuple1Value:
-	case *state.CLValueInstance_Value_Tuple2Value:
-	case *state.CLValueInstance_Value_Tuple3Value:
-	case *state.CLValueInstance_Value_BytesValue:
-		c.Bytes = value.GetBytesValue()
-		c.Tags = []CL_TYPE_TAG{TAG_FIXED_LIST, TAG_U8}
-	default:
-		return CLValue{}, errors.New("ClValue data is invalid.")
+// ToStateCLValue converts the value into the state.CLValue of a state.StoredValue.
+func (c CLValue) ToStateCLValue() (*state.CLValue, error) {
+	clType, err := c.Type.ToStateValue()
+	if err != nil {
+		return nil, err
 	}
 
-	return c, nil
+	return &state.CLValue{ClType: clType, SerializedValue: append([]byte{}, c.Bytes...)}, nil
 }
 
-func (c CLValue) ToCLInstanceValue() (value *state.CLValueInstance_Value) {
-	value = &state.CLValueInstance_Value{}
-
-	switch c.Tags[TAG_INDEX] {
-	case TAG_BOOL:
-		boolValue := false
-		if c.Bytes[0] == 1 {
-			boolValue = true
-		}
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_BoolValue{
-				BoolValue: boolValue,
-			},
-		}
-	case TAG_I32:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_I32{
-				I32: int32(binary.LittleEndian.Uint32(c.Bytes)),
-			},
-		}
-	case TAG_I64:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_I64{
-				I64: int64(binary.LittleEndian.Uint64(c.Bytes)),
-			},
-		}
-	case TAG_U8:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_U8{
-				U8: int32(c.Bytes[0]),
-			},
-		}
-	case TAG_U32:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_U32{
-				U32: binary.LittleEndian.Uint32(c.Bytes),
-			},
-		}
-	case TAG_U64:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_U64{
-				U64: binary.LittleEndian.Uint64(c.Bytes),
-			},
-		}
-	case TAG_U128:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_U128{
-				U128: &state.CLValueInstance_U128{
-					Value: fromByteToBigInt(c.Bytes).String(),
-				},
-			},
-		}
-	case TAG_U256:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_U256{
-				U256: &state.CLValueInstance_U256{
-					Value: fromByteToBigInt(c.Bytes).String(),
-				},
-			},
-		}
-	case TAG_U512:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_U512{
-				U512: &state.CLValueInstance_U512{
-					Value: fromByteToBigInt(c.Bytes).String(),
-				},
-			},
-		}
-	case TAG_STRING:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_StrValue{
-				StrValue: string(c.Bytes),
-			},
-		}
-	case TAG_KEY:
-		var key Key
-		key, _, _ = key.FromBytes(c.Bytes)
-		value = key.ToCLInstanceValue()
-	case TAG_UREF:
-		var uref URef
-		uref, _, _ = uref.FromBytes(c.Bytes)
-		value = uref.ToCLInstanceValue()
-	case TAG_LIST:
-		entrys := []*state.CLValueInstance_Value{}
-		if c.Tags[TAG_LENGTH] == TAG_U8 {
-			return &state.CLValueInstance_Value{
-				Value: &state.CLValueInstance_Value_BytesValue{
-					BytesValue: c.Bytes,
-				},
-			}
-		}
-		length := int(binary.LittleEndian.Uint32(c.Bytes[:SIZE_LENGTH]))
-		pos := length
-		for i := 0; i < length; i++ {
-			entry, entryLength := parserClInstanceValue(c.Bytes[pos:], c.Tags[TAG_LENGTH])
-			entrys = append(entrys, entry)
-			pos += entryLength
-		}
-
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_ListValue{
-				ListValue: &state.CLValueInstance_List{
-					Values: entrys,
-				},
-			},
-		}
-	case TAG_FIXED_LIST:
-		entrys := []*state.CLValueInstance_Value{}
-		if c.Tags[TAG_LENGTH] == TAG_U8 {
-			return &state.CLValueInstance_Value{
-				Value: &state.CLValueInstance_Value_BytesValue{
-					BytesValue: c.Bytes,
-				},
-			}
-		}
-		length := int(binary.LittleEndian.Uint32(c.Bytes[:SIZE_LENGTH]))
-		pos := length
-		for i := 0; i < length; i++ {
-			entry, entryLength := parserClInstanceValue(c.Bytes[pos:], c.Tags[TAG_LENGTH])
-			entrys = append(entrys, entry)
-			pos += entryLength
-		}
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_FixedListValue{
-				FixedListValue: &state.CLValueInstance_FixedList{
-					Length: uint32(length),
-					Values: entrys,
-				},
-			},
-		}
-	case TAG_MAP:
-		entrys := []*state.CLValueInstance_MapEntry{}
-		length := int(binary.LittleEndian.Uint32(c.Bytes[:SIZE_LENGTH]))
-		pos := SIZE_LENGTH
-		for i := 0; i < length; i++ {
-			// key
-			entryKey, entryKeyLength := parserClInstanceValue(c.Bytes[pos:], c.Tags[TAG_LENGTH])
-			pos += entryKeyLength
-
-			// value
-			entryValue, entryValueLength := parserClInstanceValue(c.Bytes[pos:], c.Tags[TAG_LENGTH+TAG_LENGTH])
-			pos += entryValueLength
-
-			entry := &state.CLValueInstance_MapEntry{
-				Key:   entryKey,
-				Value: entryValue,
-			}
+// FromStateCLValue builds a CLValue from the state.CLValue of a state.StoredValue.
+func (c CLValue) FromStateCLValue(clValue *state.CLValue) (CLValue, error) {
+	if clValue.GetClType() == nil {
+		return CLValue{}, fmt.Errorf("CLValue has no CLType")
+	}
 
-			entrys = append(entrys, entry)
-		}
+	clType, err := c.Type.FromStateValue(clValue.GetClType())
+	if err != nil {
+		return CLValue{}, err
+	}
 
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_MapValue{
-				MapValue: &state.CLValueInstance_Map{
-					Values: entrys,
-				},
-			},
-		}
-	case TAG_UNIT:
+	return NewClValue(append([]byte{}, clValue.GetSerializedValue()...), clType), nil
+}
 
-	case TAG_OPTION:
+// FromCLValueInstanceValue builds a CLValue from a value whose CL type is inferred.
+// Use CLValueInstanceToBytes to serialize values whose type is known.
+func (c CLValue) FromCLValueInstanceValue(value *state.CLValueInstance_Value) (CLValue, error) {
+	clType, err := InferCLType(value)
+	if err != nil {
+		return CLValue{}, err
+	}
 
-	case TAG_RESULT:
+	bytes, err := CLValueInstanceValueToBytes(clType, value)
+	if err != nil {
+		return CLValue{}, err
+	}
 
-	case TAG_TUPLE1:
+	c.Type, err = c.Type.FromStateValue(clType)
+	if err != nil {
+		return CLValue{}, err
+	}
+	c.Bytes = bytes
 
-	case TAG_TUPLE2:
+	return c, nil
+}
 
-	case TAG_TUPLE3:
+// FromCLValueInstance builds a CLValue from a value with an explicit CL type.
+func (c CLValue) FromCLValueInstance(instance *state.CLValueInstance) (CLValue, error) {
+	bytes, err := CLValueInstanceValueToBytes(instance.GetClType(), instance.GetValue())
+	if err != nil {
+		return CLValue{}, err
+	}
 
-	case TAG_ANY:
+	c.Type, err = c.Type.FromStateValue(instance.GetClType())
+	if err != nil {
+		return CLValue{}, err
 	}
+	c.Bytes = bytes
 
-	return value
+	return c, nil
 }
 
-func parserClInstanceValue(src []byte, tag CL_TYPE_TAG) (value *state.CLValueInstance_Value, length int) {
-	pos := 0
-	length = 0
+// ToCLInstanceValue decodes the value bytes according to the CL type.
+func (c CLValue) ToCLInstanceValue() (*state.CLValueInstance_Value, error) {
+	value, err := c.decodeBytes(STORED_VALUE_FORMAT_V1)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %s", c.Type, err.Error())
+	}
 
-	switch tag {
-	case TAG_I32, TAG_U32:
-		length = INT32_LENGTH
-	case TAG_I64, TAG_U64:
-		length = LONG_LENGTH
-	case TAG_U8:
-		value = &state.CLValueInstance_Value{
-			Value: &state.CLValueInstance_Value_BytesValue{
-				BytesValue: src,
-			},
-		}
+	return clValueInstanceValueOf(c.Type, value), nil
+}
 
-		return value, len(src)
-	default:
-		length = int(binary.LittleEndian.Uint32(src[:SIZE_LENGTH]))
-		pos += SIZE_LENGTH
+func (c CLValue) ToCLValueInstance() (*state.CLValueInstance, error) {
+	clType, err := c.Type.ToStateValue()
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.ToCLInstanceValue()
+	if err != nil {
+		return nil, err
 	}
 
-	clValue := NewClValue(src[pos:pos+length], []CL_TYPE_TAG{tag})
-	value = clValue.ToCLInstanceValue()
-
-	return value, pos + length
+	return &state.CLValueInstance{ClType: clType, Value: value}, nil
 }
 
 func reverseBytes(src []byte) []byte {
@@ -0,0 +1,708 @@
+package storedvalue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+)
+
+const (
+	OPTION_NONE_TAG = 0
+	OPTION_SOME_TAG = 1
+
+	RESULT_ERR_TAG = 0
+	RESULT_OK_TAG  = 1
+
+	U8_MAX = 255
+
+	// MAX_ZERO_SIZED_SEQUENCE_LENGTH 는 0 byte 인 원소로 이루어진 list 의 최대 원소 개수.
+	MAX_ZERO_SIZED_SEQUENCE_LENGTH = 1 << 16
+)
+
+// CLValueInstanceToBytes 는 CLValueInstance 를 EE 의 CLValue ABI 형식으로 serialize 하는 함수.
+// serialize 된 값의 u32 길이, serialize 된 값, serialize 된 CL type 순서이다.
+// cl_type 이 없으면 값으로부터 type 을 추론한다.
+func CLValueInstanceToBytes(instance *state.CLValueInstance) ([]byte, error) {
+	clType := instance.GetClType()
+	if clType.GetVariants() == nil {
+		var err error
+		clType, err = InferCLType(instance.GetValue())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	valueBytes, err := CLValueInstanceValueToBytes(clType, instance.GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	typeBytes, err := CLTypeToBytes(clType)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(res, uint32(len(valueBytes)))
+	res = append(res, valueBytes...)
+	res = append(res, typeBytes...)
+
+	return res, nil
+}
+
+// CLTypeToBytes 는 CL type 을 tag 와 serialize 된 inner type 들의 순서로 serialize 하는 함수.
+func CLTypeToBytes(clType *state.CLType) ([]byte, error) {
+	res, err := CLType{}.FromStateValue(clType)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ToBytes(), nil
+}
+
+// CLValueInstanceValueToBytes 는 값을 주어진 CL type 의 EE bytesrepr 형식으로 serialize 하는 함수.
+func CLValueInstanceValueToBytes(clType *state.CLType, value *state.CLValueInstance_Value) ([]byte, error) {
+	switch clType.GetVariants().(type) {
+	case *state.CLType_SimpleType:
+		return simpleValueToBytes(clType.GetSimpleType(), value)
+	case *state.CLType_OptionType:
+		optionValue, ok := value.GetValue().(*state.CLValueInstance_Value_OptionValue)
+		if !ok {
+			return nil, typeMismatchError("Option", value)
+		}
+		if optionValue.OptionValue.GetValue().GetValue() == nil {
+			return []byte{OPTION_NONE_TAG}, nil
+		}
+		innerBytes, err := CLValueInstanceValueToBytes(clType.GetOptionType().GetInner(), optionValue.OptionValue.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{OPTION_SOME_TAG}, innerBytes...), nil
+	case *state.CLType_ListType:
+		inner := clType.GetListType().GetInner()
+		switch listValue := value.GetValue().(type) {
+		case *state.CLValueInstance_Value_BytesValue:
+			if inner.GetSimpleType() != state.CLType_U8 {
+				return nil, typeMismatchError("List", value)
+			}
+			res := make([]byte, SIZE_LENGTH)
+			binary.LittleEndian.PutUint32(res, uint32(len(listValue.BytesValue)))
+			return append(res, listValue.BytesValue...), nil
+		case *state.CLValueInstance_Value_ListValue:
+			return sequenceToBytes(inner, listValue.ListValue.GetValues(), true)
+		default:
+			return nil, typeMismatchError("List", value)
+		}
+	case *state.CLType_FixedListType:
+		fixedListType := clType.GetFixedListType()
+		inner := fixedListType.GetInner()
+		switch fixedListValue := value.GetValue().(type) {
+		case *state.CLValueInstance_Value_BytesValue:
+			if inner.GetSimpleType() != state.CLType_U8 {
+				return nil, typeMismatchError("FixedList", value)
+			}
+			if uint32(len(fixedListValue.BytesValue)) != fixedListType.GetLen() {
+				return nil, fmt.Errorf("FixedList length must be %d, but %d", fixedListType.GetLen(), len(fixedListValue.BytesValue))
+			}
+			return fixedListValue.BytesValue, nil
+		case *state.CLValueInstance_Value_FixedListValue:
+			values := fixedListValue.FixedListValue.GetValues()
+			if uint32(len(values)) != fixedListType.GetLen() {
+				return nil, fmt.Errorf("FixedList length must be %d, but %d", fixedListType.GetLen(), len(values))
+			}
+			// [u8; N] 은 bytes 그대로, 다른 array 는 길이를 앞에 붙여 serialize 한다.
+			return sequenceToBytes(inner, values, inner.GetSimpleType() != state.CLType_U8)
+		default:
+			return nil, typeMismatchError("FixedList", value)
+		}
+	case *state.CLType_ResultType:
+		resultValue, ok := value.GetValue().(*state.CLValueInstance_Value_ResultValue)
+		if !ok {
+			return nil, typeMismatchError("Result", value)
+		}
+		switch resultValue.ResultValue.GetValue().(type) {
+		case *state.CLValueInstance_Result_Ok:
+			okBytes, err := CLValueInstanceValueToBytes(clType.GetResultType().GetOk(), resultValue.ResultValue.GetOk())
+			if err != nil {
+				return nil, err
+			}
+			return append([]byte{RESULT_OK_TAG}, okBytes...), nil
+		case *state.CLValueInstance_Result_Err:
+			errBytes, err := CLValueInstanceValueToBytes(clType.GetResultType().GetErr(), resultValue.ResultValue.GetErr())
+			if err != nil {
+				return nil, err
+			}
+			return append([]byte{RESULT_ERR_TAG}, errBytes...), nil
+		default:
+			return nil, errors.New("Result value must be ok or err.")
+		}
+	case *state.CLType_MapType:
+		mapValue, ok := value.GetValue().(*state.CLValueInstance_Value_MapValue)
+		if !ok {
+			return nil, typeMismatchError("Map", value)
+		}
+		entries := mapValue.MapValue.GetValues()
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(entries)))
+		for _, entry := range entries {
+			keyBytes, err := CLValueInstanceValueToBytes(clType.GetMapType().GetKey(), entry.GetKey())
+			if err != nil {
+				return nil, err
+			}
+			valueBytes, err := CLValueInstanceValueToBytes(clType.GetMapType().GetValue(), entry.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, keyBytes...)
+			res = append(res, valueBytes...)
+		}
+		return res, nil
+	case *state.CLType_Tuple1Type:
+		tupleValue, ok := value.GetValue().(*state.CLValueInstance_Value_Tuple1Value)
+		if !ok {
+			return nil, typeMismatchError("Tuple1", value)
+		}
+		return tupleToBytes(
+			[]*state.CLType{clType.GetTuple1Type().GetType0()},
+			[]*state.CLValueInstance_Value{tupleValue.Tuple1Value.GetValue_1()})
+	case *state.CLType_Tuple2Type:
+		tupleValue, ok := value.GetValue().(*state.CLValueInstance_Value_Tuple2Value)
+		if !ok {
+			return nil, typeMismatchError("Tuple2", value)
+		}
+		return tupleToBytes(
+			[]*state.CLType{clType.GetTuple2Type().GetType0(), clType.GetTuple2Type().GetType1()},
+			[]*state.CLValueInstance_Value{tupleValue.Tuple2Value.GetValue_1(), tupleValue.Tuple2Value.GetValue_2()})
+	case *state.CLType_Tuple3Type:
+		tupleValue, ok := value.GetValue().(*state.CLValueInstance_Value_Tuple3Value)
+		if !ok {
+			return nil, typeMismatchError("Tuple3", value)
+		}
+		return tupleToBytes(
+			[]*state.CLType{clType.GetTuple3Type().GetType0(), clType.GetTuple3Type().GetType1(), clType.GetTuple3Type().GetType2()},
+			[]*state.CLValueInstance_Value{tupleValue.Tuple3Value.GetValue_1(), tupleValue.Tuple3Value.GetValue_2(), tupleValue.Tuple3Value.GetValue_3()})
+	case *state.CLType_AnyType:
+		return nil, errors.New("Any type value can not be serialized.")
+	default:
+		return nil, errors.New("CLType data is invalid.")
+	}
+}
+
+func sequenceToBytes(inner *state.CLType, values []*state.CLValueInstance_Value, withLength bool) ([]byte, error) {
+	res := []byte{}
+	if withLength {
+		res = make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(values)))
+	}
+
+	for _, value := range values {
+		valueBytes, err := CLValueInstanceValueToBytes(inner, value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, valueBytes...)
+	}
+
+	return res, nil
+}
+
+func tupleToBytes(clTypes []*state.CLType, values []*state.CLValueInstance_Value) ([]byte, error) {
+	res := []byte{}
+	for idx, clType := range clTypes {
+		valueBytes, err := CLValueInstanceValueToBytes(clType, values[idx])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, valueBytes...)
+	}
+
+	return res, nil
+}
+
+func simpleValueToBytes(simpleType state.CLType_Simple, value *state.CLValueInstance_Value) ([]byte, error) {
+	switch simpleType {
+	case state.CLType_BOOL:
+		boolValue, ok := value.GetValue().(*state.CLValueInstance_Value_BoolValue)
+		if !ok {
+			return nil, typeMismatchError("Bool", value)
+		}
+		if boolValue.BoolValue {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case state.CLType_I32:
+		i32Value, ok := value.GetValue().(*state.CLValueInstance_Value_I32)
+		if !ok {
+			return nil, typeMismatchError("I32", value)
+		}
+		res := make([]byte, INT32_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(i32Value.I32))
+		return res, nil
+	case state.CLType_I64:
+		i64Value, ok := value.GetValue().(*state.CLValueInstance_Value_I64)
+		if !ok {
+			return nil, typeMismatchError("I64", value)
+		}
+		res := make([]byte, LONG_LENGTH)
+		binary.LittleEndian.PutUint64(res, uint64(i64Value.I64))
+		return res, nil
+	case state.CLType_U8:
+		u8Value, ok := value.GetValue().(*state.CLValueInstance_Value_U8)
+		if !ok {
+			return nil, typeMismatchError("U8", value)
+		}
+		if u8Value.U8 < 0 || u8Value.U8 > U8_MAX {
+			return nil, fmt.Errorf("U8 value must be in [0, %d], but %d", U8_MAX, u8Value.U8)
+		}
+		return []byte{byte(u8Value.U8)}, nil
+	case state.CLType_U32:
+		u32Value, ok := value.GetValue().(*state.CLValueInstance_Value_U32)
+		if !ok {
+			return nil, typeMismatchError("U32", value)
+		}
+		res := make([]byte, UINT32_LENGTH)
+		binary.LittleEndian.PutUint32(res, u32Value.U32)
+		return res, nil
+	case state.CLType_U64:
+		u64Value, ok := value.GetValue().(*state.CLValueInstance_Value_U64)
+		if !ok {
+			return nil, typeMismatchError("U64", value)
+		}
+		res := make([]byte, LONG_LENGTH)
+		binary.LittleEndian.PutUint64(res, u64Value.U64)
+		return res, nil
+	case state.CLType_U128:
+		u128Value, ok := value.GetValue().(*state.CLValueInstance_Value_U128)
+		if !ok {
+			return nil, typeMismatchError("U128", value)
+		}
+		return bigIntStringToBytes(u128Value.U128.GetValue(), 128)
+	case state.CLType_U256:
+		u256Value, ok := value.GetValue().(*state.CLValueInstance_Value_U256)
+		if !ok {
+			return nil, typeMismatchError("U256", value)
+		}
+		return bigIntStringToBytes(u256Value.U256.GetValue(), 256)
+	case state.CLType_U512:
+		u512Value, ok := value.GetValue().(*state.CLValueInstance_Value_U512)
+		if !ok {
+			return nil, typeMismatchError("U512", value)
+		}
+		return bigIntStringToBytes(u512Value.U512.GetValue(), 512)
+	case state.CLType_UNIT:
+		if _, ok := value.GetValue().(*state.CLValueInstance_Value_Unit); !ok {
+			return nil, typeMismatchError("Unit", value)
+		}
+		return []byte{}, nil
+	case state.CLType_STRING:
+		strValue, ok := value.GetValue().(*state.CLValueInstance_Value_StrValue)
+		if !ok {
+			return nil, typeMismatchError("String", value)
+		}
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(strValue.StrValue)))
+		return append(res, []byte(strValue.StrValue)...), nil
+	case state.CLType_KEY:
+		keyValue, ok := value.GetValue().(*state.CLValueInstance_Value_Key)
+		if !ok {
+			return nil, typeMismatchError("Key", value)
+		}
+		return stateKeyToBytes(keyValue.Key)
+	case state.CLType_UREF:
+		urefValue, ok := value.GetValue().(*state.CLValueInstance_Value_Uref)
+		if !ok {
+			return nil, typeMismatchError("URef", value)
+		}
+		return stateURefToBytes(urefValue.Uref)
+	default:
+		return nil, fmt.Errorf("Unknown simple CLType %d", simpleType)
+	}
+}
+
+func bigIntStringToBytes(str string, bitWidth int) ([]byte, error) {
+	bigIntValue, ok := new(big.Int).SetString(str, 10)
+	if !ok || bigIntValue.Sign() < 0 {
+		return nil, errors.New("Bigint data is invalid.")
+	}
+	if bigIntValue.BitLen() > bitWidth {
+		return nil, fmt.Errorf("Bigint value overflows U%d", bitWidth)
+	}
+
+	bytes := reverseBytes(bigIntValue.Bytes())
+	return append([]byte{byte(len(bytes))}, bytes...), nil
+}
+
+func stateKeyToBytes(key *state.Key) ([]byte, error) {
+	switch key.GetValue().(type) {
+	case *state.Key_Address_:
+		return addressToKeyBytes(KEY_ID_ACCOUNT, key.GetAddress().GetAccount())
+	case *state.Key_Hash_:
+		return addressToKeyBytes(KEY_ID_HASH, key.GetHash().GetHash())
+	case *state.Key_Uref:
+		urefBytes, err := stateURefToBytes(key.GetUref())
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{byte(KEY_ID_UREF)}, urefBytes...), nil
+	case *state.Key_Local_:
+		return addressToKeyBytes(KEY_ID_LOCAL, key.GetLocal().GetHash())
+	default:
+		return nil, errors.New("Key data is invalid.")
+	}
+}
+
+func addressToKeyBytes(keyID KEY_ID, address []byte) ([]byte, error) {
+	if len(address) != ADDRESS_LENGTH {
+		return nil, fmt.Errorf("Key address must be %d, but %d", ADDRESS_LENGTH, len(address))
+	}
+
+	return append([]byte{byte(keyID)}, address...), nil
+}
+
+func stateURefToBytes(uref *state.Key_URef) ([]byte, error) {
+	if len(uref.GetUref()) != ADDRESS_LENGTH {
+		return nil, fmt.Errorf("URef address must be %d, but %d", ADDRESS_LENGTH, len(uref.GetUref()))
+	}
+
+	res := make([]byte, 0, ADDRESS_LENGTH+UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH)
+	res = append(res, uref.GetUref()...)
+	return append(res, byte(uref.GetAccessRights())), nil
+}
+
+func typeMismatchError(expected string, value *state.CLValueInstance_Value) error {
+	return fmt.Errorf("Type mismatch : expected (%s), but (%T)", expected, value.GetValue())
+}
+
+// InferCLType 은 cl_type 없이 주어진 값의 CL type 을 추론하는 함수.
+// 빈 list, map, None option 과 result 는 추론할 수 없다.
+func InferCLType(value *state.CLValueInstance_Value) (*state.CLType, error) {
+	switch v := value.GetValue().(type) {
+	case *state.CLValueInstance_Value_BoolValue:
+		return simpleCLType(state.CLType_BOOL), nil
+	case *state.CLValueInstance_Value_I32:
+		return simpleCLType(state.CLType_I32), nil
+	case *state.CLValueInstance_Value_I64:
+		return simpleCLType(state.CLType_I64), nil
+	case *state.CLValueInstance_Value_U8:
+		return simpleCLType(state.CLType_U8), nil
+	case *state.CLValueInstance_Value_U32:
+		return simpleCLType(state.CLType_U32), nil
+	case *state.CLValueInstance_Value_U64:
+		return simpleCLType(state.CLType_U64), nil
+	case *state.CLValueInstance_Value_U128:
+		return simpleCLType(state.CLType_U128), nil
+	case *state.CLValueInstance_Value_U256:
+		return simpleCLType(state.CLType_U256), nil
+	case *state.CLValueInstance_Value_U512:
+		return simpleCLType(state.CLType_U512), nil
+	case *state.CLValueInstance_Value_Unit:
+		return simpleCLType(state.CLType_UNIT), nil
+	case *state.CLValueInstance_Value_StrValue:
+		return simpleCLType(state.CLType_STRING), nil
+	case *state.CLValueInstance_Value_Key:
+		return simpleCLType(state.CLType_KEY), nil
+	case *state.CLValueInstance_Value_Uref:
+		return simpleCLType(state.CLType_UREF), nil
+	case *state.CLValueInstance_Value_OptionValue:
+		if v.OptionValue.GetValue().GetValue() == nil {
+			return nil, errors.New("CLType of None option can not be inferred.")
+		}
+		inner, err := InferCLType(v.OptionValue.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{Inner: inner}}}, nil
+	case *state.CLValueInstance_Value_ListValue:
+		if len(v.ListValue.GetValues()) == 0 {
+			return nil, errors.New("CLType of empty list can not be inferred.")
+		}
+		inner, err := InferCLType(v.ListValue.GetValues()[0])
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: inner}}}, nil
+	case *state.CLValueInstance_Value_FixedListValue:
+		if len(v.FixedListValue.GetValues()) == 0 {
+			return nil, errors.New("CLType of empty fixed list can not be inferred.")
+		}
+		inner, err := InferCLType(v.FixedListValue.GetValues()[0])
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{
+			Inner: inner,
+			Len:   uint32(len(v.FixedListValue.GetValues()))}}}, nil
+	case *state.CLValueInstance_Value_ResultValue:
+		return nil, errors.New("CLType of result can not be inferred.")
+	case *state.CLValueInstance_Value_MapValue:
+		if len(v.MapValue.GetValues()) == 0 {
+			return nil, errors.New("CLType of empty map can not be inferred.")
+		}
+		keyType, err := InferCLType(v.MapValue.GetValues()[0].GetKey())
+		if err != nil {
+			return nil, err
+		}
+		valueType, err := InferCLType(v.MapValue.GetValues()[0].GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_MapType{MapType: &state.CLType_Map{Key: keyType, Value: valueType}}}, nil
+	case *state.CLValueInstance_Value_Tuple1Value:
+		type0, err := InferCLType(v.Tuple1Value.GetValue_1())
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_Tuple1Type{Tuple1Type: &state.CLType_Tuple1{Type0: type0}}}, nil
+	case *state.CLValueInstance_Value_Tuple2Value:
+		type0, err := InferCLType(v.Tuple2Value.GetValue_1())
+		if err != nil {
+			return nil, err
+		}
+		type1, err := InferCLType(v.Tuple2Value.GetValue_2())
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_Tuple2Type{Tuple2Type: &state.CLType_Tuple2{Type0: type0, Type1: type1}}}, nil
+	case *state.CLValueInstance_Value_Tuple3Value:
+		type0, err := InferCLType(v.Tuple3Value.GetValue_1())
+		if err != nil {
+			return nil, err
+		}
+		type1, err := InferCLType(v.Tuple3Value.GetValue_2())
+		if err != nil {
+			return nil, err
+		}
+		type2, err := InferCLType(v.Tuple3Value.GetValue_3())
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLType{Variants: &state.CLType_Tuple3Type{Tuple3Type: &state.CLType_Tuple3{Type0: type0, Type1: type1, Type2: type2}}}, nil
+	case *state.CLValueInstance_Value_BytesValue:
+		return &state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{
+			Inner: simpleCLType(state.CLType_U8),
+			Len:   uint32(len(v.BytesValue))}}}, nil
+	default:
+		return nil, errors.New("ClValue data is invalid.")
+	}
+}
+
+func simpleCLType(simpleType state.CLType_Simple) *state.CLType {
+	return &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: simpleType}}
+}
+
+// CLValueInstanceFromBytes 는 CLValueInstanceToBytes 가 만드는 EE 의 CLValue ABI 형식을 deserialize 하는 함수.
+func CLValueInstanceFromBytes(src []byte) (instance *state.CLValueInstance, err error, pos int) {
+	valueLength, err := sizeFromBytes(src, pos, "CLValue")
+	if err != nil {
+		return nil, err, pos
+	}
+	pos = SIZE_LENGTH
+	if err := checkLength(src, pos, valueLength, "CLValue"); err != nil {
+		return nil, err, pos
+	}
+	valueBytes := src[pos : pos+valueLength]
+	pos += valueLength
+
+	clType, err, length := CLTypeFromBytes(src[pos:])
+	if err != nil {
+		return nil, shiftDecodeError(err, pos), pos
+	}
+	pos += length
+
+	value, err, length := CLValueInstanceValueFromBytes(clType, valueBytes)
+	if err != nil {
+		return nil, shiftDecodeError(err, SIZE_LENGTH), pos
+	}
+	if length != len(valueBytes) {
+		return nil, decodeErrorf(SIZE_LENGTH+length, "CLValue value has %d trailing bytes", len(valueBytes)-length), pos
+	}
+
+	return &state.CLValueInstance{ClType: clType, Value: value}, nil, pos
+}
+
+// CLTypeFromBytes 는 CLTypeToBytes 로 serialize 된 CL type 을 deserialize 하는 함수.
+func CLTypeFromBytes(src []byte) (clType *state.CLType, err error, pos int) {
+	res, err, pos := CLType{}.FromBytes(src)
+	if err != nil {
+		return nil, err, pos
+	}
+	clType, err = res.ToStateValue()
+	if err != nil {
+		return nil, err, pos
+	}
+
+	return clType, nil, pos
+}
+
+// CLValueInstanceValueFromBytes 는 주어진 CL type 의 값을 deserialize 하는 함수.
+// List(U8) 와 FixedList(U8) 는 bytes_value 로 return 한다.
+func CLValueInstanceValueFromBytes(clType *state.CLType, src []byte) (value *state.CLValueInstance_Value, err error, pos int) {
+	res, err := CLType{}.FromStateValue(clType)
+	if err != nil {
+		return nil, err, pos
+	}
+	if err := res.Validate(); err != nil {
+		return nil, err, pos
+	}
+
+	decoded, pos, err := decodeCLValue(res, src, STORED_VALUE_FORMAT_V1)
+	if err != nil {
+		return nil, err, pos
+	}
+
+	return clValueInstanceValueOf(res, decoded), nil, pos
+}
+
+// clValueInstanceValueOf 는 decodeCLValue 가 decode 한 값을 CLValueInstance 의 값으로 변환하는 함수.
+func clValueInstanceValueOf(clType CLType, value interface{}) *state.CLValueInstance_Value {
+	values := func(typeOf func(idx int) CLType) []*state.CLValueInstance_Value {
+		res := []*state.CLValueInstance_Value{}
+		for idx, elem := range value.([]interface{}) {
+			res = append(res, clValueInstanceValueOf(typeOf(idx), elem))
+		}
+		return res
+	}
+	listElem := func(int) CLType { return clType.Inner[0] }
+	tupleElem := func(idx int) CLType { return clType.Inner[idx] }
+
+	switch clType.Tag {
+	case TAG_BOOL:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BoolValue{BoolValue: value.(bool)}}
+	case TAG_I32:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I32{I32: value.(int32)}}
+	case TAG_I64:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I64{I64: value.(int64)}}
+	case TAG_U8:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U8{U8: int32(value.(uint8))}}
+	case TAG_U32:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U32{U32: value.(uint32)}}
+	case TAG_U64:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U64{U64: value.(uint64)}}
+	case TAG_U128:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U128{U128: &state.CLValueInstance_U128{Value: value.(*big.Int).String()}}}
+	case TAG_U256:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U256{U256: &state.CLValueInstance_U256{Value: value.(*big.Int).String()}}}
+	case TAG_U512:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U512{U512: &state.CLValueInstance_U512{Value: value.(*big.Int).String()}}}
+	case TAG_UNIT:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Unit{Unit: &state.Unit{}}}
+	case TAG_STRING:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: value.(string)}}
+	case TAG_KEY:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Key{Key: value.(Key).ToStateValue()}}
+	case TAG_UREF:
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Uref{Uref: value.(URef).ToStateValue()}}
+	case TAG_OPTION:
+		option := &state.CLValueInstance_Option{}
+		if decoded := value.(clOption); decoded.Some {
+			option.Value = clValueInstanceValueOf(clType.Inner[0], decoded.Value)
+		}
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{OptionValue: option}}
+	case TAG_LIST, TAG_FIXED_LIST:
+		if bytesValue, ok := value.([]byte); ok {
+			return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BytesValue{BytesValue: bytesValue}}
+		}
+		elems := values(listElem)
+		if clType.Tag == TAG_LIST {
+			return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{Values: elems}}}
+		}
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_FixedListValue{FixedListValue: &state.CLValueInstance_FixedList{
+			Length: clType.Length, Values: elems}}}
+	case TAG_RESULT:
+		result := value.(clResult)
+		if result.Ok {
+			return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ResultValue{ResultValue: &state.CLValueInstance_Result{
+				Value: &state.CLValueInstance_Result_Ok{Ok: clValueInstanceValueOf(clType.Inner[0], result.Value)}}}}
+		}
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ResultValue{ResultValue: &state.CLValueInstance_Result{
+			Value: &state.CLValueInstance_Result_Err{Err: clValueInstanceValueOf(clType.Inner[1], result.Value)}}}}
+	case TAG_MAP:
+		entries := []*state.CLValueInstance_MapEntry{}
+		for _, entry := range value.([]clMapEntry) {
+			entries = append(entries, &state.CLValueInstance_MapEntry{
+				Key:   clValueInstanceValueOf(clType.Inner[0], entry.Key),
+				Value: clValueInstanceValueOf(clType.Inner[1], entry.Value)})
+		}
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_MapValue{MapValue: &state.CLValueInstance_Map{Values: entries}}}
+	case TAG_TUPLE1:
+		elems := values(tupleElem)
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Tuple1Value{Tuple1Value: &state.CLValueInstance_Tuple1{
+			Value_1: elems[0]}}}
+	case TAG_TUPLE2:
+		elems := values(tupleElem)
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Tuple2Value{Tuple2Value: &state.CLValueInstance_Tuple2{
+			Value_1: elems[0], Value_2: elems[1]}}}
+	default:
+		elems := values(tupleElem)
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Tuple3Value{Tuple3Value: &state.CLValueInstance_Tuple3{
+			Value_1: elems[0], Value_2: elems[1], Value_3: elems[2]}}}
+	}
+}
+
+// CLTuple 은 CL tuple 로 serialize 되는 1 ~ 3 개의 Go 값.
+type CLTuple []interface{}
+
+// ToCLValueInstance 는 Go 값을 cl_type 이 있는 CLValueInstance 로 변환하는 함수.
+//
+// CL type 은 Encode 와 같이 정해진다. 추가로 *state.Key 와 *state.Key_URef 는 Key, URef 와 같이 변환되고,
+// CLTuple 은 원소들의 tuple 이 되며, *state.CLValueInstance 는 그대로 return 한다.
+func ToCLValueInstance(value interface{}) (*state.CLValueInstance, error) {
+	if instance, ok := value.(*state.CLValueInstance); ok {
+		return instance, nil
+	}
+
+	clValue, err := toCLValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return clValue.ToCLValueInstance()
+}
+
+// toCLValue 는 ToCLValueInstance 만 받는 값을 변환한 뒤 Encode 로 encode 하는 함수.
+func toCLValue(value interface{}) (CLValue, error) {
+	switch v := value.(type) {
+	case *state.Key:
+		if v.GetValue() == nil {
+			return CLValue{}, errors.New("Key is empty")
+		}
+		key, err := Key{}.FromStateValue(v)
+		if err != nil {
+			return CLValue{}, err
+		}
+		return Encode(key)
+	case *state.Key_URef:
+		uref, err := URef{}.FromStateValue(v)
+		if err != nil {
+			return CLValue{}, err
+		}
+		return Encode(uref)
+	case CLTuple:
+		return tupleToCLValue(v)
+	default:
+		return Encode(value)
+	}
+}
+
+func tupleToCLValue(tuple CLTuple) (CLValue, error) {
+	elements := make([]CLType, len(tuple))
+	valueBytes := []byte{}
+	for idx, value := range tuple {
+		clValue, err := toCLValue(value)
+		if err != nil {
+			return CLValue{}, fmt.Errorf("Tuple element %d : %s", idx, err.Error())
+		}
+		elements[idx] = clValue.Type
+		valueBytes = append(valueBytes, clValue.Bytes...)
+	}
+
+	clType, err := NewTupleCLType(elements...)
+	if err != nil {
+		return CLValue{}, err
+	}
+
+	return NewClValue(valueBytes, clType), nil
+}
@@ -0,0 +1,281 @@
+package storedvalue
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func simpleType(simple state.CLType_Simple) *state.CLType {
+	return &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: simple}}
+}
+
+func TestCLValueInstanceToBytesSimple(t *testing.T) {
+	address := make([]byte, 32)
+	address[0] = 1
+
+	testCases := []struct {
+		name     string
+		clType   *state.CLType
+		value    *state.CLValueInstance_Value
+		expected []byte
+	}{
+		{"bool", simpleType(state.CLType_BOOL),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BoolValue{BoolValue: true}},
+			[]byte{1, 0, 0, 0, 1, 0}},
+		{"i32", simpleType(state.CLType_I32),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I32{I32: -1}},
+			[]byte{4, 0, 0, 0, 255, 255, 255, 255, 1}},
+		{"i64", simpleType(state.CLType_I64),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I64{I64: 2342}},
+			[]byte{8, 0, 0, 0, 38, 9, 0, 0, 0, 0, 0, 0, 2}},
+		{"u8", simpleType(state.CLType_U8),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U8{U8: 7}},
+			[]byte{1, 0, 0, 0, 7, 3}},
+		{"u32", simpleType(state.CLType_U32),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U32{U32: 314}},
+			[]byte{4, 0, 0, 0, 58, 1, 0, 0, 4}},
+		{"u64", simpleType(state.CLType_U64),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U64{U64: 2342}},
+			[]byte{8, 0, 0, 0, 38, 9, 0, 0, 0, 0, 0, 0, 5}},
+		{"u128 zero", simpleType(state.CLType_U128),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U128{U128: &state.CLValueInstance_U128{Value: "0"}}},
+			[]byte{1, 0, 0, 0, 0, 6}},
+		{"u256", simpleType(state.CLType_U256),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U256{U256: &state.CLValueInstance_U256{Value: "256"}}},
+			[]byte{3, 0, 0, 0, 2, 0, 1, 7}},
+		{"u512", simpleType(state.CLType_U512),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U512{U512: &state.CLValueInstance_U512{Value: "123456789101112131415161718"}}},
+			[]byte{12, 0, 0, 0, 11, 118, 187, 175, 114, 70, 79, 148, 242, 253, 30, 102, 8}},
+		{"unit", simpleType(state.CLType_UNIT),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Unit{Unit: &state.Unit{}}},
+			[]byte{0, 0, 0, 0, 9}},
+		{"string", simpleType(state.CLType_STRING),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: "abc"}},
+			[]byte{7, 0, 0, 0, 3, 0, 0, 0, 97, 98, 99, 10}},
+		{"account key", simpleType(state.CLType_KEY),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Key{Key: &state.Key{Value: &state.Key_Address_{Address: &state.Key_Address{Account: address}}}}},
+			append(append([]byte{33, 0, 0, 0, 0}, address...), 11)},
+		{"uref key", simpleType(state.CLType_KEY),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Key{Key: &state.Key{Value: &state.Key_Uref{Uref: &state.Key_URef{Uref: address, AccessRights: state.Key_URef_READ_ADD_WRITE}}}}},
+			append(append([]byte{34, 0, 0, 0, 2}, address...), 7, 11)},
+		{"uref", simpleType(state.CLType_UREF),
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Uref{Uref: &state.Key_URef{Uref: address, AccessRights: state.Key_URef_READ}}},
+			append(append([]byte{33, 0, 0, 0}, address...), 1, 12)},
+	}
+
+	for _, testCase := range testCases {
+		res, err := CLValueInstanceToBytes(&state.CLValueInstance{ClType: testCase.clType, Value: testCase.value})
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, res, testCase.name)
+
+		instance, err, pos := CLValueInstanceFromBytes(testCase.expected)
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, len(testCase.expected), pos, testCase.name)
+		assert.True(t, proto.Equal(testCase.clType, instance.GetClType()), testCase.name)
+		assert.True(t, proto.Equal(testCase.value, instance.GetValue()), testCase.name)
+	}
+}
+
+func TestCLValueInstanceToBytesNested(t *testing.T) {
+	u512Value := func(value string) *state.CLValueInstance_Value {
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U512{U512: &state.CLValueInstance_U512{Value: value}}}
+	}
+	strValue := func(value string) *state.CLValueInstance_Value {
+		return &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: value}}
+	}
+
+	testCases := []struct {
+		name     string
+		clType   *state.CLType
+		value    *state.CLValueInstance_Value
+		expected []byte
+	}{
+		{"option none",
+			&state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{Inner: simpleType(state.CLType_U64)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{OptionValue: &state.CLValueInstance_Option{}}},
+			[]byte{1, 0, 0, 0, 0, 13, 5}},
+		{"option list u512",
+			&state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{
+				Inner: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: simpleType(state.CLType_U512)}}}}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{OptionValue: &state.CLValueInstance_Option{
+				Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{
+					Values: []*state.CLValueInstance_Value{u512Value("0"), u512Value("256")}}}}}}},
+			[]byte{9, 0, 0, 0, 1, 2, 0, 0, 0, 0, 2, 0, 1, 13, 14, 8}},
+		{"list u8 bytes",
+			&state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: simpleType(state.CLType_U8)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BytesValue{BytesValue: []byte{1, 2, 3}}},
+			[]byte{7, 0, 0, 0, 3, 0, 0, 0, 1, 2, 3, 14, 3}},
+		{"fixed list u8 bytes",
+			&state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{Inner: simpleType(state.CLType_U8), Len: 3}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BytesValue{BytesValue: []byte{1, 2, 3}}},
+			[]byte{3, 0, 0, 0, 1, 2, 3, 15, 3, 3, 0, 0, 0}},
+		{"fixed list string",
+			&state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{Inner: simpleType(state.CLType_STRING), Len: 2}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_FixedListValue{FixedListValue: &state.CLValueInstance_FixedList{
+				Length: 2, Values: []*state.CLValueInstance_Value{strValue("A"), strValue("B")}}}},
+			[]byte{14, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 65, 1, 0, 0, 0, 66, 15, 10, 2, 0, 0, 0}},
+		{"result ok",
+			&state.CLType{Variants: &state.CLType_ResultType{ResultType: &state.CLType_Result{Ok: simpleType(state.CLType_BOOL), Err: simpleType(state.CLType_STRING)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ResultValue{ResultValue: &state.CLValueInstance_Result{
+				Value: &state.CLValueInstance_Result_Ok{Ok: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BoolValue{BoolValue: true}}}}}},
+			[]byte{2, 0, 0, 0, 1, 1, 16, 0, 10}},
+		{"result err",
+			&state.CLType{Variants: &state.CLType_ResultType{ResultType: &state.CLType_Result{Ok: simpleType(state.CLType_BOOL), Err: simpleType(state.CLType_STRING)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ResultValue{ResultValue: &state.CLValueInstance_Result{
+				Value: &state.CLValueInstance_Result_Err{Err: strValue("A")}}}},
+			[]byte{6, 0, 0, 0, 0, 1, 0, 0, 0, 65, 16, 0, 10}},
+		{"map string list key",
+			&state.CLType{Variants: &state.CLType_MapType{MapType: &state.CLType_Map{
+				Key:   simpleType(state.CLType_STRING),
+				Value: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: simpleType(state.CLType_KEY)}}}}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_MapValue{MapValue: &state.CLValueInstance_Map{
+				Values: []*state.CLValueInstance_MapEntry{{
+					Key:   strValue("A"),
+					Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{}}}}}}}},
+			[]byte{13, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 65, 0, 0, 0, 0, 17, 10, 14, 11}},
+		{"tuple1",
+			&state.CLType{Variants: &state.CLType_Tuple1Type{Tuple1Type: &state.CLType_Tuple1{Type0: simpleType(state.CLType_U8)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Tuple1Value{Tuple1Value: &state.CLValueInstance_Tuple1{
+				Value_1: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U8{U8: 8}}}}},
+			[]byte{1, 0, 0, 0, 8, 18, 3}},
+		{"tuple2 option string",
+			&state.CLType{Variants: &state.CLType_Tuple2Type{Tuple2Type: &state.CLType_Tuple2{
+				Type0: &state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{Inner: simpleType(state.CLType_U64)}}},
+				Type1: simpleType(state.CLType_STRING)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Tuple2Value{Tuple2Value: &state.CLValueInstance_Tuple2{
+				Value_1: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{OptionValue: &state.CLValueInstance_Option{
+					Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U64{U64: 1}}}}},
+				Value_2: strValue("A")}}},
+			[]byte{14, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 65, 19, 13, 5, 10}},
+		{"tuple3",
+			&state.CLType{Variants: &state.CLType_Tuple3Type{Tuple3Type: &state.CLType_Tuple3{
+				Type0: simpleType(state.CLType_U8), Type1: simpleType(state.CLType_U32), Type2: simpleType(state.CLType_U64)}}},
+			&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Tuple3Value{Tuple3Value: &state.CLValueInstance_Tuple3{
+				Value_1: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U8{U8: 8}},
+				Value_2: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U32{U32: 314}},
+				Value_3: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U64{U64: 2342}}}}},
+			[]byte{13, 0, 0, 0, 8, 58, 1, 0, 0, 38, 9, 0, 0, 0, 0, 0, 0, 20, 3, 4, 5}},
+	}
+
+	for _, testCase := range testCases {
+		res, err := CLValueInstanceToBytes(&state.CLValueInstance{ClType: testCase.clType, Value: testCase.value})
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, res, testCase.name)
+
+		instance, err, pos := CLValueInstanceFromBytes(testCase.expected)
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, len(testCase.expected), pos, testCase.name)
+		assert.True(t, proto.Equal(testCase.clType, instance.GetClType()), testCase.name)
+		assert.True(t, proto.Equal(testCase.value, instance.GetValue()), testCase.name)
+	}
+}
+
+func TestCLValueInstanceToBytesInferred(t *testing.T) {
+	res, err := CLValueInstanceToBytes(&state.CLValueInstance{
+		Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{
+			Values: []*state.CLValueInstance_Value{
+				{Value: &state.CLValueInstance_Value_I32{I32: 1}},
+				{Value: &state.CLValueInstance_Value_I32{I32: 2}}}}}}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{12, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 14, 1}, res)
+
+	_, err = CLValueInstanceToBytes(&state.CLValueInstance{
+		Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{OptionValue: &state.CLValueInstance_Option{}}}})
+	assert.Error(t, err)
+}
+
+func TestCLValueInstanceToBytesError(t *testing.T) {
+	_, err := CLValueInstanceToBytes(&state.CLValueInstance{
+		ClType: simpleType(state.CLType_U32),
+		Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: "abc"}}})
+	assert.Error(t, err)
+
+	_, err = CLValueInstanceToBytes(&state.CLValueInstance{
+		ClType: simpleType(state.CLType_U8),
+		Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U8{U8: 256}}})
+	assert.Error(t, err)
+
+	_, err = CLValueInstanceToBytes(&state.CLValueInstance{
+		ClType: simpleType(state.CLType_U128),
+		Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U128{U128: &state.CLValueInstance_U128{Value: "340282366920938463463374607431768211456"}}}})
+	assert.Error(t, err)
+
+	_, err = CLValueInstanceToBytes(&state.CLValueInstance{
+		ClType: &state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{Inner: simpleType(state.CLType_U8), Len: 32}}},
+		Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BytesValue{BytesValue: []byte{1, 2, 3}}}})
+	assert.Error(t, err)
+}
+
+func TestCLValueInstanceFromBytesError(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  []byte
+	}{
+		{"empty", []byte{}},
+		{"short value", []byte{4, 0, 0, 0, 1, 4}},
+		{"missing tag", []byte{1, 0, 0, 0, 1}},
+		{"unknown tag", []byte{1, 0, 0, 0, 1, 99}},
+		{"invalid bool", []byte{1, 0, 0, 0, 2, 0}},
+		{"huge list count", []byte{4, 0, 0, 0, 255, 255, 255, 255, 14, 4}},
+		{"value length mismatch", []byte{2, 0, 0, 0, 1, 0, 3}},
+	}
+
+	for _, testCase := range testCases {
+		_, err, _ := CLValueInstanceFromBytes(testCase.src)
+		assert.Error(t, err, testCase.name)
+	}
+}
+
+func TestToCLValueInstance(t *testing.T) {
+	address := make([]byte, 32)
+	address[0] = 1
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected []byte
+	}{
+		{"bool", true, []byte{1, 0, 0, 0, 1, 0}},
+		{"u8", uint8(7), []byte{1, 0, 0, 0, 7, 3}},
+		{"u512", big.NewInt(256), []byte{3, 0, 0, 0, 2, 0, 1, 8}},
+		{"string", "abc", []byte{7, 0, 0, 0, 3, 0, 0, 0, 97, 98, 99, 10}},
+		{"bytes", []byte{1, 2}, []byte{6, 0, 0, 0, 2, 0, 0, 0, 1, 2, 14, 3}},
+		{"hash key", mustNewKey(NewHashKey(address)), append(append([]byte{33, 0, 0, 0, 1}, address...), 11)},
+		{"state key", mustNewKey(NewHashKey(address)).ToStateValue(), append(append([]byte{33, 0, 0, 0, 1}, address...), 11)},
+		{"int as i64", 5, []byte{8, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 2}},
+		{"u64 list", []uint64{1}, []byte{12, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 14, 5}},
+		{"tuple2", CLTuple{uint8(8), "A"}, []byte{6, 0, 0, 0, 8, 1, 0, 0, 0, 65, 19, 3, 10}},
+	}
+
+	for _, testCase := range testCases {
+		instance, err := ToCLValueInstance(testCase.value)
+		assert.NoError(t, err, testCase.name)
+		res, err := CLValueInstanceToBytes(instance)
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, res, testCase.name)
+	}
+
+	_, err := ToCLValueInstance(CLTuple{})
+	assert.Error(t, err)
+	_, err = ToCLValueInstance(big.NewInt(-1))
+	assert.Error(t, err)
+	_, err = ToCLValueInstance(float32(1))
+	assert.Error(t, err)
+}
+
+func TestZeroSizedSequenceFromBytes(t *testing.T) {
+	unitList := NewListCLType(NewSimpleCLType(TAG_UNIT))
+	clValue := NewClValue([]byte{3, 0, 0, 0}, unitList)
+	value, err := clValue.ToCLInstanceValue()
+	assert.NoError(t, err)
+	assert.Len(t, value.GetListValue().GetValues(), 3)
+
+	clValue = NewClValue([]byte{0xff, 0xff, 0xff, 0xff}, unitList)
+	_, err = clValue.ToCLInstanceValue()
+	assert.Error(t, err)
+}
@@ -1,8 +1,10 @@
 package storedvalue
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/stretchr/testify/assert"
 )
@@ -110,3 +112,127 @@ func TestFromStringListToBytes(t *testing.T) {
 		14, 10},
 		clValue.ToBytes())
 }
+
+func TestCLValueToCLValueInstanceAllTypes(t *testing.T) {
+	address := make([]byte, ADDRESS_LENGTH)
+	address[0] = 1
+	var hash [ADDRESS_LENGTH]byte
+	hash[1] = 2
+
+	instances := []*state.CLValueInstance{}
+	for _, value := range []interface{}{
+		true, int32(-3), int64(-4), uint8(5), uint32(6), uint64(7), big.NewInt(256), "abc",
+		Address(address), hash, mustNewKey(NewHashKey(address)), NewURef(address, state.Key_URef_READ_ADD_WRITE),
+		CLTuple{"name", mustNewKey(NewHashKey(address))}, CLTuple{true}, CLTuple{uint8(1), "a", int64(2)},
+	} {
+		instance, err := ToCLValueInstance(value)
+		assert.NoError(t, err)
+		instances = append(instances, instance)
+	}
+
+	u64Type := simpleType(state.CLType_U64)
+	stringType := simpleType(state.CLType_STRING)
+	instances = append(instances,
+		&state.CLValueInstance{
+			ClType: simpleType(state.CLType_UNIT),
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Unit{Unit: &state.Unit{}}}},
+		&state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{Inner: u64Type}}},
+			Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{OptionValue: &state.CLValueInstance_Option{
+				Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U64{U64: 3}}}}}},
+		&state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_ResultType{ResultType: &state.CLType_Result{Ok: u64Type, Err: stringType}}},
+			Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ResultValue{ResultValue: &state.CLValueInstance_Result{
+				Value: &state.CLValueInstance_Result_Err{Err: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: "fail"}}}}}}},
+		&state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_MapType{MapType: &state.CLType_Map{Key: stringType, Value: &state.CLType{
+				Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: u64Type}}}}}},
+			Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_MapValue{MapValue: &state.CLValueInstance_Map{
+				Values: []*state.CLValueInstance_MapEntry{{
+					Key: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: "a"}},
+					Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{
+						Values: []*state.CLValueInstance_Value{{Value: &state.CLValueInstance_Value_U64{U64: 1}}}}}}}}}}}},
+	)
+
+	for _, instance := range instances {
+		var clValue CLValue
+		clValue, err := clValue.FromCLValueInstance(instance)
+		assert.NoError(t, err)
+
+		res, err := clValue.ToCLValueInstance()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(instance, res), "%s", clValue.Type)
+
+		var decoded CLValue
+		decoded, err, _ = decoded.FromBytes(clValue.ToBytes())
+		assert.NoError(t, err)
+		assert.Equal(t, clValue, decoded)
+	}
+}
+
+func TestCLValueStateValueRoundTrip(t *testing.T) {
+	address := make([]byte, ADDRESS_LENGTH)
+	address[0] = 1
+	stateKey := &state.Key{Value: &state.Key_Address_{Address: &state.Key_Address{Account: address}}}
+
+	for _, stateValue := range []*state.Value{
+		{Value: &state.Value_IntValue{IntValue: -10}},
+		{Value: &state.Value_LongValue{LongValue: 67305985}},
+		{Value: &state.Value_BytesValue{BytesValue: []byte{1, 2, 3}}},
+		{Value: &state.Value_IntList{IntList: &state.IntList{Values: []int32{1, -2, 3}}}},
+		{Value: &state.Value_StringValue{StringValue: "안녕하세요"}},
+		{Value: &state.Value_StringList{StringList: &state.StringList{Values: []string{"abc", "defgh"}}}},
+		{Value: &state.Value_NamedKey{NamedKey: &state.NamedKey{Name: "counter", Key: stateKey}}},
+		{Value: &state.Value_BigInt{BigInt: &state.BigInt{Value: "123456789101112131415161718", BitWidth: 512}}},
+		{Value: &state.Value_Key{Key: stateKey}},
+		{Value: &state.Value_Unit{Unit: &state.Unit{}}},
+	} {
+		var clValue CLValue
+		clValue, err := clValue.FromStateValue(stateValue)
+		assert.NoError(t, err)
+
+		res, err := clValue.ToStateValues()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(stateValue, res), "%s", clValue.Type)
+
+		// 여러 번 변환해도 bytes가 변경되지 않는다.
+		res, err = clValue.ToStateValues()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(stateValue, res), "%s", clValue.Type)
+	}
+
+	clValue := NewClValue([]byte{1, 0, 0, 0, 7, 0, 0, 0}, NewListCLType(NewSimpleCLType(TAG_U32)))
+	res, err := clValue.ToStateValues()
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{7}, res.GetIntList().GetValues())
+}
+
+func TestCLValueConversionError(t *testing.T) {
+	var clValue CLValue
+	_, err := clValue.FromStateValue(&state.Value{Value: &state.Value_Account{Account: &state.Account{}}})
+	assert.Error(t, err)
+
+	for _, clValue := range []CLValue{
+		NewClValue([]byte{1}, NewSimpleCLType(TAG_BOOL)),
+		NewClValue([]byte{0}, NewOptionCLType(NewSimpleCLType(TAG_U8))),
+		NewClValue([]byte{0, 0, 0, 0}, NewMapCLType(NewSimpleCLType(TAG_STRING), NewSimpleCLType(TAG_STRING))),
+		NewClValue([]byte{0, 0, 0, 0}, NewListCLType(NewSimpleCLType(TAG_U512))),
+	} {
+		_, err := clValue.ToCLInstanceValue()
+		assert.NoError(t, err)
+		_, err = clValue.ToStateValues()
+		assert.Error(t, err, "%s", clValue.Type)
+	}
+
+	for _, clValue := range []CLValue{
+		NewClValue([]byte{1, 0}, NewSimpleCLType(TAG_I32)),
+		NewClValue([]byte{1, 0, 0, 0, 0}, NewSimpleCLType(TAG_I32)),
+		NewClValue([]byte{5, 0, 0, 0}, NewListCLType(NewSimpleCLType(TAG_STRING))),
+		NewClValue([]byte{}, CLType{Tag: TAG_OPTION}),
+	} {
+		_, err := clValue.ToCLInstanceValue()
+		assert.Error(t, err)
+		_, err = clValue.ToStateValues()
+		assert.Error(t, err)
+	}
+}
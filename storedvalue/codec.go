@@ -0,0 +1,558 @@
+package storedvalue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+var (
+	keyGoType       = reflect.TypeOf(Key{})
+	urefGoType      = reflect.TypeOf(URef{})
+	bigIntGoType    = reflect.TypeOf(big.Int{})
+	bigIntPtrGoType = reflect.TypeOf(&big.Int{})
+)
+
+// uintGoTypes 는 큰 정수 type 마다 정확히 그 범위를 가지는 Go type.
+var uintGoTypes = map[CL_TYPE_TAG]reflect.Type{
+	TAG_U128: reflect.TypeOf(U128{}),
+	TAG_U256: reflect.TypeOf(U256{}),
+	TAG_U512: reflect.TypeOf(U512{}),
+}
+
+// bigIntMaxLengths 는 큰 정수 type 마다 serialize 된 bytes 의 최대 길이.
+var bigIntMaxLengths = map[CL_TYPE_TAG]int{TAG_U128: 16, TAG_U256: 32, TAG_U512: 64}
+
+// CLTypeMismatchError 는 값의 CL type 을 Go type 에 저장할 수 없을 때 Decode 가 return 하는 error.
+type CLTypeMismatchError struct {
+	CLType CLType
+	GoType reflect.Type
+}
+
+func (e *CLTypeMismatchError) Error() string {
+	return fmt.Sprintf("%s can not be decoded into %s", e.CLType, e.GoType)
+}
+
+// Decode 는 값을 v 가 가리키는 Go 값에 저장하는 함수.
+//
+// CL type 과 Go type 의 대응은 다음과 같다.
+//
+//	Bool                      bool
+//	I32, I64                  같거나 더 큰 bit 의 signed integer
+//	U8, U32, U64              같거나 더 큰 bit 의 unsigned integer
+//	U128, U256, U512          *big.Int, big.Int 또는 같은 bit 의 U128, U256, U512
+//	Unit                      struct{}
+//	String                    string
+//	Key, URef                 Key, URef
+//	Option<T>                 *T, None 은 nil
+//	List<T>                   []T
+//	FixedList<T, N>           [N]T 또는 []T
+//	Map<K, V>                 map[K]V
+//	Tuple1-3                  field 가 1 ~ 3 개인 struct
+//
+// Tuple 의 원소는 선언 순서의 exported field 이다. `cl:"N"` tag 가 있는 field 가 있으면
+// tag 가 있는 field 만 N 번째 원소로 사용한다. Result 와 Any 는 지원하지 않는다.
+func (c CLValue) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("Decode target must be a non-nil pointer, but %T", v)
+	}
+
+	if err := checkDecodeType(c.Type, rv.Elem().Type()); err != nil {
+		return err
+	}
+
+	value, err := c.decodeBytes(STORED_VALUE_FORMAT_V1)
+	if err != nil {
+		return err
+	}
+
+	return setValue(c.Type, value, rv.Elem())
+}
+
+// Encode 는 CLValue.Decode 의 대응을 사용해 Go 값을 CLValue 로 변환하는 함수.
+//
+// CL type 은 Go type 으로 추론한다. int 와 int64 는 I64, uint 와 uint64 는 U64,
+// *big.Int 와 big.Int 는 U512, U128, U256, U512 는 같은 이름의 CL type,
+// field 가 없는 struct 는 Unit 이 된다.
+// CLValue 는 그대로 return 한다.
+func Encode(v interface{}) (CLValue, error) {
+	if clValue, ok := v.(CLValue); ok {
+		return clValue, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return CLValue{}, fmt.Errorf("Can not encode nil")
+	}
+
+	clType, err := clTypeOfGoType(rv.Type())
+	if err != nil {
+		return CLValue{}, err
+	}
+
+	valueBytes, err := encodeValue(clType, rv)
+	if err != nil {
+		return CLValue{}, err
+	}
+
+	return NewClValue(valueBytes, clType), nil
+}
+
+// tupleFields 는 tuple 원소로 사용되는 struct field 의 index 를 원소 순서로 return 하는 함수.
+func tupleFields(t reflect.Type) ([]int, error) {
+	all := []int{}
+	tagged := map[int]int{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("cl")
+		if tag == "-" {
+			continue
+		}
+		all = append(all, i)
+		if !ok {
+			continue
+		}
+
+		order, err := strconv.Atoi(tag)
+		if err != nil || order < 0 {
+			return nil, fmt.Errorf("%s.%s : cl tag must be a tuple element index, but %q", t, field.Name, tag)
+		}
+		if _, exists := tagged[order]; exists {
+			return nil, fmt.Errorf("%s.%s : duplicated tuple element index %d", t, field.Name, order)
+		}
+		tagged[order] = i
+	}
+
+	if len(tagged) == 0 {
+		return all, nil
+	}
+
+	fields := make([]int, len(tagged))
+	for order, idx := range tagged {
+		if order >= len(tagged) {
+			return nil, fmt.Errorf("%s : tuple element index %d out of range %d", t, order, len(tagged))
+		}
+		fields[order] = idx
+	}
+
+	return fields, nil
+}
+
+func isSignedKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUnsignedKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uint64
+}
+
+// checkDecodeType 은 clType 의 값을 t 에 저장할 수 있는지 검사하는 함수.
+func checkDecodeType(clType CLType, t reflect.Type) error {
+	mismatch := &CLTypeMismatchError{CLType: clType, GoType: t}
+	if err := clType.Validate(); err != nil {
+		return err
+	}
+
+	inners := func(goTypes ...reflect.Type) error {
+		for idx, goType := range goTypes {
+			if err := checkDecodeType(clType.Inner[idx], goType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch clType.Tag {
+	case TAG_BOOL:
+		if t.Kind() == reflect.Bool {
+			return nil
+		}
+	case TAG_I32, TAG_I64:
+		if isSignedKind(t.Kind()) && t.Bits() >= map[CL_TYPE_TAG]int{TAG_I32: 32, TAG_I64: 64}[clType.Tag] {
+			return nil
+		}
+	case TAG_U8, TAG_U32, TAG_U64:
+		if isUnsignedKind(t.Kind()) && t.Bits() >= map[CL_TYPE_TAG]int{TAG_U8: 8, TAG_U32: 32, TAG_U64: 64}[clType.Tag] {
+			return nil
+		}
+	case TAG_U128, TAG_U256, TAG_U512:
+		if t == bigIntGoType || t == bigIntPtrGoType || t == uintGoTypes[clType.Tag] {
+			return nil
+		}
+	case TAG_UNIT:
+		if t.Kind() == reflect.Struct && t.NumField() == 0 {
+			return nil
+		}
+	case TAG_STRING:
+		if t.Kind() == reflect.String {
+			return nil
+		}
+	case TAG_KEY:
+		if t == keyGoType {
+			return nil
+		}
+	case TAG_UREF:
+		if t == urefGoType {
+			return nil
+		}
+	case TAG_OPTION:
+		if t.Kind() == reflect.Ptr && t != bigIntPtrGoType {
+			return inners(t.Elem())
+		}
+	case TAG_LIST:
+		if t.Kind() == reflect.Slice {
+			return inners(t.Elem())
+		}
+	case TAG_FIXED_LIST:
+		if t.Kind() == reflect.Slice || (t.Kind() == reflect.Array && t.Len() == int(clType.Length)) {
+			return inners(t.Elem())
+		}
+	case TAG_MAP:
+		if t.Kind() == reflect.Map {
+			return inners(t.Key(), t.Elem())
+		}
+	case TAG_TUPLE1, TAG_TUPLE2, TAG_TUPLE3:
+		if t.Kind() != reflect.Struct || t == keyGoType || t == urefGoType || t == bigIntGoType {
+			break
+		}
+		fields, err := tupleFields(t)
+		if err != nil {
+			return err
+		}
+		if len(fields) != len(clType.Inner) {
+			break
+		}
+		goTypes := []reflect.Type{}
+		for _, idx := range fields {
+			goTypes = append(goTypes, t.Field(idx).Type)
+		}
+		return inners(goTypes...)
+	}
+
+	return mismatch
+}
+
+// setValue 는 decodeCLValue 가 decode 한 값을 rv 에 저장하는 함수. rv 의 type 은 checkDecodeType 으로 검사되어 있어야 한다.
+func setValue(clType CLType, value interface{}, rv reflect.Value) error {
+	switch clType.Tag {
+	case TAG_BOOL:
+		rv.SetBool(value.(bool))
+	case TAG_I32:
+		rv.SetInt(int64(value.(int32)))
+	case TAG_I64:
+		rv.SetInt(value.(int64))
+	case TAG_U8:
+		rv.SetUint(uint64(value.(uint8)))
+	case TAG_U32:
+		rv.SetUint(uint64(value.(uint32)))
+	case TAG_U64:
+		rv.SetUint(value.(uint64))
+	case TAG_U128, TAG_U256, TAG_U512:
+		bigIntValue := value.(*big.Int)
+		switch rv.Type() {
+		case bigIntPtrGoType:
+			rv.Set(reflect.ValueOf(bigIntValue))
+		case uintGoTypes[TAG_U128]:
+			rv.Set(reflect.ValueOf(U128{uintN{value: bigIntValue}}))
+		case uintGoTypes[TAG_U256]:
+			rv.Set(reflect.ValueOf(U256{uintN{value: bigIntValue}}))
+		case uintGoTypes[TAG_U512]:
+			rv.Set(reflect.ValueOf(U512{uintN{value: bigIntValue}}))
+		default:
+			rv.Addr().Interface().(*big.Int).Set(bigIntValue)
+		}
+	case TAG_UNIT:
+	case TAG_STRING:
+		rv.SetString(value.(string))
+	case TAG_KEY, TAG_UREF:
+		rv.Set(reflect.ValueOf(value))
+	case TAG_OPTION:
+		option := value.(clOption)
+		if !option.Some {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		some := reflect.New(rv.Type().Elem())
+		if err := setValue(clType.Inner[0], option.Value, some.Elem()); err != nil {
+			return err
+		}
+		rv.Set(some)
+	case TAG_LIST, TAG_FIXED_LIST:
+		values, ok := value.([]interface{})
+		if !ok {
+			for _, b := range value.([]byte) {
+				values = append(values, b)
+			}
+		}
+		if rv.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(rv.Type(), len(values), len(values)))
+		}
+		for i, elem := range values {
+			if err := setValue(clType.Inner[0], elem, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	case TAG_MAP:
+		m := reflect.MakeMap(rv.Type())
+		for _, entry := range value.([]clMapEntry) {
+			key := reflect.New(rv.Type().Key()).Elem()
+			if err := setValue(clType.Inner[0], entry.Key, key); err != nil {
+				return err
+			}
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := setValue(clType.Inner[1], entry.Value, elem); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		rv.Set(m)
+	default:
+		fields, err := tupleFields(rv.Type())
+		if err != nil {
+			return err
+		}
+		values := value.([]interface{})
+		for idx, field := range fields {
+			if err := setValue(clType.Inner[idx], values[idx], rv.Field(field)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// clTypeOfGoType 은 Encode 가 t 에 사용하는 CL type 을 추론하는 함수.
+func clTypeOfGoType(t reflect.Type) (CLType, error) {
+	switch t {
+	case keyGoType:
+		return NewSimpleCLType(TAG_KEY), nil
+	case urefGoType:
+		return NewSimpleCLType(TAG_UREF), nil
+	case bigIntGoType, bigIntPtrGoType:
+		return NewSimpleCLType(TAG_U512), nil
+	}
+	for tag, uintGoType := range uintGoTypes {
+		if t == uintGoType {
+			return NewSimpleCLType(tag), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return NewSimpleCLType(TAG_BOOL), nil
+	case reflect.Int32:
+		return NewSimpleCLType(TAG_I32), nil
+	case reflect.Int, reflect.Int64:
+		return NewSimpleCLType(TAG_I64), nil
+	case reflect.Uint8:
+		return NewSimpleCLType(TAG_U8), nil
+	case reflect.Uint32:
+		return NewSimpleCLType(TAG_U32), nil
+	case reflect.Uint, reflect.Uint64:
+		return NewSimpleCLType(TAG_U64), nil
+	case reflect.String:
+		return NewSimpleCLType(TAG_STRING), nil
+	case reflect.Ptr:
+		inner, err := clTypeOfGoType(t.Elem())
+		if err != nil {
+			return CLType{}, err
+		}
+		return NewOptionCLType(inner), nil
+	case reflect.Slice:
+		inner, err := clTypeOfGoType(t.Elem())
+		if err != nil {
+			return CLType{}, err
+		}
+		return NewListCLType(inner), nil
+	case reflect.Array:
+		inner, err := clTypeOfGoType(t.Elem())
+		if err != nil {
+			return CLType{}, err
+		}
+		return NewFixedListCLType(inner, uint32(t.Len())), nil
+	case reflect.Map:
+		key, err := clTypeOfGoType(t.Key())
+		if err != nil {
+			return CLType{}, err
+		}
+		value, err := clTypeOfGoType(t.Elem())
+		if err != nil {
+			return CLType{}, err
+		}
+		return NewMapCLType(key, value), nil
+	case reflect.Struct:
+		if t.NumField() == 0 {
+			return NewSimpleCLType(TAG_UNIT), nil
+		}
+		fields, err := tupleFields(t)
+		if err != nil {
+			return CLType{}, err
+		}
+		elements := []CLType{}
+		for _, idx := range fields {
+			element, err := clTypeOfGoType(t.Field(idx).Type)
+			if err != nil {
+				return CLType{}, err
+			}
+			elements = append(elements, element)
+		}
+		tuple, err := NewTupleCLType(elements...)
+		if err != nil {
+			return CLType{}, fmt.Errorf("%s : %s", t, err.Error())
+		}
+		return tuple, nil
+	}
+
+	return CLType{}, fmt.Errorf("Unsupported Go type %s", t)
+}
+
+// encodeValue 는 rv 를 clTypeOfGoType 이 추론한 clType 의 값으로 serialize 하는 함수.
+func encodeValue(clType CLType, rv reflect.Value) ([]byte, error) {
+	switch clType.Tag {
+	case TAG_BOOL:
+		if rv.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case TAG_I32:
+		res := make([]byte, INT32_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(rv.Int()))
+		return res, nil
+	case TAG_I64:
+		res := make([]byte, LONG_LENGTH)
+		binary.LittleEndian.PutUint64(res, uint64(rv.Int()))
+		return res, nil
+	case TAG_U8:
+		return []byte{byte(rv.Uint())}, nil
+	case TAG_U32:
+		res := make([]byte, UINT32_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(rv.Uint()))
+		return res, nil
+	case TAG_U64:
+		res := make([]byte, LONG_LENGTH)
+		binary.LittleEndian.PutUint64(res, rv.Uint())
+		return res, nil
+	case TAG_U128:
+		return rv.Interface().(U128).ToBytes(), nil
+	case TAG_U256:
+		return rv.Interface().(U256).ToBytes(), nil
+	case TAG_U512:
+		if value, ok := rv.Interface().(U512); ok {
+			return value.ToBytes(), nil
+		}
+		value, ok := rv.Interface().(*big.Int)
+		if !ok {
+			bigIntValue := rv.Interface().(big.Int)
+			value = &bigIntValue
+		}
+		if value == nil {
+			return nil, fmt.Errorf("U512 value must not be nil")
+		}
+		return bigIntStringToBytes(value.String(), 512)
+	case TAG_UNIT:
+		return []byte{}, nil
+	case TAG_STRING:
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(rv.Len()))
+		return append(res, rv.String()...), nil
+	case TAG_KEY:
+		return rv.Interface().(Key).ToBytes(), nil
+	case TAG_UREF:
+		return rv.Interface().(URef).ToBytes(), nil
+	case TAG_OPTION:
+		if rv.IsNil() {
+			return []byte{0}, nil
+		}
+		inner, err := encodeValue(clType.Inner[0], rv.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{1}, inner...), nil
+	case TAG_LIST, TAG_FIXED_LIST:
+		res := []byte{}
+		// [u8; N] 은 bytes 그대로, 다른 list 는 길이를 앞에 붙여 serialize 한다.
+		if clType.Tag == TAG_LIST || clType.Inner[0].Tag != TAG_U8 {
+			res = make([]byte, SIZE_LENGTH)
+			binary.LittleEndian.PutUint32(res, uint32(rv.Len()))
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := encodeValue(clType.Inner[0], rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, elem...)
+		}
+		return res, nil
+	case TAG_MAP:
+		return encodeMap(clType, rv)
+	default:
+		fields, err := tupleFields(rv.Type())
+		if err != nil {
+			return nil, err
+		}
+		res := []byte{}
+		for idx, field := range fields {
+			elem, err := encodeValue(clType.Inner[idx], rv.Field(field))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, elem...)
+		}
+		return res, nil
+	}
+}
+
+// encodeMap 은 EE 의 BTreeMap 과 같이 key 순서로 정렬된 map 을 serialize 하는 함수.
+func encodeMap(clType CLType, rv reflect.Value) ([]byte, error) {
+	type entry struct {
+		key        reflect.Value
+		keyBytes   []byte
+		valueBytes []byte
+	}
+
+	entries := []entry{}
+	for _, key := range rv.MapKeys() {
+		keyBytes, err := encodeValue(clType.Inner[0], key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := encodeValue(clType.Inner[1], rv.MapIndex(key))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{key: key, keyBytes: keyBytes, valueBytes: valueBytes})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].key, entries[j].key
+		switch {
+		case a.Kind() == reflect.String:
+			return a.String() < b.String()
+		case isSignedKind(a.Kind()):
+			return a.Int() < b.Int()
+		case isUnsignedKind(a.Kind()):
+			return a.Uint() < b.Uint()
+		default:
+			return bytes.Compare(entries[i].keyBytes, entries[j].keyBytes) < 0
+		}
+	})
+
+	res := make([]byte, SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(res, uint32(len(entries)))
+	for _, entry := range entries {
+		res = append(res, entry.keyBytes...)
+		res = append(res, entry.valueBytes...)
+	}
+
+	return res, nil
+}
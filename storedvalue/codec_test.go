@@ -0,0 +1,184 @@
+package storedvalue
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type codecTestStake struct {
+	Validator Key
+	Amount    *big.Int
+}
+
+type codecTestTagged struct {
+	Name    string `cl:"1"`
+	Count   uint32 `cl:"0"`
+	Ignored bool
+	hidden  int
+}
+
+func TestEncodeDecode(t *testing.T) {
+	key := mustNewKey(NewHashKey(make([]byte, ADDRESS_LENGTH)))
+	uref := NewURef(make([]byte, ADDRESS_LENGTH), state.Key_URef_READ_ADD_WRITE)
+	some := "some"
+
+	values := []struct {
+		value    interface{}
+		typeName string
+	}{
+		{true, "Bool"},
+		{int32(-7), "I32"},
+		{int64(-1 << 40), "I64"},
+		{uint8(255), "U8"},
+		{uint32(1 << 31), "U32"},
+		{uint64(1 << 63), "U64"},
+		{big.NewInt(1000000000000), "U512"},
+		{struct{}{}, "Unit"},
+		{"hdac", "String"},
+		{key, "Key"},
+		{uref, "URef"},
+		{&some, "Option<String>"},
+		{(*string)(nil), "Option<String>"},
+		{[]byte{1, 2, 3}, "List<U8>"},
+		{[]string{"a", "b"}, "List<String>"},
+		{[3]byte{1, 2, 3}, "FixedList<U8, 3>"},
+		{[2]int32{1, -1}, "FixedList<I32, 2>"},
+		{map[string]uint64{"b": 2, "a": 1}, "Map<String, U64>"},
+		{codecTestStake{Validator: key, Amount: big.NewInt(5)}, "Tuple2<Key, U512>"},
+		{codecTestTagged{Name: "n", Count: 3}, "Tuple2<U32, String>"},
+		{[]codecTestStake{}, "List<Tuple2<Key, U512>>"},
+	}
+
+	for _, v := range values {
+		clValue, err := Encode(v.value)
+		assert.NoError(t, err)
+		assert.Equal(t, v.typeName, clValue.Type.String())
+
+		// Encode 결과는 CLValueInstance 로도 decode 할 수 있는 EE 형식이어야 한다.
+		_, err = clValue.ToCLInstanceValue()
+		assert.NoError(t, err, v.typeName)
+
+		decoded := reflect.New(reflect.TypeOf(v.value))
+		assert.NoError(t, clValue.Decode(decoded.Interface()), v.typeName)
+		assert.Equal(t, v.value, decoded.Elem().Interface())
+	}
+}
+
+func TestEncodeMapOrder(t *testing.T) {
+	clValue, err := Encode(map[uint32]bool{256: true, 1: false})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 1}, clValue.Bytes)
+}
+
+func TestDecodeWiderTypes(t *testing.T) {
+	clValue, err := Encode(uint8(200))
+	assert.NoError(t, err)
+	var wide uint64
+	assert.NoError(t, clValue.Decode(&wide))
+	assert.Equal(t, uint64(200), wide)
+
+	clValue, err = Encode(int32(-3))
+	assert.NoError(t, err)
+	var i int
+	assert.NoError(t, clValue.Decode(&i))
+	assert.Equal(t, -3, i)
+
+	clValue = NewClValue([]byte{2, 0x10, 0x27}, NewSimpleCLType(TAG_U256))
+	var amount big.Int
+	assert.NoError(t, clValue.Decode(&amount))
+	assert.Equal(t, "10000", amount.String())
+
+	clValue = NewClValue([]byte{1, 2, 3}, NewFixedListCLType(NewSimpleCLType(TAG_U8), 3))
+	var list []byte
+	assert.NoError(t, clValue.Decode(&list))
+	assert.Equal(t, []byte{1, 2, 3}, list)
+}
+
+func TestDecodeStateValue(t *testing.T) {
+	var clValue CLValue
+	clValue, err := clValue.FromStateValue(&state.Value{Value: &state.Value_StringList{StringList: &state.StringList{Values: []string{"x", "y"}}}})
+	assert.NoError(t, err)
+
+	var values []string
+	assert.NoError(t, clValue.Decode(&values))
+	assert.Equal(t, []string{"x", "y"}, values)
+}
+
+func TestDecodeError(t *testing.T) {
+	clValue, err := Encode("hdac")
+	assert.NoError(t, err)
+
+	var number uint64
+	err = clValue.Decode(&number)
+	assert.IsType(t, &CLTypeMismatchError{}, err)
+	assert.Equal(t, "String can not be decoded into uint64", err.Error())
+
+	assert.Error(t, clValue.Decode(number))
+	assert.Error(t, clValue.Decode(nil))
+
+	clValue, err = Encode(uint64(1))
+	assert.NoError(t, err)
+	var narrow uint32
+	assert.IsType(t, &CLTypeMismatchError{}, clValue.Decode(&narrow))
+
+	clValue, err = Encode([]*string{nil})
+	assert.NoError(t, err)
+	var pair struct{ A, B string }
+	assert.IsType(t, &CLTypeMismatchError{}, clValue.Decode(&pair))
+
+	// 잘린 bytes 는 DecodeError 로 처리한다.
+	clValue = NewClValue([]byte{2, 0, 0, 0, 1, 0, 0, 0, 'a'}, NewListCLType(NewSimpleCLType(TAG_STRING)))
+	var list []string
+	err = clValue.Decode(&list)
+	assert.Equal(t, &DecodeError{Offset: 9, Msg: "String size needs 4 bytes, but 0"}, err)
+
+	clValue = NewClValue([]byte{1, 0}, NewSimpleCLType(TAG_BOOL))
+	var flag bool
+	assert.Equal(t, &DecodeError{Offset: 1, Msg: "1 trailing bytes"}, clValue.Decode(&flag))
+}
+
+// 크기가 0 인 원소의 개수는 bytes 로 제한되지 않으므로 큰 개수는 loop 전에 거부한다.
+func TestDecodeZeroSizedSequence(t *testing.T) {
+	unit := NewSimpleCLType(TAG_UNIT)
+	hugeCount := []byte{0xff, 0xff, 0xff, 0x0f}
+
+	var units []struct{}
+	err := NewClValue(hugeCount, NewListCLType(unit)).Decode(&units)
+	assert.Equal(t, &DecodeError{Offset: SIZE_LENGTH,
+		Msg: "Sequence of zero sized elements has 268435455 elements, more than 65536"}, err)
+
+	var unitMap map[struct{}]struct{}
+	assert.Error(t, NewClValue([]byte{0xff, 0xff, 0xff, 0xff}, NewMapCLType(unit, unit)).Decode(&unitMap))
+
+	// 크기가 있는 원소는 남은 bytes 보다 많을 수 없다.
+	var numbers []uint32
+	err = NewClValue(hugeCount, NewListCLType(NewSimpleCLType(TAG_U32))).Decode(&numbers)
+	assert.Equal(t, &DecodeError{Offset: SIZE_LENGTH, Msg: "Sequence has 268435455 elements, but only 0 bytes remain"}, err)
+
+	assert.NoError(t, NewClValue([]byte{3, 0, 0, 0}, NewListCLType(unit)).Decode(&units))
+	assert.Equal(t, []struct{}{{}, {}, {}}, units)
+}
+
+func TestEncodeError(t *testing.T) {
+	_, err := Encode(nil)
+	assert.Error(t, err)
+
+	_, err = Encode(float64(1))
+	assert.Equal(t, "Unsupported Go type float64", err.Error())
+
+	_, err = Encode(big.NewInt(-1))
+	assert.Error(t, err)
+
+	_, err = Encode(struct{ A, B, C, D int32 }{})
+	assert.Error(t, err)
+
+	_, err = Encode(struct {
+		A int32 `cl:"0"`
+		B int32 `cl:"0"`
+	}{})
+	assert.Error(t, err)
+}
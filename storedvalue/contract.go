@@ -30,34 +30,31 @@ func NewContract(body []byte, namedKeys NamedKeys, protocolVersion ProtocolVersi
 
 func (c Contract) FromBytes(src []byte) (contract Contract, err error, pos int) {
 	pos = 0
-	bodySizeBytes := src[pos : pos+SIZE_LENGTH]
+	bodySize, err := sizeFromBytes(src, pos, "Contract body")
+	if err != nil {
+		return Contract{}, err, pos
+	}
 	pos += SIZE_LENGTH
-	bodySize := int(binary.LittleEndian.Uint32(bodySizeBytes))
+	if err := checkLength(src, pos, bodySize, "Contract body"); err != nil {
+		return Contract{}, err, pos
+	}
 	body := src[pos : pos+bodySize]
 	pos += bodySize
 
 	// NamedKeys
-	namedKeys := NamedKeys{}
-	namedKeysSizeBytes := src[pos : pos+SIZE_LENGTH]
-	pos += SIZE_LENGTH
-	namedKeysSize := int(binary.LittleEndian.Uint32(namedKeysSizeBytes))
-	for i := 0; i < namedKeysSize; i++ {
-		var namedKey NamedKey
-		namedKey, err, length := namedKey.FromBytes(src[pos:])
-		if err != nil {
-			return Contract{}, err, pos
-		}
-		pos += length
-
-		namedKeys = append(namedKeys, namedKey)
+	var namedKeys NamedKeys
+	namedKeys, err, length := namedKeys.FromBytes(src[pos:])
+	if err != nil {
+		return Contract{}, shiftDecodeError(err, pos), pos
 	}
+	pos += length
 
 	var protocolVersion ProtocolVersion
-	protocolVersion, err, length := protocolVersion.FromBytes(src[pos:])
-	pos += length
+	protocolVersion, err, length = protocolVersion.FromBytes(src[pos:])
 	if err != nil {
-		return Contract{}, err, pos
+		return Contract{}, shiftDecodeError(err, pos), pos
 	}
+	pos += length
 
 	c = NewContract(body, namedKeys, protocolVersion)
 	return c, nil, pos
@@ -66,13 +63,9 @@ func (c Contract) FromBytes(src []byte) (contract Contract, err error, pos int)
 func (c Contract) ToBytes() []byte {
 	res := make([]byte, SIZE_LENGTH)
 	binary.LittleEndian.PutUint32(res, uint32(len(c.Body)))
+	res = append(res, c.Body...)
 
-	namedKeysLengthBytes := make([]byte, SIZE_LENGTH)
-	binary.LittleEndian.PutUint32(namedKeysLengthBytes, uint32(len(c.NamedKeys)))
-	res = append(res, namedKeysLengthBytes...)
-	for _, namedKey := range c.NamedKeys {
-		res = append(res, namedKey.ToBytes()...)
-	}
+	res = append(res, c.NamedKeys.ToBytes()...)
 
 	res = append(res, c.ProtocolVersion.ToBytes()...)
 
@@ -98,22 +91,22 @@ func (c Contract) FromStateValue(state *state.Contract) (Contract, error) {
 		var namedKey NamedKey
 		namedKey, err := namedKey.FromStateValue(stateNamedKey)
 		if err != nil {
-			return Contract{}, nil
+			return Contract{}, err
 		}
 		namedKeys = append(namedKeys, namedKey)
 	}
 
 	return NewContract(
-		state.Body,
+		state.GetBody(),
 		namedKeys,
-		NewProtocolVersion(state.ProtocolVersion.GetMajor(), state.ProtocolVersion.GetMinor(), state.ProtocolVersion.GetPatch()),
+		NewProtocolVersion(state.GetProtocolVersion().GetMajor(), state.GetProtocolVersion().GetMinor(), state.GetProtocolVersion().GetPatch()),
 	), nil
 }
 
 type ProtocolVersion struct {
-	Major uint32 `json:"major,omitempty"`
-	Minor uint32 `json:"minor,omitempty"`
-	Patch uint32 `json:"patch,omitempty"`
+	Major uint32 `json:"major"`
+	Minor uint32 `json:"minor"`
+	Patch uint32 `json:"patch"`
 }
 
 func NewProtocolVersion(major uint32, minor uint32, patch uint32) ProtocolVersion {
@@ -126,8 +119,8 @@ func NewProtocolVersion(major uint32, minor uint32, patch uint32) ProtocolVersio
 
 func (p ProtocolVersion) FromBytes(src []byte) (protocolVersion ProtocolVersion, err error, pos int) {
 	pos = 0
-	if len(src) != PROTOCOL_VERSION_LENGTH {
-		return ProtocolVersion{}, fmt.Errorf("ActionThresholds must be %d, but %d", PROTOCOL_VERSION_LENGTH, len(src)), pos
+	if err := checkLength(src, pos, PROTOCOL_VERSION_LENGTH, "ProtocolVersion"); err != nil {
+		return ProtocolVersion{}, err, pos
 	}
 
 	major := binary.LittleEndian.Uint32(src[pos : pos+PROTOCOL_VERSION_MAJOR_LENGTH])
@@ -148,7 +141,7 @@ func (p ProtocolVersion) ToBytes() []byte {
 	binary.LittleEndian.PutUint32(res[pos:pos+PROTOCOL_VERSION_MAJOR_LENGTH], p.Major)
 	pos += PROTOCOL_VERSION_MAJOR_LENGTH
 	binary.LittleEndian.PutUint32(res[pos:pos+PROTOCOL_VERSION_MINOR_LENGTH], p.Minor)
-	pos += PROTOCOL_VERSION_PATCH_LENGTH
+	pos += PROTOCOL_VERSION_MINOR_LENGTH
 	binary.LittleEndian.PutUint32(res[pos:pos+PROTOCOL_VERSION_PATCH_LENGTH], p.Patch)
 	return res
 }
@@ -160,3 +153,21 @@ func (p ProtocolVersion) ToStateValue() *state.ProtocolVersion {
 		Patch: p.Patch,
 	}
 }
+
+// Compare 는 major, minor, patch 순서로 비교하여 -1, 0, 1 을 return 하는 함수.
+func (p ProtocolVersion) Compare(other ProtocolVersion) int {
+	for _, v := range [][2]uint32{{p.Major, other.Major}, {p.Minor, other.Minor}, {p.Patch, other.Patch}} {
+		switch {
+		case v[0] < v[1]:
+			return -1
+		case v[0] > v[1]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (p ProtocolVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", p.Major, p.Minor, p.Patch)
+}
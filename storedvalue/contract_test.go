@@ -25,18 +25,18 @@ func TestContractAbi(t *testing.T) {
 	assert.Equal(t, 5, len(contract.NamedKeys))
 
 	assert.Equal(t, "d_d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84_d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84_1000000000000000000", contract.NamedKeys[0].Name)
-	assert.Equal(t, make([]byte, 32), contract.NamedKeys[4].Key.Hash)
+	assert.Equal(t, make([]byte, 32), contract.NamedKeys[4].Key.Address())
 	assert.Equal(t, "pos_bonding_purse", contract.NamedKeys[1].Name)
-	assert.Equal(t, "7cdb081c47a129b41273a1d2830f7f8481eae8380978e17cec5b4e4f9e1d0b68", hex.EncodeToString(contract.NamedKeys[1].Key.Uref.GetAddress()))
-	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, contract.NamedKeys[1].Key.Uref.GetAccessRights())
+	assert.Equal(t, "7cdb081c47a129b41273a1d2830f7f8481eae8380978e17cec5b4e4f9e1d0b68", hex.EncodeToString(contract.NamedKeys[1].Key.Address()))
+	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, contract.NamedKeys[1].Key.AccessRights())
 	assert.Equal(t, "pos_payment_purse", contract.NamedKeys[2].Name)
-	assert.Equal(t, "51f1ddda0933696150cf78fe7a2141653e6a841d2f4ecaaa915a299cb7a4d19c", hex.EncodeToString(contract.NamedKeys[2].Key.Uref.GetAddress()))
-	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, contract.NamedKeys[2].Key.Uref.GetAccessRights())
+	assert.Equal(t, "51f1ddda0933696150cf78fe7a2141653e6a841d2f4ecaaa915a299cb7a4d19c", hex.EncodeToString(contract.NamedKeys[2].Key.Address()))
+	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, contract.NamedKeys[2].Key.AccessRights())
 	assert.Equal(t, "pos_rewards_purse", contract.NamedKeys[3].Name)
-	assert.Equal(t, "c32d411249f72f9da9d61c8e0d115f3000ce00d6889b8195b94bc020ba522b1b", hex.EncodeToString(contract.NamedKeys[3].Key.Uref.GetAddress()))
-	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, contract.NamedKeys[3].Key.Uref.GetAccessRights())
+	assert.Equal(t, "c32d411249f72f9da9d61c8e0d115f3000ce00d6889b8195b94bc020ba522b1b", hex.EncodeToString(contract.NamedKeys[3].Key.Address()))
+	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, contract.NamedKeys[3].Key.AccessRights())
 	assert.Equal(t, "v_d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84_1000000000000000000", contract.NamedKeys[4].Name)
-	assert.Equal(t, make([]byte, 32), contract.NamedKeys[4].Key.Hash)
+	assert.Equal(t, make([]byte, 32), contract.NamedKeys[4].Key.Address())
 
 	assert.Equal(t,
 		NewProtocolVersion(1, 0, 0),
@@ -0,0 +1,406 @@
+package storedvalue
+
+import (
+	"fmt"
+)
+
+// ContractWasm 은 STORED_VALUE_FORMAT_V2 에서 contract의 wasm bytes.
+type ContractWasm struct {
+	Bytes []byte `json:"bytes"`
+}
+
+func NewContractWasm(bytes []byte) ContractWasm {
+	return ContractWasm{Bytes: bytes}
+}
+
+func (c ContractWasm) FromBytes(src []byte) (contractWasm ContractWasm, err error, pos int) {
+	bytes, pos, err := sizedBytesFromBytes(src, 0, "ContractWasm")
+	if err != nil {
+		return ContractWasm{}, err, pos
+	}
+
+	return NewContractWasm(bytes), nil, pos
+}
+
+func (c ContractWasm) ToBytes() []byte {
+	return sizedBytesToBytes(c.Bytes)
+}
+
+// ENTRY_POINT_TYPE 은 entry point가 session 과 contract 중 어디에서 실행되는지 나타낸다.
+type ENTRY_POINT_TYPE int
+
+const (
+	ENTRY_POINT_TYPE_SESSION ENTRY_POINT_TYPE = iota
+	ENTRY_POINT_TYPE_CONTRACT
+)
+
+// EntryPointAccess 의 tag. casper-types 와 같이 1 부터 시작한다.
+const (
+	ENTRY_POINT_ACCESS_PUBLIC = 1
+	ENTRY_POINT_ACCESS_GROUPS = 2
+)
+
+// Parameter 는 entry point 인자의 이름과 CLType.
+type Parameter struct {
+	Name   string `json:"name"`
+	CLType CLType `json:"cl_type"`
+}
+
+// EntryPointAccess 는 entry point를 호출할 수 있는 대상. Public 이 아니면 Groups 에 속한 URef 만 호출할 수 있다.
+type EntryPointAccess struct {
+	Public bool     `json:"public"`
+	Groups []string `json:"groups"`
+}
+
+// EntryPoint 는 ContractV2 에서 호출할 수 있는 함수의 정보.
+type EntryPoint struct {
+	Name   string           `json:"name"`
+	Args   []Parameter      `json:"args"`
+	Ret    CLType           `json:"ret"`
+	Access EntryPointAccess `json:"access"`
+	Type   ENTRY_POINT_TYPE `json:"entry_point_type"`
+}
+
+func entryPointFromBytes(src []byte) (entryPoint EntryPoint, err error, pos int) {
+	entryPoint.Name, pos, err = stringFromBytes(src, pos, "EntryPoint name")
+	if err != nil {
+		return EntryPoint{}, err, pos
+	}
+
+	argsSize, err := sizeFromBytes(src, pos, "EntryPoint args")
+	if err != nil {
+		return EntryPoint{}, err, pos
+	}
+	pos += SIZE_LENGTH
+	entryPoint.Args = []Parameter{}
+	for i := 0; i < argsSize; i++ {
+		var parameter Parameter
+		parameter.Name, pos, err = stringFromBytes(src, pos, "Parameter name")
+		if err != nil {
+			return EntryPoint{}, err, pos
+		}
+		clType, err, length := clTypeFromBytes(src[pos:], STORED_VALUE_FORMAT_V2, 0)
+		if err != nil {
+			return EntryPoint{}, shiftDecodeError(err, pos), pos
+		}
+		pos += length
+		parameter.CLType = clType
+
+		entryPoint.Args = append(entryPoint.Args, parameter)
+	}
+
+	ret, err, length := clTypeFromBytes(src[pos:], STORED_VALUE_FORMAT_V2, 0)
+	if err != nil {
+		return EntryPoint{}, shiftDecodeError(err, pos), pos
+	}
+	pos += length
+	entryPoint.Ret = ret
+
+	if err := checkLength(src, pos, TAG_LENGTH, "EntryPointAccess"); err != nil {
+		return EntryPoint{}, err, pos
+	}
+	switch src[pos] {
+	case ENTRY_POINT_ACCESS_PUBLIC:
+		entryPoint.Access.Public = true
+		pos += TAG_LENGTH
+	case ENTRY_POINT_ACCESS_GROUPS:
+		pos += TAG_LENGTH
+		groupsSize, err := sizeFromBytes(src, pos, "EntryPointAccess groups")
+		if err != nil {
+			return EntryPoint{}, err, pos
+		}
+		pos += SIZE_LENGTH
+		entryPoint.Access.Groups = []string{}
+		for i := 0; i < groupsSize; i++ {
+			var group string
+			group, pos, err = stringFromBytes(src, pos, "EntryPointAccess group")
+			if err != nil {
+				return EntryPoint{}, err, pos
+			}
+			entryPoint.Access.Groups = append(entryPoint.Access.Groups, group)
+		}
+	default:
+		return EntryPoint{}, decodeErrorf(pos, "EntryPointAccess tag must be 1 or 2, but %d", src[pos]), pos
+	}
+
+	if err := checkLength(src, pos, TAG_LENGTH, "EntryPointType"); err != nil {
+		return EntryPoint{}, err, pos
+	}
+	entryPoint.Type = ENTRY_POINT_TYPE(src[pos])
+	if entryPoint.Type != ENTRY_POINT_TYPE_SESSION && entryPoint.Type != ENTRY_POINT_TYPE_CONTRACT {
+		return EntryPoint{}, decodeErrorf(pos, "EntryPointType must be 0 or 1, but %d", src[pos]), pos
+	}
+	pos += TAG_LENGTH
+
+	return entryPoint, nil, pos
+}
+
+func (e EntryPoint) toBytes() ([]byte, error) {
+	res := sizedBytesToBytes([]byte(e.Name))
+
+	res = append(res, sizeToBytes(len(e.Args))...)
+	for _, parameter := range e.Args {
+		clType, err := parameter.CLType.toBytesWithFormat(STORED_VALUE_FORMAT_V2)
+		if err != nil {
+			return nil, fmt.Errorf("EntryPoint %s arg %s : %s", e.Name, parameter.Name, err)
+		}
+		res = append(res, sizedBytesToBytes([]byte(parameter.Name))...)
+		res = append(res, clType...)
+	}
+
+	ret, err := e.Ret.toBytesWithFormat(STORED_VALUE_FORMAT_V2)
+	if err != nil {
+		return nil, fmt.Errorf("EntryPoint %s ret : %s", e.Name, err)
+	}
+	res = append(res, ret...)
+
+	if e.Access.Public {
+		res = append(res, ENTRY_POINT_ACCESS_PUBLIC)
+	} else {
+		res = append(res, ENTRY_POINT_ACCESS_GROUPS)
+		res = append(res, sizeToBytes(len(e.Access.Groups))...)
+		for _, group := range e.Access.Groups {
+			res = append(res, sizedBytesToBytes([]byte(group))...)
+		}
+	}
+
+	return append(res, byte(e.Type)), nil
+}
+
+// ContractV2 는 STORED_VALUE_FORMAT_V2 의 contract. wasm 은 ContractWasmHash 의 ContractWasm 에 저장된다.
+//
+// EntryPoints 는 EE와 같이 이름 순서로 serialize 되어 있어야 한다.
+type ContractV2 struct {
+	ContractPackageHash []byte          `json:"contract_package_hash"`
+	ContractWasmHash    []byte          `json:"contract_wasm_hash"`
+	NamedKeys           NamedKeys       `json:"named_keys"`
+	EntryPoints         []EntryPoint    `json:"entry_points"`
+	ProtocolVersion     ProtocolVersion `json:"protocol_version"`
+}
+
+func (c ContractV2) FromBytes(src []byte) (contract ContractV2, err error, pos int) {
+	contract.ContractPackageHash, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "ContractV2 contract package hash")
+	if err != nil {
+		return ContractV2{}, err, pos
+	}
+	contract.ContractWasmHash, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "ContractV2 contract wasm hash")
+	if err != nil {
+		return ContractV2{}, err, pos
+	}
+
+	namedKeys, err, length := namedKeysFromBytes(src[pos:], STORED_VALUE_FORMAT_V2)
+	if err != nil {
+		return ContractV2{}, shiftDecodeError(err, pos), pos
+	}
+	pos += length
+	contract.NamedKeys = namedKeys
+
+	// EntryPoints 는 이름을 key로 하는 map 으로 serialize 된다.
+	entryPointsSize, err := sizeFromBytes(src, pos, "EntryPoints")
+	if err != nil {
+		return ContractV2{}, err, pos
+	}
+	pos += SIZE_LENGTH
+	contract.EntryPoints = []EntryPoint{}
+	for i := 0; i < entryPointsSize; i++ {
+		namePos := pos
+		var name string
+		name, pos, err = stringFromBytes(src, pos, "EntryPoints key")
+		if err != nil {
+			return ContractV2{}, err, pos
+		}
+		entryPoint, err, length := entryPointFromBytes(src[pos:])
+		if err != nil {
+			return ContractV2{}, shiftDecodeError(err, pos), pos
+		}
+		if entryPoint.Name != name {
+			return ContractV2{}, decodeErrorf(namePos, "EntryPoints key %q is different from name %q", name, entryPoint.Name), namePos
+		}
+		pos += length
+
+		contract.EntryPoints = append(contract.EntryPoints, entryPoint)
+	}
+
+	protocolVersion, err, length := ProtocolVersion{}.FromBytes(src[pos:])
+	if err != nil {
+		return ContractV2{}, shiftDecodeError(err, pos), pos
+	}
+	pos += length
+	contract.ProtocolVersion = protocolVersion
+
+	return contract, nil, pos
+}
+
+// ToBytes 는 ContractV2 를 serialize 하는 함수.
+//
+// named keys 나 entry point의 CLType 이 STORED_VALUE_FORMAT_V2 에서 표현할 수 없으면 error 이다.
+func (c ContractV2) ToBytes() ([]byte, error) {
+	if len(c.ContractPackageHash) != ADDRESS_LENGTH || len(c.ContractWasmHash) != ADDRESS_LENGTH {
+		return nil, fmt.Errorf("ContractV2 hash length must be %d", ADDRESS_LENGTH)
+	}
+	if err := checkKeysFormat(c.NamedKeys, STORED_VALUE_FORMAT_V2); err != nil {
+		return nil, err
+	}
+
+	res := append([]byte{}, c.ContractPackageHash...)
+	res = append(res, c.ContractWasmHash...)
+	res = append(res, c.NamedKeys.ToBytes()...)
+
+	res = append(res, sizeToBytes(len(c.EntryPoints))...)
+	for _, entryPoint := range c.EntryPoints {
+		entryPointBytes, err := entryPoint.toBytes()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sizedBytesToBytes([]byte(entryPoint.Name))...)
+		res = append(res, entryPointBytes...)
+	}
+
+	return append(res, c.ProtocolVersion.ToBytes()...), nil
+}
+
+// ContractVersionKey 는 protocol major version 과 그 안에서의 contract version.
+type ContractVersionKey struct {
+	ProtocolVersionMajor uint32 `json:"protocol_version_major"`
+	ContractVersion      uint32 `json:"contract_version"`
+}
+
+func contractVersionKeyFromBytes(src []byte, pos int) (ContractVersionKey, int, error) {
+	major, pos, err := uint32FromBytes(src, pos, "ContractVersionKey protocol version major")
+	if err != nil {
+		return ContractVersionKey{}, pos, err
+	}
+	version, pos, err := uint32FromBytes(src, pos, "ContractVersionKey contract version")
+	if err != nil {
+		return ContractVersionKey{}, pos, err
+	}
+
+	return ContractVersionKey{ProtocolVersionMajor: major, ContractVersion: version}, pos, nil
+}
+
+func (c ContractVersionKey) toBytes() []byte {
+	return append(uint32ToBytes(c.ProtocolVersionMajor), uint32ToBytes(c.ContractVersion)...)
+}
+
+// ContractVersion 은 ContractPackage 의 version 과 그 version의 contract hash.
+type ContractVersion struct {
+	Key          ContractVersionKey `json:"key"`
+	ContractHash []byte             `json:"contract_hash"`
+}
+
+// ContractGroup 은 ContractPackage 의 group 이름과 group에 속한 URef 목록.
+type ContractGroup struct {
+	Name  string `json:"name"`
+	URefs []URef `json:"urefs"`
+}
+
+// ContractPackage 는 STORED_VALUE_FORMAT_V2 에서 contract의 version 목록.
+type ContractPackage struct {
+	AccessKey        URef                 `json:"access_key"`
+	Versions         []ContractVersion    `json:"versions"`
+	DisabledVersions []ContractVersionKey `json:"disabled_versions"`
+	Groups           []ContractGroup      `json:"groups"`
+}
+
+func (c ContractPackage) FromBytes(src []byte) (contractPackage ContractPackage, err error, pos int) {
+	accessKey, err, pos := URef{}.FromBytes(src)
+	if err != nil {
+		return ContractPackage{}, err, pos
+	}
+	contractPackage.AccessKey = accessKey
+
+	versionsSize, err := sizeFromBytes(src, pos, "ContractPackage versions")
+	if err != nil {
+		return ContractPackage{}, err, pos
+	}
+	pos += SIZE_LENGTH
+	contractPackage.Versions = []ContractVersion{}
+	for i := 0; i < versionsSize; i++ {
+		var version ContractVersion
+		version.Key, pos, err = contractVersionKeyFromBytes(src, pos)
+		if err != nil {
+			return ContractPackage{}, err, pos
+		}
+		version.ContractHash, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "ContractVersion contract hash")
+		if err != nil {
+			return ContractPackage{}, err, pos
+		}
+
+		contractPackage.Versions = append(contractPackage.Versions, version)
+	}
+
+	disabledSize, err := sizeFromBytes(src, pos, "ContractPackage disabled versions")
+	if err != nil {
+		return ContractPackage{}, err, pos
+	}
+	pos += SIZE_LENGTH
+	contractPackage.DisabledVersions = []ContractVersionKey{}
+	for i := 0; i < disabledSize; i++ {
+		var versionKey ContractVersionKey
+		versionKey, pos, err = contractVersionKeyFromBytes(src, pos)
+		if err != nil {
+			return ContractPackage{}, err, pos
+		}
+
+		contractPackage.DisabledVersions = append(contractPackage.DisabledVersions, versionKey)
+	}
+
+	groupsSize, err := sizeFromBytes(src, pos, "ContractPackage groups")
+	if err != nil {
+		return ContractPackage{}, err, pos
+	}
+	pos += SIZE_LENGTH
+	contractPackage.Groups = []ContractGroup{}
+	for i := 0; i < groupsSize; i++ {
+		var group ContractGroup
+		group.Name, pos, err = stringFromBytes(src, pos, "ContractGroup name")
+		if err != nil {
+			return ContractPackage{}, err, pos
+		}
+		urefsSize, err := sizeFromBytes(src, pos, "ContractGroup urefs")
+		if err != nil {
+			return ContractPackage{}, err, pos
+		}
+		pos += SIZE_LENGTH
+		group.URefs = []URef{}
+		for j := 0; j < urefsSize; j++ {
+			uref, err, length := URef{}.FromBytes(src[pos:])
+			if err != nil {
+				return ContractPackage{}, shiftDecodeError(err, pos), pos
+			}
+			pos += length
+
+			group.URefs = append(group.URefs, uref)
+		}
+
+		contractPackage.Groups = append(contractPackage.Groups, group)
+	}
+
+	return contractPackage, nil, pos
+}
+
+func (c ContractPackage) ToBytes() []byte {
+	res := c.AccessKey.ToBytes()
+
+	res = append(res, sizeToBytes(len(c.Versions))...)
+	for _, version := range c.Versions {
+		res = append(res, version.Key.toBytes()...)
+		res = append(res, version.ContractHash...)
+	}
+
+	res = append(res, sizeToBytes(len(c.DisabledVersions))...)
+	for _, versionKey := range c.DisabledVersions {
+		res = append(res, versionKey.toBytes()...)
+	}
+
+	res = append(res, sizeToBytes(len(c.Groups))...)
+	for _, group := range c.Groups {
+		res = append(res, sizedBytesToBytes([]byte(group.Name))...)
+		res = append(res, sizeToBytes(len(group.URefs))...)
+		for _, uref := range group.URefs {
+			res = append(res, uref.ToBytes()...)
+		}
+	}
+
+	return res
+}
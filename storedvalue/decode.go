@@ -0,0 +1,372 @@
+package storedvalue
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// DecodeError 는 FromBytes 계열 함수가 return 하는 error.
+//
+// Offset 은 가장 바깥 decoder에 전달된 bytes 기준으로 decode에 실패한 위치이다.
+type DecodeError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s (offset %d)", e.Msg, e.Offset)
+}
+
+func decodeErrorf(offset int, format string, args ...interface{}) error {
+	return &DecodeError{Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// shiftDecodeError 는 내부 decoder가 return 한 error의 offset에 base를 더하는 함수.
+func shiftDecodeError(err error, base int) error {
+	if decodeErr, ok := err.(*DecodeError); ok {
+		return &DecodeError{Offset: decodeErr.Offset + base, Msg: decodeErr.Msg}
+	}
+
+	return &DecodeError{Offset: base, Msg: err.Error()}
+}
+
+// checkLength 는 src의 pos 이후에 name을 decode 할 length bytes가 남아있는지 검사하는 함수.
+func checkLength(src []byte, pos int, length int, name string) error {
+	if length < 0 || pos > len(src) || len(src)-pos < length {
+		remain := len(src) - pos
+		if remain < 0 {
+			remain = 0
+		}
+		return decodeErrorf(pos, "%s needs %d bytes, but %d", name, length, remain)
+	}
+
+	return nil
+}
+
+// sizeFromBytes 는 pos 위치에 serialize 된 u32 길이 또는 개수를 읽는 함수.
+func sizeFromBytes(src []byte, pos int, name string) (int, error) {
+	if err := checkLength(src, pos, SIZE_LENGTH, name+" size"); err != nil {
+		return 0, err
+	}
+
+	return int(binary.LittleEndian.Uint32(src[pos : pos+SIZE_LENGTH])), nil
+}
+
+// checkSequenceCount 는 남은 remain bytes 에서 count 개의 원소를 decode 할 수 있는지 검사하는 함수.
+//
+// 크기가 0 인 원소가 아니면 원소마다 적어도 1 byte가 필요하므로 remain 보다 큰 count 는 손상된 data 이고,
+// 크기가 0 인 원소는 MAX_ZERO_SIZED_SEQUENCE_LENGTH 개까지만 허용한다.
+func checkSequenceCount(zeroSized bool, count int, remain int) error {
+	if zeroSized {
+		if count > MAX_ZERO_SIZED_SEQUENCE_LENGTH {
+			return fmt.Errorf("Sequence of zero sized elements has %d elements, more than %d", count, MAX_ZERO_SIZED_SEQUENCE_LENGTH)
+		}
+	} else if count > remain {
+		return fmt.Errorf("Sequence has %d elements, but only %d bytes remain", count, remain)
+	}
+
+	return nil
+}
+
+// bytesFromBytes 는 pos 위치의 length bytes를 읽고 다음 위치를 return 하는 함수.
+func bytesFromBytes(src []byte, pos int, length int, name string) ([]byte, int, error) {
+	if err := checkLength(src, pos, length, name); err != nil {
+		return nil, pos, err
+	}
+
+	return src[pos : pos+length], pos + length, nil
+}
+
+// sizedBytesFromBytes 는 pos 위치의 u32 길이가 앞에 붙은 bytes를 읽고 다음 위치를 return 하는 함수.
+func sizedBytesFromBytes(src []byte, pos int, name string) ([]byte, int, error) {
+	size, err := sizeFromBytes(src, pos, name)
+	if err != nil {
+		return nil, pos, err
+	}
+
+	return bytesFromBytes(src, pos+SIZE_LENGTH, size, name)
+}
+
+func stringFromBytes(src []byte, pos int, name string) (string, int, error) {
+	res, pos, err := sizedBytesFromBytes(src, pos, name)
+	return string(res), pos, err
+}
+
+func uint32FromBytes(src []byte, pos int, name string) (uint32, int, error) {
+	res, pos, err := bytesFromBytes(src, pos, UINT32_LENGTH, name)
+	if err != nil {
+		return 0, pos, err
+	}
+
+	return binary.LittleEndian.Uint32(res), pos, nil
+}
+
+// bigIntFromBytes 는 pos 위치의 1 byte 길이와 little endian bytes로 serialize 된 큰 정수를 읽는 함수.
+func bigIntFromBytes(src []byte, pos int, maxLength int, name string) (*big.Int, int, error) {
+	if err := checkLength(src, pos, BIGINT_SIZE_LENGTH, name); err != nil {
+		return nil, pos, err
+	}
+	length := int(src[pos])
+	if length > maxLength {
+		return nil, pos, decodeErrorf(pos, "%s length must be at most %d, but %d", name, maxLength, length)
+	}
+	if err := checkLength(src, pos, BIGINT_SIZE_LENGTH+length, name); err != nil {
+		return nil, pos, err
+	}
+	// EE 는 상위 0 byte를 serialize 하지 않으므로, 0 으로 끝나는 bytes는 다시 serialize 할 수 없다.
+	if length > 0 && src[pos+length] == 0 {
+		return nil, pos, decodeErrorf(pos, "%s must not end with zero byte", name)
+	}
+
+	value := fromByteToBigInt(append([]byte{}, src[pos:pos+BIGINT_SIZE_LENGTH+length]...))
+	if value.Sign() == 0 {
+		value = new(big.Int)
+	}
+
+	return value, pos + BIGINT_SIZE_LENGTH + length, nil
+}
+
+func sizeToBytes(size int) []byte {
+	res := make([]byte, SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(res, uint32(size))
+	return res
+}
+
+func sizedBytesToBytes(src []byte) []byte {
+	return append(sizeToBytes(len(src)), src...)
+}
+
+func uint32ToBytes(value uint32) []byte {
+	res := make([]byte, UINT32_LENGTH)
+	binary.LittleEndian.PutUint32(res, value)
+	return res
+}
+
+// bigIntToBytes 는 음수가 아닌 큰 정수를 1 byte 길이와 최소 길이의 little endian bytes로 serialize 하는 함수.
+// nil은 0으로 serialize 한다.
+func bigIntToBytes(value *big.Int) []byte {
+	if value == nil {
+		return []byte{0}
+	}
+
+	res := reverseBytes(value.Bytes())
+	return append([]byte{byte(len(res))}, res...)
+}
+
+// clOption 은 decodeCLValue 가 decode 한 Option 값. None 이면 Some 이 false 이고 Value 는 nil 이다.
+type clOption struct {
+	Some  bool
+	Value interface{}
+}
+
+// clResult 는 decodeCLValue 가 decode 한 Result 값.
+type clResult struct {
+	Ok    bool
+	Value interface{}
+}
+
+// clMapEntry 는 decodeCLValue 가 decode 한 Map 의 key, value 한 쌍.
+type clMapEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// decodeCLValue 는 src 의 clType 값을 decode 하고 값의 길이를 return 하는 함수.
+//
+// 값은 type에 따라 bool, int32, int64, uint8, uint32, uint64, *big.Int, struct{}, string, Key, URef,
+// clOption, clResult, []clMapEntry 로 decode 된다. List<U8> 와 FixedList<U8, N> 은 []byte,
+// 그 외의 list 와 tuple 은 []interface{} 이다.
+// format 이 V2 이면 V1 의 Local key 로 잘못 해석될 key id 3 이상의 Key 는 error 이다.
+//
+// CLValue.Decode, JSON 변환, CLValueInstance 변환, V2 key 검사는 모두 이 함수의 결과를 사용한다.
+func decodeCLValue(clType CLType, src []byte, format STORED_VALUE_FORMAT) (value interface{}, pos int, err error) {
+	inner := func(innerType CLType) (interface{}, error) {
+		value, length, err := decodeCLValue(innerType, src[pos:], format)
+		if err != nil {
+			return nil, shiftDecodeError(err, pos)
+		}
+		pos += length
+		return value, nil
+	}
+
+	var res []byte
+	switch clType.Tag {
+	case TAG_BOOL:
+		if res, pos, err = bytesFromBytes(src, 0, 1, "Bool"); err != nil {
+			return nil, pos, err
+		}
+		if res[0] > 1 {
+			return nil, 0, decodeErrorf(0, "Bool byte must be 0 or 1, but %d", res[0])
+		}
+		return res[0] == 1, pos, nil
+	case TAG_I32:
+		if res, pos, err = bytesFromBytes(src, 0, INT32_LENGTH, "I32"); err != nil {
+			return nil, pos, err
+		}
+		return int32(binary.LittleEndian.Uint32(res)), pos, nil
+	case TAG_I64:
+		if res, pos, err = bytesFromBytes(src, 0, LONG_LENGTH, "I64"); err != nil {
+			return nil, pos, err
+		}
+		return int64(binary.LittleEndian.Uint64(res)), pos, nil
+	case TAG_U8:
+		if res, pos, err = bytesFromBytes(src, 0, 1, "U8"); err != nil {
+			return nil, pos, err
+		}
+		return res[0], pos, nil
+	case TAG_U32:
+		if res, pos, err = bytesFromBytes(src, 0, UINT32_LENGTH, "U32"); err != nil {
+			return nil, pos, err
+		}
+		return binary.LittleEndian.Uint32(res), pos, nil
+	case TAG_U64:
+		if res, pos, err = bytesFromBytes(src, 0, LONG_LENGTH, "U64"); err != nil {
+			return nil, pos, err
+		}
+		return binary.LittleEndian.Uint64(res), pos, nil
+	case TAG_U128, TAG_U256, TAG_U512:
+		var bigIntValue *big.Int
+		if bigIntValue, pos, err = bigIntFromBytes(src, 0, bigIntMaxLengths[clType.Tag], clType.String()); err != nil {
+			return nil, pos, err
+		}
+		return bigIntValue, pos, nil
+	case TAG_UNIT:
+		return struct{}{}, 0, nil
+	case TAG_STRING:
+		var str string
+		if str, pos, err = stringFromBytes(src, 0, "String"); err != nil {
+			return nil, pos, err
+		}
+		return str, pos, nil
+	case TAG_KEY:
+		if format == STORED_VALUE_FORMAT_V2 {
+			if err := checkLength(src, 0, KEY_ID_LENGTH, "Key id"); err != nil {
+				return nil, 0, err
+			}
+			if KEY_ID(src[KEY_ID_POS]) > KEY_ID_UREF {
+				return nil, 0, decodeErrorf(KEY_ID_POS, "Key id %d is not supported in %s format", src[KEY_ID_POS], format)
+			}
+		}
+		var key Key
+		if key, err, pos = key.FromBytes(src); err != nil {
+			return nil, pos, shiftDecodeError(err, 0)
+		}
+		return key, pos, nil
+	case TAG_UREF:
+		var uref URef
+		if uref, err, pos = uref.FromBytes(src); err != nil {
+			return nil, pos, shiftDecodeError(err, 0)
+		}
+		return uref, pos, nil
+	case TAG_OPTION:
+		var some bool
+		if some, pos, err = optionFromBytes(src, 0, clType.String()); err != nil {
+			return nil, pos, err
+		}
+		if !some {
+			return clOption{}, pos, nil
+		}
+		value, err := inner(clType.Inner[0])
+		return clOption{Some: true, Value: value}, pos, err
+	case TAG_LIST, TAG_FIXED_LIST:
+		count := int(clType.Length)
+		// [u8; N] 은 bytes 그대로, 그 외의 list 는 길이가 앞에 붙는다.
+		if clType.Tag == TAG_LIST || clType.Inner[0].Tag != TAG_U8 {
+			if count, err = sizeFromBytes(src, 0, clType.String()); err != nil {
+				return nil, 0, err
+			}
+			if clType.Tag == TAG_FIXED_LIST && count != int(clType.Length) {
+				return nil, 0, decodeErrorf(0, "FixedList length must be %d, but %d", clType.Length, count)
+			}
+			pos = SIZE_LENGTH
+		}
+
+		if clType.Inner[0].Tag == TAG_U8 {
+			if res, pos, err = bytesFromBytes(src, pos, count, clType.String()); err != nil {
+				return nil, pos, err
+			}
+			return res, pos, nil
+		}
+		if err := checkSequenceCount(clType.Inner[0].isZeroSized(), count, len(src)-pos); err != nil {
+			return nil, pos, decodeErrorf(pos, "%s", err)
+		}
+
+		values := []interface{}{}
+		for i := 0; i < count; i++ {
+			value, err := inner(clType.Inner[0])
+			if err != nil {
+				return nil, pos, err
+			}
+			values = append(values, value)
+		}
+		return values, pos, nil
+	case TAG_RESULT:
+		if err := checkLength(src, 0, TAG_LENGTH, "Result"); err != nil {
+			return nil, 0, err
+		}
+		pos = TAG_LENGTH
+		switch src[0] {
+		case RESULT_OK_TAG:
+			value, err := inner(clType.Inner[0])
+			return clResult{Ok: true, Value: value}, pos, err
+		case RESULT_ERR_TAG:
+			value, err := inner(clType.Inner[1])
+			return clResult{Value: value}, pos, err
+		default:
+			return nil, 0, decodeErrorf(0, "Result tag must be 0 or 1, but %d", src[0])
+		}
+	case TAG_MAP:
+		count, err := sizeFromBytes(src, 0, "Map")
+		if err != nil {
+			return nil, 0, err
+		}
+		pos = SIZE_LENGTH
+		zeroSized := clType.Inner[0].isZeroSized() && clType.Inner[1].isZeroSized()
+		if err := checkSequenceCount(zeroSized, count, len(src)-pos); err != nil {
+			return nil, pos, decodeErrorf(pos, "%s", err)
+		}
+
+		entries := []clMapEntry{}
+		for i := 0; i < count; i++ {
+			key, err := inner(clType.Inner[0])
+			if err != nil {
+				return nil, pos, err
+			}
+			value, err := inner(clType.Inner[1])
+			if err != nil {
+				return nil, pos, err
+			}
+			entries = append(entries, clMapEntry{Key: key, Value: value})
+		}
+		return entries, pos, nil
+	case TAG_TUPLE1, TAG_TUPLE2, TAG_TUPLE3:
+		values := []interface{}{}
+		for _, innerType := range clType.Inner {
+			value, err := inner(innerType)
+			if err != nil {
+				return nil, pos, err
+			}
+			values = append(values, value)
+		}
+		return values, pos, nil
+	default:
+		return nil, 0, decodeErrorf(0, "%s value can not be decoded", clType)
+	}
+}
+
+// decodeBytes 는 Bytes 전체를 Type 의 값 하나로 decode 하는 함수.
+func (c CLValue) decodeBytes(format STORED_VALUE_FORMAT) (interface{}, error) {
+	if err := c.Type.Validate(); err != nil {
+		return nil, err
+	}
+	value, pos, err := decodeCLValue(c.Type, c.Bytes, format)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(c.Bytes) {
+		return nil, decodeErrorf(pos, "%d trailing bytes", len(c.Bytes)-pos)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,71 @@
+package storedvalue
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	decodeTestAccountHex  = "000000000000000000000000000000000000000000000000000000000000000002000000040000006d696e74026cc261631cd46c959857de59ee0a5f61099457300012267bbde569820625c7f80103000000706f7302bb0d91b8604970a269bf96ac55de5fa416135e2837d88a0bac938e2eca2d0fe2012efe91034583b378b4b9ffcc62b642650f5d455c4665f4206168ed0637ff7a7007010000000000000000000000000000000000000000000000000000000000000000000000010101"
+	decodeTestContractHex = "01000000000500000097000000645f643730323433646439643064363436666436646632383261386637613866613035613636323962656330316438303234633336313165623163316662396638345f643730323433646439643064363436666436646632383261386637613866613035613636323962656330316438303234633336313165623163316662396638345f3130303030303030303030303030303030303001000000000000000000000000000000000000000000000000000000000000000011000000706f735f626f6e64696e675f7075727365027cdb081c47a129b41273a1d2830f7f8481eae8380978e17cec5b4e4f9e1d0b680711000000706f735f7061796d656e745f70757273650251f1ddda0933696150cf78fe7a2141653e6a841d2f4ecaaa915a299cb7a4d19c0711000000706f735f726577617264735f707572736502c32d411249f72f9da9d61c8e0d115f3000ce00d6889b8195b94bc020ba522b1b0756000000765f643730323433646439643064363436666436646632383261386637613866613035613636323962656330316438303234633336313165623163316662396638345f31303030303030303030303030303030303030010000000000000000000000000000000000000000000000000000000000000000010000000000000000000000"
+)
+
+func decodeTestVectors(t testing.TB) map[string][]byte {
+	vectors := map[string][]byte{}
+	for name, str := range map[string]string{
+		"account":  "01" + decodeTestAccountHex,
+		"contract": "02" + decodeTestContractHex,
+		// Map<String, String>
+		"clvalue": "002d00000002000000090000006b79635f6c6576656c01000000310e000000737761707065645f616d6f756e740100000030110a0a",
+	} {
+		src, err := hex.DecodeString(str)
+		assert.NoError(t, err)
+		vectors[name] = src
+	}
+
+	return vectors
+}
+
+func TestStoredValueFromBytesTruncated(t *testing.T) {
+	for name, src := range decodeTestVectors(t) {
+		var storedValue StoredValue
+		_, err, pos := storedValue.FromBytes(src)
+		assert.NoError(t, err, name)
+		assert.Equal(t, len(src), pos, name)
+
+		for length := 0; length < len(src); length++ {
+			_, err, _ := storedValue.FromBytes(src[:length])
+			assert.Error(t, err, "%s truncated to %d", name, length)
+
+			decodeErr, ok := err.(*DecodeError)
+			assert.True(t, ok, "%s truncated to %d : %T", name, length, err)
+			if ok {
+				assert.True(t, decodeErr.Offset <= length, "%s truncated to %d : %s", name, length, err)
+			}
+		}
+	}
+}
+
+func TestDecodeErrorOffset(t *testing.T) {
+	src, err := hex.DecodeString("01" + decodeTestAccountHex)
+	assert.NoError(t, err)
+
+	// 두번째 named key("pos")의 key id를 잘못된 값으로 변경
+	keyIDOffset := 1 + ADDRESS_LENGTH + SIZE_LENGTH + (SIZE_LENGTH + 4 + 1 + ADDRESS_LENGTH + 1) + SIZE_LENGTH + 3
+	assert.Equal(t, byte(KEY_ID_UREF), src[keyIDOffset])
+	src[keyIDOffset] = 9
+
+	var storedValue StoredValue
+	_, err, _ = storedValue.FromBytes(src)
+	assert.Equal(t, &DecodeError{Offset: keyIDOffset, Msg: "Unknown key id 9"}, err)
+	assert.Equal(t, "Unknown key id 9 (offset 86)", err.Error())
+
+	var clValue CLValue
+	_, err, _ = clValue.FromBytes([]byte{0xff, 0xff, 0xff, 0xff, 1})
+	assert.Equal(t, &DecodeError{Offset: SIZE_LENGTH, Msg: "CLValue needs 4294967295 bytes, but 1"}, err)
+
+	_, err, _ = clValue.FromBytes([]byte{1, 0, 0, 0, 1, 13, 14, 15, 3, 1})
+	assert.Equal(t, &DecodeError{Offset: 9, Msg: "FixedList length needs 4 bytes, but 1"}, err)
+}
@@ -0,0 +1,336 @@
+package storedvalue
+
+import (
+	"fmt"
+)
+
+// STORED_VALUE_FORMAT 는 execution engine 버전에 따라 달라지는 StoredValue의 serialize 형식.
+//
+// 어떤 protocol version 에서 어떤 형식을 쓰는지는 chain 마다 다르므로,
+// 호출하는 쪽이 StoredValueFormatTable 을 넘겨 FormatForProtocolVersion 으로 형식을 정한다.
+type STORED_VALUE_FORMAT int
+
+const (
+	// STORED_VALUE_FORMAT_V1 은 CLValue(0), Account(1), Contract(2) 만 있는 현재 EE의 형식.
+	STORED_VALUE_FORMAT_V1 STORED_VALUE_FORMAT = iota + 1
+
+	// STORED_VALUE_FORMAT_V2 는 CLValue(0), Account(1), ContractWasm(2), Contract(3), ContractPackage(4),
+	// Transfer(5), DeployInfo(6), EraInfo(7) 이 있는 새 EE의 형식.
+	//
+	// V1 과 비교하여 Key id 3 이상은 Transfer, DeployInfo 등의 key 이며 Local key는 없다.
+	// CLType tag 15 는 U8 만 가지는 ByteArray(길이) 이고, tag 22 는 PublicKey 이다.
+	// Key id 3 이상의 key와 PublicKey CLType은 이 package에서 표현할 수 없으므로 decode 시 error 이다.
+	STORED_VALUE_FORMAT_V2
+)
+
+func (f STORED_VALUE_FORMAT) String() string {
+	switch f {
+	case STORED_VALUE_FORMAT_V1:
+		return "V1"
+	case STORED_VALUE_FORMAT_V2:
+		return "V2"
+	default:
+		return fmt.Sprintf("STORED_VALUE_FORMAT(%d)", int(f))
+	}
+}
+
+// StoredValueFormatFrom 은 From 이상의 protocol version 에서 Format 형식을 사용한다는 table 항목.
+type StoredValueFormatFrom struct {
+	From   ProtocolVersion
+	Format STORED_VALUE_FORMAT
+}
+
+// StoredValueFormatTable 은 chain 의 protocol version 별 StoredValue 형식. 항목의 순서는 상관없다.
+type StoredValueFormatTable []StoredValueFormatFrom
+
+// DefaultStoredValueFormatTable 은 모든 protocol version 에서 STORED_VALUE_FORMAT_V1 을 사용하는 현재 EE의 table을 return 하는 함수.
+func DefaultStoredValueFormatTable() StoredValueFormatTable {
+	return StoredValueFormatTable{{From: NewProtocolVersion(0, 0, 0), Format: STORED_VALUE_FORMAT_V1}}
+}
+
+// FormatForProtocolVersion 은 table 에서 From 이 protocolVersion 이하인 항목 중 가장 최근 항목의 형식을 return 하는 함수.
+//
+// 해당하는 항목이 없거나 알 수 없는 형식이면 error를 return 한다.
+func FormatForProtocolVersion(protocolVersion ProtocolVersion, table StoredValueFormatTable) (STORED_VALUE_FORMAT, error) {
+	found := false
+	var res StoredValueFormatFrom
+	for _, entry := range table {
+		if entry.From.Compare(protocolVersion) > 0 {
+			continue
+		}
+		if !found || entry.From.Compare(res.From) > 0 {
+			res = entry
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("No stored value format for protocol version %s", protocolVersion)
+	}
+	if _, ok := storedValueTags[res.Format]; !ok {
+		return 0, fmt.Errorf("Unknown stored value format %s for protocol version %s", res.Format, protocolVersion)
+	}
+
+	return res.Format, nil
+}
+
+// storedValueTags 는 형식별 StoredValue type byte 와 STORED_VALUE_TYPE 의 대응.
+var storedValueTags = map[STORED_VALUE_FORMAT][]STORED_VALUE_TYPE{
+	STORED_VALUE_FORMAT_V1: {TYPE_CL_VALUE, TYPE_ACCOUNT, TYPE_CONTRACT},
+	STORED_VALUE_FORMAT_V2: {TYPE_CL_VALUE, TYPE_ACCOUNT, TYPE_CONTRACT_WASM, TYPE_CONTRACT_V2,
+		TYPE_CONTRACT_PACKAGE, TYPE_TRANSFER, TYPE_DEPLOY_INFO, TYPE_ERA_INFO},
+}
+
+// FromBytesWithFormat 은 format 형식으로 serialize 된 StoredValue를 decode 하는 함수.
+func (s StoredValue) FromBytesWithFormat(src []byte, format STORED_VALUE_FORMAT) (storedValue StoredValue, err error, pos int) {
+	tags, ok := storedValueTags[format]
+	if !ok {
+		return StoredValue{}, fmt.Errorf("Unknown StoredValue format %s", format), 0
+	}
+
+	pos = STORED_VALUE_TYPE_POS
+	if err := checkLength(src, pos, STORED_VALUE_TYPE_LENGTH, "StoredValue type"); err != nil {
+		return StoredValue{}, err, pos
+	}
+	tag := int(src[STORED_VALUE_TYPE_POS])
+	if tag >= len(tags) {
+		return StoredValue{}, decodeErrorf(STORED_VALUE_TYPE_POS, "Unknown StoredValue type %d in %s format", tag, format), pos
+	}
+	pos += STORED_VALUE_TYPE_LENGTH
+
+	s = StoredValue{Type: tags[tag]}
+	var length int
+	switch s.Type {
+	case TYPE_CL_VALUE:
+		s.ClValue, err, length = clValueFromBytes(src[pos:], format)
+	case TYPE_ACCOUNT:
+		s.Account, err, length = accountFromBytes(src[pos:], format)
+	case TYPE_CONTRACT:
+		s.Contract, err, length = s.Contract.FromBytes(src[pos:])
+	case TYPE_CONTRACT_WASM:
+		s.ContractWasm, err, length = s.ContractWasm.FromBytes(src[pos:])
+	case TYPE_CONTRACT_V2:
+		s.ContractV2, err, length = s.ContractV2.FromBytes(src[pos:])
+	case TYPE_CONTRACT_PACKAGE:
+		s.ContractPackage, err, length = s.ContractPackage.FromBytes(src[pos:])
+	case TYPE_TRANSFER:
+		s.Transfer, err, length = s.Transfer.FromBytes(src[pos:])
+	case TYPE_DEPLOY_INFO:
+		s.DeployInfo, err, length = s.DeployInfo.FromBytes(src[pos:])
+	case TYPE_ERA_INFO:
+		s.EraInfo, err, length = s.EraInfo.FromBytes(src[pos:])
+	}
+	if err != nil {
+		return StoredValue{}, shiftDecodeError(err, pos), pos
+	}
+	pos += length
+
+	return s, nil, pos
+}
+
+// ToBytesWithFormat 은 StoredValue를 format 형식으로 serialize 하는 함수.
+//
+// format 에 없는 type 이거나 format 에서 표현할 수 없는 값이면 error 이다.
+func (s StoredValue) ToBytesWithFormat(format STORED_VALUE_FORMAT) ([]byte, error) {
+	tags, ok := storedValueTags[format]
+	if !ok {
+		return nil, fmt.Errorf("Unknown StoredValue format %s", format)
+	}
+	tag := -1
+	for i, storedValueType := range tags {
+		if storedValueType == s.Type {
+			tag = i
+		}
+	}
+	if tag < 0 {
+		return nil, fmt.Errorf("StoredValue type %d is not in %s format", s.Type, format)
+	}
+
+	var (
+		value []byte
+		err   error
+	)
+	switch s.Type {
+	case TYPE_CL_VALUE:
+		value, err = s.ClValue.toBytesWithFormat(format)
+	case TYPE_ACCOUNT:
+		value = s.Account.ToBytes()
+		err = checkKeysFormat(s.Account.NamedKeys, format)
+	case TYPE_CONTRACT:
+		value = s.Contract.ToBytes()
+	case TYPE_CONTRACT_WASM:
+		value = s.ContractWasm.ToBytes()
+	case TYPE_CONTRACT_V2:
+		value, err = s.ContractV2.ToBytes()
+	case TYPE_CONTRACT_PACKAGE:
+		value = s.ContractPackage.ToBytes()
+	case TYPE_TRANSFER:
+		value = s.Transfer.ToBytes()
+	case TYPE_DEPLOY_INFO:
+		value = s.DeployInfo.ToBytes()
+	case TYPE_ERA_INFO:
+		value = s.EraInfo.ToBytes()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{byte(tag)}, value...), nil
+}
+
+// keyFromBytes 는 format 형식으로 serialize 된 Key를 decode 하는 함수.
+func keyFromBytes(src []byte, format STORED_VALUE_FORMAT) (Key, error, int) {
+	if format == STORED_VALUE_FORMAT_V2 && len(src) > KEY_ID_POS && KEY_ID(src[KEY_ID_POS]) > KEY_ID_UREF {
+		return Key{}, decodeErrorf(KEY_ID_POS, "Key id %d is not supported in %s format", src[KEY_ID_POS], format), KEY_ID_POS
+	}
+
+	return Key{}.FromBytes(src)
+}
+
+// checkKeysFormat 은 named keys의 Key가 format 에서 같은 의미로 serialize 되는지 확인하는 함수.
+func checkKeysFormat(namedKeys NamedKeys, format STORED_VALUE_FORMAT) error {
+	if format != STORED_VALUE_FORMAT_V2 {
+		return nil
+	}
+	for _, namedKey := range namedKeys {
+		if namedKey.Key.KeyID() > KEY_ID_UREF {
+			return fmt.Errorf("Named key %s : %s key is not supported in %s format", namedKey.Name, namedKey.Key.KeyID(), format)
+		}
+	}
+
+	return nil
+}
+
+// clTypeFromBytes 는 format 형식으로 serialize 된 CLType을 decode 하는 함수.
+//
+// depth 는 src 가 놓인 중첩 깊이로, MAX_CL_TYPE_DEPTH 보다 깊으면 error를 반환한다.
+// V2 의 ByteArray(길이) 는 FixedList<U8, 길이> 로 decode 한다. 두 type의 값은 같은 bytes로 serialize 된다.
+func clTypeFromBytes(src []byte, format STORED_VALUE_FORMAT, depth int) (CLType, error, int) {
+	if err := checkLength(src, TAG_INDEX, TAG_LENGTH, "CLType"); err != nil {
+		return CLType{}, err, TAG_INDEX
+	}
+	if depth > MAX_CL_TYPE_DEPTH {
+		return CLType{}, decodeErrorf(TAG_INDEX, "CLType is nested deeper than %d", MAX_CL_TYPE_DEPTH), TAG_INDEX
+	}
+	clType := CLType{Tag: CL_TYPE_TAG(src[TAG_INDEX])}
+	pos := TAG_LENGTH
+
+	if format == STORED_VALUE_FORMAT_V2 && clType.Tag == TAG_FIXED_LIST {
+		length, pos, err := uint32FromBytes(src, pos, "ByteArray length")
+		if err != nil {
+			return CLType{}, err, pos
+		}
+		return NewFixedListCLType(NewSimpleCLType(TAG_U8), length), nil, pos
+	}
+
+	count := clType.innerCount()
+	if count < 0 {
+		if format == STORED_VALUE_FORMAT_V2 {
+			return CLType{}, decodeErrorf(TAG_INDEX, "CLType tag %d is not supported in %s format", clType.Tag, format), pos
+		}
+		return CLType{}, decodeErrorf(TAG_INDEX, "Unknown CLType tag %d", clType.Tag), pos
+	}
+	for i := 0; i < count; i++ {
+		inner, err, length := clTypeFromBytes(src[pos:], format, depth+1)
+		if err != nil {
+			return CLType{}, shiftDecodeError(err, pos), pos
+		}
+		pos += length
+
+		clType.Inner = append(clType.Inner, inner)
+	}
+
+	if clType.Tag == TAG_FIXED_LIST {
+		length, next, err := uint32FromBytes(src, pos, "FixedList length")
+		if err != nil {
+			return CLType{}, err, next
+		}
+		clType.Length = length
+		pos = next
+	}
+
+	return clType, nil, pos
+}
+
+// toBytesWithFormat 은 CLType을 format 형식으로 serialize 하는 함수.
+func (c CLType) toBytesWithFormat(format STORED_VALUE_FORMAT) ([]byte, error) {
+	if format != STORED_VALUE_FORMAT_V2 {
+		return c.ToBytes(), nil
+	}
+
+	if c.Tag == TAG_FIXED_LIST {
+		if len(c.Inner) != 1 || c.Inner[0].Tag != TAG_U8 {
+			return nil, fmt.Errorf("%s is not supported in %s format", c, format)
+		}
+		return append([]byte{byte(c.Tag)}, uint32ToBytes(c.Length)...), nil
+	}
+
+	res := []byte{byte(c.Tag)}
+	for _, inner := range c.Inner {
+		innerBytes, err := inner.toBytesWithFormat(format)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, innerBytes...)
+	}
+
+	return res, nil
+}
+
+// clValueFromBytes 는 format 형식으로 serialize 된 CLValue를 decode 하는 함수.
+func clValueFromBytes(src []byte, format STORED_VALUE_FORMAT) (CLValue, error, int) {
+	value, pos, err := sizedBytesFromBytes(src, 0, "CLValue")
+	if err != nil {
+		return CLValue{}, err, pos
+	}
+
+	clType, err, length := clTypeFromBytes(src[pos:], format, 0)
+	if err != nil {
+		return CLValue{}, shiftDecodeError(err, pos), pos
+	}
+	if err := checkCLValueKeysFormat(clType, value, format); err != nil {
+		return CLValue{}, shiftDecodeError(err, SIZE_LENGTH), SIZE_LENGTH
+	}
+	pos += length
+
+	return NewClValue(value, clType), nil, pos
+}
+
+// toBytesWithFormat 은 CLValue를 format 형식으로 serialize 하는 함수.
+func (c CLValue) toBytesWithFormat(format STORED_VALUE_FORMAT) ([]byte, error) {
+	clType, err := c.Type.toBytesWithFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCLValueKeysFormat(c.Type, c.Bytes, format); err != nil {
+		return nil, err
+	}
+
+	return append(sizedBytesToBytes(c.Bytes), clType...), nil
+}
+
+// checkCLValueKeysFormat 은 CL value 안의 Key 가 format 에서 같은 의미로 serialize 되는지 확인하는 함수.
+//
+// V2 의 key id 3 이상은 V1 의 Local key 와 같은 모양이므로, decode 후 Local key 로 잘못 해석되지 않도록 거부한다.
+// Any type 뒤의 값은 위치를 알 수 없으므로 Key 를 포함하는 type 에 Any 가 있으면 error 이다.
+func checkCLValueKeysFormat(clType CLType, src []byte, format STORED_VALUE_FORMAT) error {
+	if format != STORED_VALUE_FORMAT_V2 || !clTypeHasTag(clType, TAG_KEY) {
+		return nil
+	}
+
+	_, _, err := decodeCLValue(clType, src, format)
+	return err
+}
+
+func clTypeHasTag(clType CLType, tag CL_TYPE_TAG) bool {
+	if clType.Tag == tag {
+		return true
+	}
+	for _, inner := range clType.Inner {
+		if clTypeHasTag(inner, tag) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,446 @@
+package storedvalue
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocolVersionCompare(t *testing.T) {
+	assert.Equal(t, -1, NewProtocolVersion(1, 2, 3).Compare(NewProtocolVersion(1, 3, 0)))
+	assert.Equal(t, 1, NewProtocolVersion(2, 0, 0).Compare(NewProtocolVersion(1, 9, 9)))
+	assert.Equal(t, 0, NewProtocolVersion(1, 0, 0).Compare(NewProtocolVersion(1, 0, 0)))
+}
+
+func TestFormatForProtocolVersion(t *testing.T) {
+	table := StoredValueFormatTable{
+		{From: NewProtocolVersion(2, 0, 0), Format: STORED_VALUE_FORMAT_V2},
+		{From: NewProtocolVersion(1, 0, 0), Format: STORED_VALUE_FORMAT_V1},
+	}
+
+	for _, testCase := range []struct {
+		protocolVersion ProtocolVersion
+		format          STORED_VALUE_FORMAT
+	}{
+		{NewProtocolVersion(1, 0, 0), STORED_VALUE_FORMAT_V1},
+		{NewProtocolVersion(1, 9, 9), STORED_VALUE_FORMAT_V1},
+		{NewProtocolVersion(2, 0, 0), STORED_VALUE_FORMAT_V2},
+		{NewProtocolVersion(3, 1, 0), STORED_VALUE_FORMAT_V2},
+	} {
+		format, err := FormatForProtocolVersion(testCase.protocolVersion, table)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.format, format, testCase.protocolVersion.String())
+	}
+
+	format, err := FormatForProtocolVersion(NewProtocolVersion(5, 0, 0), DefaultStoredValueFormatTable())
+	assert.NoError(t, err)
+	assert.Equal(t, STORED_VALUE_FORMAT_V1, format)
+
+	_, err = FormatForProtocolVersion(NewProtocolVersion(0, 9, 0), table)
+	assert.Error(t, err)
+	_, err = FormatForProtocolVersion(NewProtocolVersion(1, 0, 0), nil)
+	assert.Error(t, err)
+	_, err = FormatForProtocolVersion(NewProtocolVersion(1, 0, 0), StoredValueFormatTable{{Format: STORED_VALUE_FORMAT(9)}})
+	assert.Error(t, err)
+}
+
+func formatTestHex(t *testing.T, strs ...string) []byte {
+	src, err := hex.DecodeString(strings.Join(strs, ""))
+	assert.NoError(t, err)
+	return src
+}
+
+func TestStoredValueV2FromBytes(t *testing.T) {
+	hash1 := strings.Repeat("11", ADDRESS_LENGTH)
+	hash2 := strings.Repeat("22", ADDRESS_LENGTH)
+	uref := strings.Repeat("33", ADDRESS_LENGTH)
+
+	// V1 의 tag 2 는 Contract, V2 의 tag 2 는 ContractWasm 이다.
+	src := formatTestHex(t, "02", "03000000", "010203")
+	storedValue, err, pos := StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+	assert.NoError(t, err)
+	assert.Equal(t, len(src), pos)
+	assert.Equal(t, StoredValue{Type: TYPE_CONTRACT_WASM, ContractWasm: NewContractWasm([]byte{1, 2, 3})}, storedValue)
+	_, err, _ = StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V1)
+	assert.Error(t, err)
+
+	// ByteArray(32) 는 FixedList<U8, 32> 로 decode 한다.
+	src = formatTestHex(t, "00", "20000000", hash1, "0f", "20000000")
+	storedValue, err, _ = StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+	assert.NoError(t, err)
+	assert.Equal(t, NewFixedListCLType(NewSimpleCLType(TAG_U8), 32), storedValue.ClValue.Type)
+
+	values := []struct {
+		hex      string
+		expected StoredValue
+	}{
+		{
+			// ContractV2: entry point "call" () -> Unit, public, contract
+			hex: "03" + hash1 + hash2 + "01000000" + "01000000" + "61" + "02" + uref + "07" +
+				"01000000" + "0400000063616c6c" + "0400000063616c6c" + "00000000" + "09" + "01" + "01" +
+				"010000000000000000000000",
+			expected: StoredValue{Type: TYPE_CONTRACT_V2, ContractV2: ContractV2{
+				ContractPackageHash: formatTestHex(t, hash1),
+				ContractWasmHash:    formatTestHex(t, hash2),
+				NamedKeys:           NamedKeys{NewNamedKey("a", mustNewKey(NewURefKey(NewURef(formatTestHex(t, uref), 7))))},
+				EntryPoints: []EntryPoint{{
+					Name:   "call",
+					Args:   []Parameter{},
+					Ret:    NewSimpleCLType(TAG_UNIT),
+					Access: EntryPointAccess{Public: true},
+					Type:   ENTRY_POINT_TYPE_CONTRACT}},
+				ProtocolVersion: NewProtocolVersion(1, 0, 0)}},
+		},
+		{
+			// ContractV2: entry point "f" (x: U64) -> U512, group "g", session
+			hex: "03" + hash1 + hash2 + "00000000" +
+				"01000000" + "0100000066" + "0100000066" + "01000000" + "0100000078" + "05" + "08" +
+				"02" + "01000000" + "0100000067" + "00" +
+				"010000000000000000000000",
+			expected: StoredValue{Type: TYPE_CONTRACT_V2, ContractV2: ContractV2{
+				ContractPackageHash: formatTestHex(t, hash1),
+				ContractWasmHash:    formatTestHex(t, hash2),
+				NamedKeys:           NamedKeys{},
+				EntryPoints: []EntryPoint{{
+					Name:   "f",
+					Args:   []Parameter{{Name: "x", CLType: NewSimpleCLType(TAG_U64)}},
+					Ret:    NewSimpleCLType(TAG_U512),
+					Access: EntryPointAccess{Groups: []string{"g"}},
+					Type:   ENTRY_POINT_TYPE_SESSION}},
+				ProtocolVersion: NewProtocolVersion(1, 0, 0)}},
+		},
+		{
+			// ContractPackage: version 1.1 -> hash1, disabled 1.2, group "g" [uref]
+			hex: "04" + uref + "07" + "01000000" + "0100000001000000" + hash1 + "01000000" + "0100000002000000" +
+				"01000000" + "0100000067" + "01000000" + uref + "01",
+			expected: StoredValue{Type: TYPE_CONTRACT_PACKAGE, ContractPackage: ContractPackage{
+				AccessKey:        NewURef(formatTestHex(t, uref), 7),
+				Versions:         []ContractVersion{{Key: ContractVersionKey{1, 1}, ContractHash: formatTestHex(t, hash1)}},
+				DisabledVersions: []ContractVersionKey{{1, 2}},
+				Groups:           []ContractGroup{{Name: "g", URefs: []URef{NewURef(formatTestHex(t, uref), 1)}}}}},
+		},
+		{
+			// Transfer: to 없음, amount 1000, gas 0, id 5
+			hex: "05" + hash1 + hash2 + "00" + uref + "07" + uref + "01" + "02e803" + "00" + "010500000000000000",
+			expected: StoredValue{Type: TYPE_TRANSFER, Transfer: Transfer{
+				DeployHash: formatTestHex(t, hash1),
+				From:       formatTestHex(t, hash2),
+				Source:     NewURef(formatTestHex(t, uref), 7),
+				Target:     NewURef(formatTestHex(t, uref), 1),
+				Amount:     big.NewInt(1000),
+				Gas:        new(big.Int),
+				ID:         func() *uint64 { id := uint64(5); return &id }()}},
+		},
+		{
+			hex: "06" + hash1 + "01000000" + hash2 + hash1 + uref + "07" + "0105",
+			expected: StoredValue{Type: TYPE_DEPLOY_INFO, DeployInfo: DeployInfo{
+				DeployHash: formatTestHex(t, hash1),
+				Transfers:  [][]byte{formatTestHex(t, hash2)},
+				From:       formatTestHex(t, hash1),
+				Source:     NewURef(formatTestHex(t, uref), 7),
+				Gas:        big.NewInt(5)}},
+		},
+		{
+			hex: "07" + "02000000" + "00" + "01" + hash1 + "0107" + "01" + "01" + hash2 + "01" + hash1 + "00",
+			expected: StoredValue{Type: TYPE_ERA_INFO, EraInfo: EraInfo{SeigniorageAllocations: []SeigniorageAllocation{
+				{Validator: PublicKey{PUBLIC_KEY_ED25519, formatTestHex(t, hash1)}, Amount: big.NewInt(7)},
+				{
+					Delegator: &PublicKey{PUBLIC_KEY_ED25519, formatTestHex(t, hash2)},
+					Validator: PublicKey{PUBLIC_KEY_ED25519, formatTestHex(t, hash1)},
+					Amount:    new(big.Int)},
+			}}},
+		},
+	}
+
+	for _, v := range values {
+		src := formatTestHex(t, v.hex)
+		storedValue, err, pos := StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+		if !assert.NoError(t, err, v.hex) {
+			continue
+		}
+		assert.Equal(t, len(src), pos)
+		assert.Equal(t, v.expected, storedValue)
+
+		res, err := storedValue.ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+		assert.NoError(t, err)
+		assert.Equal(t, src, res)
+
+		for length := 0; length < len(src); length++ {
+			_, err, _ := StoredValue{}.FromBytesWithFormat(src[:length], STORED_VALUE_FORMAT_V2)
+			_, ok := err.(*DecodeError)
+			assert.True(t, ok, "%s truncated to %d : %v", v.hex, length, err)
+		}
+	}
+}
+
+func TestStoredValueFormatError(t *testing.T) {
+	hash := strings.Repeat("11", ADDRESS_LENGTH)
+
+	// V2 의 key id 3 은 Transfer key 이며 지원하지 않는다.
+	src := formatTestHex(t, "01", hash, "01000000", "0100000061", "03", hash)
+	_, err, _ := StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+	decodeErr, ok := err.(*DecodeError)
+	assert.True(t, ok, "%v", err)
+	if ok {
+		assert.Equal(t, 1+ADDRESS_LENGTH+SIZE_LENGTH+SIZE_LENGTH+1, decodeErr.Offset)
+	}
+
+	// CLValue 안의 Key 도 같은 제한을 받는다. Option<Key> 의 key id 3 은 Some tag 다음 위치의 error 이다.
+	src = formatTestHex(t, "00", "22000000", "01", "03", hash, "0d", "0b")
+	_, err, _ = StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+	decodeErr, ok = err.(*DecodeError)
+	assert.True(t, ok, "%v", err)
+	if ok {
+		assert.Equal(t, 1+SIZE_LENGTH+1, decodeErr.Offset)
+	}
+	storedValue, err, _ := StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V1)
+	assert.NoError(t, err)
+	_, err = storedValue.ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+	assert.Error(t, err)
+	_, err, _ = StoredValue{}.FromBytesWithFormat(formatTestHex(t, "00", "22000000", "02", hash, "07", "0b"), STORED_VALUE_FORMAT_V2)
+	assert.NoError(t, err)
+
+	// EntryPointAccess 의 tag 0 은 없다.
+	src = formatTestHex(t, "03", hash, hash, "00000000", "01000000", "0100000066", "0100000066", "00000000", "09", "00", "01")
+	_, err, _ = StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+	decodeErr, ok = err.(*DecodeError)
+	assert.True(t, ok, "%v", err)
+	if ok {
+		assert.Equal(t, len(src)-2, decodeErr.Offset)
+	}
+
+	// V2 의 CLType 22 는 PublicKey 이며 지원하지 않는다.
+	_, err, _ = StoredValue{}.FromBytesWithFormat(formatTestHex(t, "00", "00000000", "16"), STORED_VALUE_FORMAT_V2)
+	assert.Error(t, err)
+	_, err, _ = StoredValue{}.FromBytesWithFormat(formatTestHex(t, "08"), STORED_VALUE_FORMAT_V2)
+	assert.Error(t, err)
+	_, err, _ = StoredValue{}.FromBytesWithFormat(formatTestHex(t, "00"), STORED_VALUE_FORMAT(9))
+	assert.Error(t, err)
+
+	// V1 에 없는 type 이거나 V2 에서 표현할 수 없는 값은 serialize 할 수 없다.
+	_, err = StoredValue{Type: TYPE_TRANSFER}.ToBytesWithFormat(STORED_VALUE_FORMAT_V1)
+	assert.Error(t, err)
+	_, err = StoredValue{Type: TYPE_TRANSFER}.ToBytes()
+	assert.Error(t, err)
+	_, err = StoredValue{Type: TYPE_CONTRACT}.ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+	assert.Error(t, err)
+	localKey := mustNewKey(NewLocalKey(formatTestHex(t, hash)))
+	_, err = NewStoredValueFromAccount(NewAccount(nil, NamedKeys{NewNamedKey("a", localKey)}, URef{}, nil, ActionThresholds{})).
+		ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+	assert.Error(t, err)
+	_, err = NewStoredValueFromClValue(NewClValue(nil, NewFixedListCLType(NewSimpleCLType(TAG_STRING), 0))).
+		ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+	assert.Error(t, err)
+}
+
+func genV2Key(r *rand.Rand) Key {
+	for {
+		if key := genKey(r); key.KeyID() != KEY_ID_LOCAL {
+			return key
+		}
+	}
+}
+
+// genV2CLType 은 FixedList 를 ByteArray 로 표현할 수 있는 FixedList<U8, n> 으로 바꾼 CLType 이다.
+func genV2CLType(r *rand.Rand, depth int) CLType {
+	clType := genCLType(r, depth)
+	return toV2CLType(clType)
+}
+
+func toV2CLType(clType CLType) CLType {
+	if clType.Tag == TAG_FIXED_LIST {
+		return NewFixedListCLType(NewSimpleCLType(TAG_U8), clType.Length)
+	}
+	for i, inner := range clType.Inner {
+		clType.Inner[i] = toV2CLType(inner)
+	}
+	return clType
+}
+
+func genBigInt(r *rand.Rand) *big.Int {
+	value := fromByteToBigInt(genBigIntBytes(r, U512_MAX_LENGTH))
+	if value.Sign() == 0 {
+		return new(big.Int)
+	}
+	return value
+}
+
+func genPublicKey(r *rand.Rand) PublicKey {
+	algorithm := PUBLIC_KEY_ALGORITHM(r.Intn(3))
+	return PublicKey{Algorithm: algorithm, Bytes: genBytes(r, publicKeyLengths[algorithm])}
+}
+
+func genV2StoredValue(r *rand.Rand) StoredValue {
+	switch r.Intn(8) {
+	case 0:
+		// V2 에서 표현할 수 없는 Local key 를 포함하지 않을 때까지 다시 생성한다.
+		for {
+			clType := genV2CLType(r, 3)
+			value := genCLValueBytes(r, clType)
+			if checkCLValueKeysFormat(clType, value, STORED_VALUE_FORMAT_V2) == nil {
+				return NewStoredValueFromClValue(NewClValue(value, clType))
+			}
+		}
+	case 1:
+		account := genAccount(r)
+		for i := range account.NamedKeys {
+			account.NamedKeys[i].Key = genV2Key(r)
+		}
+		return NewStoredValueFromAccount(account)
+	case 2:
+		return StoredValue{Type: TYPE_CONTRACT_WASM, ContractWasm: NewContractWasm(genBytes(r, r.Intn(64)))}
+	case 3:
+		contract := ContractV2{
+			ContractPackageHash: genBytes(r, ADDRESS_LENGTH),
+			ContractWasmHash:    genBytes(r, ADDRESS_LENGTH),
+			NamedKeys:           NamedKeys{},
+			EntryPoints:         []EntryPoint{},
+			ProtocolVersion:     NewProtocolVersion(r.Uint32(), r.Uint32(), r.Uint32())}
+		for i := r.Intn(3); i > 0; i-- {
+			contract.NamedKeys = append(contract.NamedKeys, NewNamedKey(genString(r, 12), genV2Key(r)))
+		}
+		contract.NamedKeys = contract.NamedKeys.Sorted()
+		for i := r.Intn(3); i > 0; i-- {
+			entryPoint := EntryPoint{
+				Name:   genString(r, 8),
+				Args:   []Parameter{},
+				Ret:    genV2CLType(r, 2),
+				Access: EntryPointAccess{Public: true},
+				Type:   ENTRY_POINT_TYPE(r.Intn(2))}
+			for j := r.Intn(3); j > 0; j-- {
+				entryPoint.Args = append(entryPoint.Args, Parameter{Name: genString(r, 8), CLType: genV2CLType(r, 2)})
+			}
+			if r.Intn(2) == 0 {
+				entryPoint.Access = EntryPointAccess{Groups: []string{}}
+				for j := r.Intn(3); j > 0; j-- {
+					entryPoint.Access.Groups = append(entryPoint.Access.Groups, genString(r, 8))
+				}
+			}
+			contract.EntryPoints = append(contract.EntryPoints, entryPoint)
+		}
+		return StoredValue{Type: TYPE_CONTRACT_V2, ContractV2: contract}
+	case 4:
+		contractPackage := ContractPackage{
+			AccessKey:        genURef(r),
+			Versions:         []ContractVersion{},
+			DisabledVersions: []ContractVersionKey{},
+			Groups:           []ContractGroup{}}
+		for i := r.Intn(3); i > 0; i-- {
+			contractPackage.Versions = append(contractPackage.Versions, ContractVersion{
+				Key:          ContractVersionKey{r.Uint32(), r.Uint32()},
+				ContractHash: genBytes(r, ADDRESS_LENGTH)})
+		}
+		for i := r.Intn(3); i > 0; i-- {
+			contractPackage.DisabledVersions = append(contractPackage.DisabledVersions, ContractVersionKey{r.Uint32(), r.Uint32()})
+		}
+		for i := r.Intn(3); i > 0; i-- {
+			group := ContractGroup{Name: genString(r, 8), URefs: []URef{}}
+			for j := r.Intn(3); j > 0; j-- {
+				group.URefs = append(group.URefs, genURef(r))
+			}
+			contractPackage.Groups = append(contractPackage.Groups, group)
+		}
+		return StoredValue{Type: TYPE_CONTRACT_PACKAGE, ContractPackage: contractPackage}
+	case 5:
+		transfer := Transfer{
+			DeployHash: genBytes(r, ADDRESS_LENGTH),
+			From:       genBytes(r, ADDRESS_LENGTH),
+			Source:     genURef(r),
+			Target:     genURef(r),
+			Amount:     genBigInt(r),
+			Gas:        genBigInt(r)}
+		if r.Intn(2) == 0 {
+			transfer.To = genBytes(r, ADDRESS_LENGTH)
+		}
+		if r.Intn(2) == 0 {
+			id := r.Uint64()
+			transfer.ID = &id
+		}
+		return StoredValue{Type: TYPE_TRANSFER, Transfer: transfer}
+	case 6:
+		deployInfo := DeployInfo{
+			DeployHash: genBytes(r, ADDRESS_LENGTH),
+			Transfers:  [][]byte{},
+			From:       genBytes(r, ADDRESS_LENGTH),
+			Source:     genURef(r),
+			Gas:        genBigInt(r)}
+		for i := r.Intn(3); i > 0; i-- {
+			deployInfo.Transfers = append(deployInfo.Transfers, genBytes(r, ADDRESS_LENGTH))
+		}
+		return StoredValue{Type: TYPE_DEPLOY_INFO, DeployInfo: deployInfo}
+	default:
+		eraInfo := EraInfo{SeigniorageAllocations: []SeigniorageAllocation{}}
+		for i := r.Intn(3); i > 0; i-- {
+			allocation := SeigniorageAllocation{Validator: genPublicKey(r), Amount: genBigInt(r)}
+			if r.Intn(2) == 0 {
+				delegator := genPublicKey(r)
+				allocation.Delegator = &delegator
+			}
+			eraInfo.SeigniorageAllocations = append(eraInfo.SeigniorageAllocations, allocation)
+		}
+		return StoredValue{Type: TYPE_ERA_INFO, EraInfo: eraInfo}
+	}
+}
+
+func TestStoredValueV2RoundTrip(t *testing.T) {
+	roundTripCheck(t,
+		func(r *rand.Rand) interface{} { return genV2StoredValue(r) },
+		func(value interface{}) []byte {
+			res, err := value.(StoredValue).ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+			assert.NoError(t, err)
+			return res
+		},
+		func(src []byte) (interface{}, error, int) {
+			return StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+		})
+}
+
+func TestStoredValueV2JSONRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	for i := 0; i < roundTripMaxCount; i++ {
+		storedValue := genV2StoredValue(r)
+
+		res, err := json.Marshal(storedValue)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		var decoded StoredValue
+		assert.NoError(t, json.Unmarshal(res, &decoded), string(res))
+		assert.Equal(t, storedValue, decoded, string(res))
+	}
+}
+
+func TestTransferJSON(t *testing.T) {
+	hash := strings.Repeat("11", ADDRESS_LENGTH)
+	transfer := Transfer{
+		DeployHash: formatTestHex(t, hash),
+		From:       formatTestHex(t, hash),
+		Source:     NewURef(formatTestHex(t, hash), 7),
+		Target:     NewURef(formatTestHex(t, hash), 1),
+		Amount:     big.NewInt(1000),
+		Gas:        new(big.Int)}
+
+	res, err := json.Marshal(StoredValue{Type: TYPE_TRANSFER, Transfer: transfer})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"transfer": {
+		"deploy_hash": "`+hash+`",
+		"from": "`+hash+`",
+		"to": null,
+		"source": {"address": "`+hash+`", "access_rights": "READ_ADD_WRITE"},
+		"target": {"address": "`+hash+`", "access_rights": "READ"},
+		"amount": "1000",
+		"gas": "0",
+		"id": null
+	}}`, string(res))
+
+	var publicKey PublicKey
+	assert.NoError(t, json.Unmarshal([]byte(`"01`+hash+`"`), &publicKey))
+	assert.Equal(t, PublicKey{PUBLIC_KEY_ED25519, formatTestHex(t, hash)}, publicKey)
+	assert.Error(t, json.Unmarshal([]byte(`"01`+hash+`00"`), &publicKey))
+	assert.Error(t, json.Unmarshal([]byte(`"03"`), &publicKey))
+}
@@ -0,0 +1,190 @@
+//go:build go1.18
+// +build go1.18
+
+package storedvalue
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// 각 decoder는 어떤 입력에도 panic 하지 않아야 하며,
+// 성공한 경우 src 범위 안의 pos를 return 해야 한다.
+
+func addDecodeSeeds(f *testing.F) {
+	for _, src := range decodeTestVectors(f) {
+		f.Add(src)
+		f.Add(src[1:])
+	}
+	f.Add([]byte{})
+}
+
+// addV2DecodeSeeds 는 V2 형식의 StoredValue 와 type byte 를 뺀 각 type 의 bytes 를 seed 로 추가하는 함수.
+func addV2DecodeSeeds(f *testing.F) {
+	r := rand.New(rand.NewSource(47))
+	for i := 0; i < 64; i++ {
+		src, err := genV2StoredValue(r).ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(src)
+		f.Add(src[1:])
+	}
+	f.Add([]byte{})
+}
+
+func checkDecodePos(t *testing.T, src []byte, err error, pos int) {
+	if err == nil && (pos < 0 || pos > len(src)) {
+		t.Fatalf("pos %d out of range %d", pos, len(src))
+	}
+	if err != nil {
+		if _, ok := err.(*DecodeError); !ok {
+			t.Fatalf("error is not a DecodeError : %T %s", err, err)
+		}
+	}
+}
+
+func FuzzStoredValueFromBytes(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		var storedValue StoredValue
+		_, err, pos := storedValue.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzAccountFromBytes(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		var account Account
+		_, err, pos := account.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzContractFromBytes(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		var contract Contract
+		_, err, pos := contract.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzKeyFromBytes(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		var key Key
+		_, err, pos := key.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzCLValueFromBytes(f *testing.F) {
+	addDecodeSeeds(f)
+	// 빈 값 뒤에 MAX_CL_TYPE_DEPTH 보다 깊게 중첩된 Option type
+	f.Add(append(append([]byte{0, 0, 0, 0}, bytes.Repeat([]byte{byte(TAG_OPTION)}, 1<<12)...), byte(TAG_UNIT)))
+	f.Fuzz(func(t *testing.T, src []byte) {
+		var clValue CLValue
+		clValue, err, pos := clValue.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+		if err != nil {
+			return
+		}
+
+		if res := clValue.ToBytes(); !bytes.Equal(res, src[:pos]) {
+			t.Fatalf("ToBytes %x, but %x", res, src[:pos])
+		}
+		// 값이 type과 맞지 않으면 error를 return 해야 한다.
+		_, _ = clValue.ToCLInstanceValue()
+		_, _ = clValue.ToStateValues()
+	})
+}
+
+func FuzzCLValueInstanceFromBytes(f *testing.F) {
+	addDecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		instance, err, pos := CLValueInstanceFromBytes(src)
+		checkDecodePos(t, src, err, pos)
+		if err != nil {
+			return
+		}
+
+		res, err := CLValueInstanceToBytes(instance)
+		if err != nil {
+			t.Fatalf("CLValueInstanceToBytes : %s", err)
+		}
+		if !bytes.Equal(res, src[:pos]) {
+			t.Fatalf("CLValueInstanceToBytes %x, but %x", res, src[:pos])
+		}
+	})
+}
+
+func FuzzStoredValueV2FromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		storedValue, err, pos := StoredValue{}.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V2)
+		checkDecodePos(t, src, err, pos)
+		if err != nil {
+			return
+		}
+
+		res, err := storedValue.ToBytesWithFormat(STORED_VALUE_FORMAT_V2)
+		if err != nil {
+			t.Fatalf("ToBytesWithFormat : %s", err)
+		}
+		if !bytes.Equal(res, src[:pos]) {
+			t.Fatalf("ToBytesWithFormat %x, but %x", res, src[:pos])
+		}
+	})
+}
+
+// FuzzCLValueV2FromBytes 는 V2 CLValue 안의 Key 검사를 포함한다.
+func FuzzCLValueV2FromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, err, pos := clValueFromBytes(src, STORED_VALUE_FORMAT_V2)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzContractV2FromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, err, pos := ContractV2{}.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzContractPackageFromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, err, pos := ContractPackage{}.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzTransferFromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, err, pos := Transfer{}.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzDeployInfoFromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, err, pos := DeployInfo{}.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
+
+func FuzzEraInfoFromBytes(f *testing.F) {
+	addV2DecodeSeeds(f)
+	f.Fuzz(func(t *testing.T, src []byte) {
+		_, err, pos := EraInfo{}.FromBytes(src)
+		checkDecodePos(t, src, err, pos)
+	})
+}
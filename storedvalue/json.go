@@ -0,0 +1,915 @@
+package storedvalue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+)
+
+// JSON 형식
+//
+// address, hash, body 등 byte 값은 hex 문자열, URef access rights는 "READ_ADD_WRITE" 와 같은 이름으로 표현한다.
+// U512 등 큰 정수는 "1000" 과 같은 10진수 문자열, PublicKey 는 algorithm tag 를 포함한 hex 문자열로 표현한다.
+// StoredValue, Key 는 값이 있는 variant 하나만 가지는 object로 표현한다.
+//
+//	{"cl_value": {"cl_type": "U512", "value": "1000", "bytes": "0203e8"}}
+//	{"account": {"public_key": "...", "named_keys": [...], "main_purse": {...}, ...}}
+//	{"contract": {"body": "...", "named_keys": [...], "protocol_version": {...}}}
+//	{"transfer": {"deploy_hash": "...", ..., "amount": "1000", "gas": "0", "id": null}}
+//	{"uref": {"address": "...", "access_rights": "READ_ADD_WRITE"}}
+
+// hexBytes 는 JSON에서 hex 문자열로 표현되는 bytes.
+type hexBytes []byte
+
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(h))
+}
+
+func (h *hexBytes) UnmarshalJSON(src []byte) error {
+	var str string
+	if err := json.Unmarshal(src, &str); err != nil {
+		return err
+	}
+	res, err := hex.DecodeString(str)
+	if err != nil {
+		return err
+	}
+
+	*h = res
+	return nil
+}
+
+type storedValueJSON struct {
+	ClValue         *CLValue         `json:"cl_value,omitempty"`
+	Account         *Account         `json:"account,omitempty"`
+	Contract        *Contract        `json:"contract,omitempty"`
+	ContractWasm    *ContractWasm    `json:"contract_wasm,omitempty"`
+	ContractV2      *ContractV2      `json:"contract_v2,omitempty"`
+	ContractPackage *ContractPackage `json:"contract_package,omitempty"`
+	Transfer        *Transfer        `json:"transfer,omitempty"`
+	DeployInfo      *DeployInfo      `json:"deploy_info,omitempty"`
+	EraInfo         *EraInfo         `json:"era_info,omitempty"`
+}
+
+func (s StoredValue) MarshalJSON() ([]byte, error) {
+	switch s.Type {
+	case TYPE_CL_VALUE:
+		return json.Marshal(storedValueJSON{ClValue: &s.ClValue})
+	case TYPE_ACCOUNT:
+		return json.Marshal(storedValueJSON{Account: &s.Account})
+	case TYPE_CONTRACT:
+		return json.Marshal(storedValueJSON{Contract: &s.Contract})
+	case TYPE_CONTRACT_WASM:
+		return json.Marshal(storedValueJSON{ContractWasm: &s.ContractWasm})
+	case TYPE_CONTRACT_V2:
+		return json.Marshal(storedValueJSON{ContractV2: &s.ContractV2})
+	case TYPE_CONTRACT_PACKAGE:
+		return json.Marshal(storedValueJSON{ContractPackage: &s.ContractPackage})
+	case TYPE_TRANSFER:
+		return json.Marshal(storedValueJSON{Transfer: &s.Transfer})
+	case TYPE_DEPLOY_INFO:
+		return json.Marshal(storedValueJSON{DeployInfo: &s.DeployInfo})
+	case TYPE_ERA_INFO:
+		return json.Marshal(storedValueJSON{EraInfo: &s.EraInfo})
+	default:
+		return nil, fmt.Errorf("Unknown StoredValue type %d", s.Type)
+	}
+}
+
+func (s *StoredValue) UnmarshalJSON(src []byte) error {
+	var v storedValueJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	var res []StoredValue
+	if v.ClValue != nil {
+		res = append(res, NewStoredValueFromClValue(*v.ClValue))
+	}
+	if v.Account != nil {
+		res = append(res, NewStoredValueFromAccount(*v.Account))
+	}
+	if v.Contract != nil {
+		res = append(res, NewStoredValueFromContract(*v.Contract))
+	}
+	if v.ContractWasm != nil {
+		res = append(res, StoredValue{Type: TYPE_CONTRACT_WASM, ContractWasm: *v.ContractWasm})
+	}
+	if v.ContractV2 != nil {
+		res = append(res, StoredValue{Type: TYPE_CONTRACT_V2, ContractV2: *v.ContractV2})
+	}
+	if v.ContractPackage != nil {
+		res = append(res, StoredValue{Type: TYPE_CONTRACT_PACKAGE, ContractPackage: *v.ContractPackage})
+	}
+	if v.Transfer != nil {
+		res = append(res, StoredValue{Type: TYPE_TRANSFER, Transfer: *v.Transfer})
+	}
+	if v.DeployInfo != nil {
+		res = append(res, StoredValue{Type: TYPE_DEPLOY_INFO, DeployInfo: *v.DeployInfo})
+	}
+	if v.EraInfo != nil {
+		res = append(res, StoredValue{Type: TYPE_ERA_INFO, EraInfo: *v.EraInfo})
+	}
+	if len(res) != 1 {
+		return fmt.Errorf("StoredValue JSON must have one of cl_value, account, contract, contract_wasm, " +
+			"contract_v2, contract_package, transfer, deploy_info and era_info")
+	}
+
+	*s = res[0]
+	return nil
+}
+
+type accountJSON struct {
+	PublicKey        hexBytes         `json:"public_key"`
+	NamedKeys        NamedKeys        `json:"named_keys"`
+	MainPurse        URef             `json:"main_purse"`
+	AssociatedKeys   []AssociatedKey  `json:"associated_keys"`
+	ActionThresholds ActionThresholds `json:"action_thresholds"`
+}
+
+func (a Account) MarshalJSON() ([]byte, error) {
+	v := accountJSON{
+		PublicKey:        a.PublicKey,
+		NamedKeys:        a.NamedKeys,
+		MainPurse:        a.MainPurse,
+		AssociatedKeys:   a.AssociatedKeys,
+		ActionThresholds: a.ActionThresholds}
+	if v.NamedKeys == nil {
+		v.NamedKeys = NamedKeys{}
+	}
+	if v.AssociatedKeys == nil {
+		v.AssociatedKeys = []AssociatedKey{}
+	}
+
+	return json.Marshal(v)
+}
+
+func (a *Account) UnmarshalJSON(src []byte) error {
+	var v accountJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*a = NewAccount(v.PublicKey, v.NamedKeys, v.MainPurse, v.AssociatedKeys, v.ActionThresholds)
+	return nil
+}
+
+type associatedKeyJSON struct {
+	PublicKey hexBytes `json:"public_key"`
+	Weight    uint32   `json:"weight"`
+}
+
+func (a AssociatedKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(associatedKeyJSON{PublicKey: a.PublicKey, Weight: a.Weight})
+}
+
+func (a *AssociatedKey) UnmarshalJSON(src []byte) error {
+	var v associatedKeyJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*a = NewAssociatedKey(v.PublicKey, v.Weight)
+	return nil
+}
+
+type contractJSON struct {
+	Body            hexBytes        `json:"body"`
+	NamedKeys       NamedKeys       `json:"named_keys"`
+	ProtocolVersion ProtocolVersion `json:"protocol_version"`
+}
+
+func (c Contract) MarshalJSON() ([]byte, error) {
+	v := contractJSON{Body: c.Body, NamedKeys: c.NamedKeys, ProtocolVersion: c.ProtocolVersion}
+	if v.NamedKeys == nil {
+		v.NamedKeys = NamedKeys{}
+	}
+
+	return json.Marshal(v)
+}
+
+func (c *Contract) UnmarshalJSON(src []byte) error {
+	var v contractJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*c = NewContract(v.Body, v.NamedKeys, v.ProtocolVersion)
+	return nil
+}
+
+type keyJSON struct {
+	Account hexBytes `json:"account,omitempty"`
+	Hash    hexBytes `json:"hash,omitempty"`
+	Uref    *URef    `json:"uref,omitempty"`
+	Local   hexBytes `json:"local,omitempty"`
+}
+
+func (k Key) MarshalJSON() ([]byte, error) {
+	switch k.KeyID() {
+	case KEY_ID_ACCOUNT:
+		return json.Marshal(keyJSON{Account: k.Address()})
+	case KEY_ID_HASH:
+		return json.Marshal(keyJSON{Hash: k.Address()})
+	case KEY_ID_UREF:
+		uref, _ := k.URef()
+		return json.Marshal(keyJSON{Uref: &uref})
+	case KEY_ID_LOCAL:
+		return json.Marshal(keyJSON{Local: k.Address()})
+	default:
+		return nil, fmt.Errorf("Unknown key id %d", k.KeyID())
+	}
+}
+
+func (k *Key) UnmarshalJSON(src []byte) error {
+	var v keyJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	var (
+		key   Key
+		err   error
+		count int
+	)
+	if v.Account != nil {
+		key, err = NewAccountKey(v.Account)
+		count++
+	}
+	if v.Hash != nil {
+		key, err = NewHashKey(v.Hash)
+		count++
+	}
+	if v.Uref != nil {
+		key, err = NewURefKey(*v.Uref)
+		count++
+	}
+	if v.Local != nil {
+		key, err = NewLocalKey(v.Local)
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Key JSON must have one of account, hash, uref and local")
+	}
+	if err != nil {
+		return err
+	}
+
+	*k = key
+	return nil
+}
+
+type urefJSON struct {
+	Address      hexBytes `json:"address"`
+	AccessRights string   `json:"access_rights"`
+}
+
+func (u URef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(urefJSON{Address: u.Address, AccessRights: u.AccessRights.String()})
+}
+
+func (u *URef) UnmarshalJSON(src []byte) error {
+	var v urefJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	accessRights, ok := state.Key_URef_AccessRights_value[v.AccessRights]
+	if !ok {
+		return fmt.Errorf("Unknown URef access rights %q", v.AccessRights)
+	}
+
+	*u = NewURef(v.Address, state.Key_URef_AccessRights(accessRights))
+	return nil
+}
+
+func (c CLType) MarshalJSON() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(c.String())
+}
+
+func (c *CLType) UnmarshalJSON(src []byte) error {
+	var str string
+	if err := json.Unmarshal(src, &str); err != nil {
+		return err
+	}
+	clType, err := ParseCLType(str)
+	if err != nil {
+		return err
+	}
+
+	*c = clType
+	return nil
+}
+
+// clValueJSON 의 Value 는 CL type에 맞는 JSON 값이다.
+//
+// U128 이상의 정수는 10진수 문자열, List<U8> 와 FixedList<U8, N> 은 hex 문자열, Unit 과 None 은 null,
+// Some 은 값 그대로 표현한다. 단 Option<Unit>, Option<Option<T>> 의 Some 은 None 과 구분하기 위해 [v] 로 표현한다.
+// Result 는 {"ok": v} 또는 {"err": v}, Map 은 [{"key": k, "value": v}, ...], Tuple 은 배열로 표현한다.
+// Any type의 값은 Value 없이 Bytes 로만 표현한다.
+type clValueJSON struct {
+	Type  CLType          `json:"cl_type"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Bytes *hexBytes       `json:"bytes,omitempty"`
+}
+
+func (c CLValue) MarshalJSON() ([]byte, error) {
+	valueBytes := hexBytes(c.Bytes)
+	v := clValueJSON{Type: c.Type, Bytes: &valueBytes}
+	if !clTypeHasAny(c.Type) {
+		decoded, err := c.decodeBytes(STORED_VALUE_FORMAT_V1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := clValueToJSON(c.Type, decoded)
+		if err != nil {
+			return nil, err
+		}
+		v.Value = value
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON 은 bytes 가 있으면 bytes 를, 없으면 value 를 cl_type 에 맞게 serialize 하여 CLValue 로 변환한다.
+func (c *CLValue) UnmarshalJSON(src []byte) error {
+	var v clValueJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+	if err := v.Type.Validate(); err != nil {
+		return err
+	}
+
+	var valueBytes []byte
+	switch {
+	case v.Bytes != nil:
+		valueBytes = *v.Bytes
+		if !clTypeHasAny(v.Type) {
+			if _, err := NewClValue(valueBytes, v.Type).decodeBytes(STORED_VALUE_FORMAT_V1); err != nil {
+				return err
+			}
+		}
+	case len(v.Value) > 0:
+		var err error
+		valueBytes, err = clValueFromJSON(v.Type, v.Value)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("CLValue JSON must have value or bytes")
+	}
+
+	*c = NewClValue(valueBytes, v.Type)
+	return nil
+}
+
+func clTypeHasAny(clType CLType) bool {
+	if clType.Tag == TAG_ANY {
+		return true
+	}
+	for _, inner := range clType.Inner {
+		if clTypeHasAny(inner) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNullableCLType 은 값이 JSON null 로 표현될 수 있는 type 인지 return 하는 함수.
+func isNullableCLType(clType CLType) bool {
+	return clType.Tag == TAG_UNIT || clType.Tag == TAG_OPTION
+}
+
+type resultJSON struct {
+	Ok  json.RawMessage `json:"ok,omitempty"`
+	Err json.RawMessage `json:"err,omitempty"`
+}
+
+type mapEntryJSON struct {
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// clValueToJSON 은 decodeCLValue 가 decode 한 clType 값을 JSON 값으로 변환하는 함수.
+func clValueToJSON(clType CLType, value interface{}) (json.RawMessage, error) {
+	values := func(typeOf func(idx int) CLType) ([]json.RawMessage, error) {
+		res := []json.RawMessage{}
+		for idx, elem := range value.([]interface{}) {
+			elemJSON, err := clValueToJSON(typeOf(idx), elem)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, elemJSON)
+		}
+		return res, nil
+	}
+
+	switch clType.Tag {
+	case TAG_U128, TAG_U256, TAG_U512:
+		return json.Marshal(value.(*big.Int).String())
+	case TAG_UNIT:
+		return json.RawMessage("null"), nil
+	case TAG_OPTION:
+		option := value.(clOption)
+		if !option.Some {
+			return json.RawMessage("null"), nil
+		}
+		res, err := clValueToJSON(clType.Inner[0], option.Value)
+		if err != nil || !isNullableCLType(clType.Inner[0]) {
+			return res, err
+		}
+		return json.Marshal([]json.RawMessage{res})
+	case TAG_LIST, TAG_FIXED_LIST:
+		if bytesValue, ok := value.([]byte); ok {
+			return json.Marshal(hexBytes(bytesValue))
+		}
+		res, err := values(func(int) CLType { return clType.Inner[0] })
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(res)
+	case TAG_RESULT:
+		result := value.(clResult)
+		if result.Ok {
+			res, err := clValueToJSON(clType.Inner[0], result.Value)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(resultJSON{Ok: res})
+		}
+		res, err := clValueToJSON(clType.Inner[1], result.Value)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(resultJSON{Err: res})
+	case TAG_MAP:
+		entries := []mapEntryJSON{}
+		for _, entry := range value.([]clMapEntry) {
+			key, err := clValueToJSON(clType.Inner[0], entry.Key)
+			if err != nil {
+				return nil, err
+			}
+			entryValue, err := clValueToJSON(clType.Inner[1], entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, mapEntryJSON{Key: key, Value: entryValue})
+		}
+		return json.Marshal(entries)
+	case TAG_TUPLE1, TAG_TUPLE2, TAG_TUPLE3:
+		res, err := values(func(idx int) CLType { return clType.Inner[idx] })
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(res)
+	default:
+		// Bool, 정수, String, Key, URef 는 decode 된 Go 값 그대로 변환한다.
+		return json.Marshal(value)
+	}
+}
+
+// clValueFromJSON 은 clValueToJSON 형식의 JSON 값을 clType 에 맞게 serialize 하는 함수.
+func clValueFromJSON(clType CLType, src json.RawMessage) ([]byte, error) {
+	isNull := bytes.Equal(bytes.TrimSpace(src), []byte("null"))
+	invalid := func(err error) error {
+		return fmt.Errorf("Invalid %s JSON value %s : %s", clType, src, err.Error())
+	}
+	parseNumber := func(parse func(str string) error) error {
+		var number json.Number
+		if err := json.Unmarshal(src, &number); err != nil {
+			return invalid(err)
+		}
+		if err := parse(number.String()); err != nil {
+			return invalid(err)
+		}
+		return nil
+	}
+	list := func(expected int) ([]json.RawMessage, error) {
+		var values []json.RawMessage
+		if err := json.Unmarshal(src, &values); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be an array"))
+		}
+		if expected >= 0 && len(values) != expected {
+			return nil, invalid(fmt.Errorf("must have %d elements, but %d", expected, len(values)))
+		}
+		return values, nil
+	}
+	concat := func(res []byte, innerType CLType, values ...json.RawMessage) ([]byte, error) {
+		for _, value := range values {
+			valueBytes, err := clValueFromJSON(innerType, value)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, valueBytes...)
+		}
+		return res, nil
+	}
+
+	switch clType.Tag {
+	case TAG_BOOL:
+		var value bool
+		if err := json.Unmarshal(src, &value); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be a boolean"))
+		}
+		if value {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case TAG_I32, TAG_I64:
+		bitSize := map[CL_TYPE_TAG]int{TAG_I32: 32, TAG_I64: 64}[clType.Tag]
+		var value int64
+		err := parseNumber(func(str string) (err error) {
+			value, err = strconv.ParseInt(str, 10, bitSize)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		res := make([]byte, bitSize/8)
+		if bitSize == 32 {
+			binary.LittleEndian.PutUint32(res, uint32(value))
+		} else {
+			binary.LittleEndian.PutUint64(res, uint64(value))
+		}
+		return res, nil
+	case TAG_U8, TAG_U32, TAG_U64:
+		bitSize := map[CL_TYPE_TAG]int{TAG_U8: 8, TAG_U32: 32, TAG_U64: 64}[clType.Tag]
+		var value uint64
+		err := parseNumber(func(str string) (err error) {
+			value, err = strconv.ParseUint(str, 10, bitSize)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		res := make([]byte, LONG_LENGTH)
+		binary.LittleEndian.PutUint64(res, value)
+		return res[:bitSize/8], nil
+	case TAG_U128, TAG_U256, TAG_U512:
+		var str string
+		if err := json.Unmarshal(src, &str); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be a decimal string"))
+		}
+		res, err := bigIntStringToBytes(str, map[CL_TYPE_TAG]int{TAG_U128: 128, TAG_U256: 256, TAG_U512: 512}[clType.Tag])
+		if err != nil {
+			return nil, invalid(err)
+		}
+		return res, nil
+	case TAG_UNIT:
+		if !isNull {
+			return nil, invalid(fmt.Errorf("must be null"))
+		}
+		return []byte{}, nil
+	case TAG_STRING:
+		var str string
+		if err := json.Unmarshal(src, &str); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be a string"))
+		}
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(str)))
+		return append(res, str...), nil
+	case TAG_KEY:
+		var key Key
+		if err := json.Unmarshal(src, &key); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be a key object"))
+		}
+		return key.ToBytes(), nil
+	case TAG_UREF:
+		var uref URef
+		if err := json.Unmarshal(src, &uref); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be a uref object"))
+		}
+		return uref.ToBytes(), nil
+	case TAG_OPTION:
+		if isNull {
+			return []byte{0}, nil
+		}
+		if isNullableCLType(clType.Inner[0]) {
+			values, err := list(1)
+			if err != nil {
+				return nil, err
+			}
+			src = values[0]
+		}
+		return concat([]byte{1}, clType.Inner[0], src)
+	case TAG_LIST, TAG_FIXED_LIST:
+		if clType.Inner[0].Tag == TAG_U8 {
+			var value hexBytes
+			if err := json.Unmarshal(src, &value); err != nil || isNull {
+				return nil, invalid(fmt.Errorf("must be a hex string"))
+			}
+			if clType.Tag == TAG_LIST {
+				res := make([]byte, SIZE_LENGTH)
+				binary.LittleEndian.PutUint32(res, uint32(len(value)))
+				return append(res, value...), nil
+			}
+			if len(value) != int(clType.Length) {
+				return nil, invalid(fmt.Errorf("must have %d bytes, but %d", clType.Length, len(value)))
+			}
+			return value, nil
+		}
+
+		expected := -1
+		if clType.Tag == TAG_FIXED_LIST {
+			expected = int(clType.Length)
+		}
+		values, err := list(expected)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(values)))
+		return concat(res, clType.Inner[0], values...)
+	case TAG_RESULT:
+		var result resultJSON
+		if err := json.Unmarshal(src, &result); err != nil || isNull || (result.Ok == nil) == (result.Err == nil) {
+			return nil, invalid(fmt.Errorf("must have one of ok and err"))
+		}
+		if result.Ok != nil {
+			return concat([]byte{RESULT_OK_TAG}, clType.Inner[0], result.Ok)
+		}
+		return concat([]byte{RESULT_ERR_TAG}, clType.Inner[1], result.Err)
+	case TAG_MAP:
+		var entries []mapEntryJSON
+		if err := json.Unmarshal(src, &entries); err != nil || isNull {
+			return nil, invalid(fmt.Errorf("must be an array of key and value"))
+		}
+		res := make([]byte, SIZE_LENGTH)
+		binary.LittleEndian.PutUint32(res, uint32(len(entries)))
+		for _, entry := range entries {
+			var err error
+			res, err = concat(res, clType.Inner[0], entry.Key)
+			if err != nil {
+				return nil, err
+			}
+			res, err = concat(res, clType.Inner[1], entry.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case TAG_TUPLE1, TAG_TUPLE2, TAG_TUPLE3:
+		values, err := list(len(clType.Inner))
+		if err != nil {
+			return nil, err
+		}
+		res := []byte{}
+		for idx, value := range values {
+			res, err = concat(res, clType.Inner[idx], value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("%s value can not be converted from JSON", clType)
+	}
+}
+
+// bigIntJSON 은 JSON에서 10진수 문자열로 표현되는 음수가 아닌 큰 정수. nil 은 "0" 으로 표현한다.
+type bigIntJSON struct {
+	value *big.Int
+}
+
+func (b bigIntJSON) MarshalJSON() ([]byte, error) {
+	if b.value == nil {
+		return json.Marshal("0")
+	}
+
+	return json.Marshal(b.value.String())
+}
+
+func (b *bigIntJSON) UnmarshalJSON(src []byte) error {
+	var str string
+	if err := json.Unmarshal(src, &str); err != nil {
+		return err
+	}
+	value, ok := new(big.Int).SetString(str, 10)
+	if !ok || value.Sign() < 0 {
+		return fmt.Errorf("Invalid big integer %q", str)
+	}
+	if value.Sign() == 0 {
+		value = new(big.Int)
+	}
+
+	b.value = value
+	return nil
+}
+
+func (c ContractWasm) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Bytes hexBytes `json:"bytes"`
+	}{c.Bytes})
+}
+
+func (c *ContractWasm) UnmarshalJSON(src []byte) error {
+	var v struct {
+		Bytes hexBytes `json:"bytes"`
+	}
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*c = NewContractWasm(v.Bytes)
+	return nil
+}
+
+type contractV2JSON struct {
+	ContractPackageHash hexBytes        `json:"contract_package_hash"`
+	ContractWasmHash    hexBytes        `json:"contract_wasm_hash"`
+	NamedKeys           NamedKeys       `json:"named_keys"`
+	EntryPoints         []EntryPoint    `json:"entry_points"`
+	ProtocolVersion     ProtocolVersion `json:"protocol_version"`
+}
+
+func (c ContractV2) MarshalJSON() ([]byte, error) {
+	v := contractV2JSON{
+		ContractPackageHash: c.ContractPackageHash,
+		ContractWasmHash:    c.ContractWasmHash,
+		NamedKeys:           c.NamedKeys,
+		EntryPoints:         c.EntryPoints,
+		ProtocolVersion:     c.ProtocolVersion}
+	if v.NamedKeys == nil {
+		v.NamedKeys = NamedKeys{}
+	}
+	if v.EntryPoints == nil {
+		v.EntryPoints = []EntryPoint{}
+	}
+
+	return json.Marshal(v)
+}
+
+func (c *ContractV2) UnmarshalJSON(src []byte) error {
+	var v contractV2JSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*c = ContractV2{
+		ContractPackageHash: v.ContractPackageHash,
+		ContractWasmHash:    v.ContractWasmHash,
+		NamedKeys:           v.NamedKeys,
+		EntryPoints:         v.EntryPoints,
+		ProtocolVersion:     v.ProtocolVersion}
+	return nil
+}
+
+type contractVersionJSON struct {
+	Key          ContractVersionKey `json:"key"`
+	ContractHash hexBytes           `json:"contract_hash"`
+}
+
+func (c ContractVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(contractVersionJSON{Key: c.Key, ContractHash: c.ContractHash})
+}
+
+func (c *ContractVersion) UnmarshalJSON(src []byte) error {
+	var v contractVersionJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*c = ContractVersion{Key: v.Key, ContractHash: v.ContractHash}
+	return nil
+}
+
+func (p PublicKey) MarshalJSON() ([]byte, error) {
+	return hexBytes(p.ToBytes()).MarshalJSON()
+}
+
+func (p *PublicKey) UnmarshalJSON(src []byte) error {
+	var v hexBytes
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+	publicKey, err, pos := PublicKey{}.FromBytes(v)
+	if err != nil {
+		return err
+	}
+	if pos != len(v) {
+		return fmt.Errorf("PublicKey has %d trailing bytes", len(v)-pos)
+	}
+
+	*p = publicKey
+	return nil
+}
+
+// transferJSON 의 To 는 없으면 null 로 표현한다.
+type transferJSON struct {
+	DeployHash hexBytes   `json:"deploy_hash"`
+	From       hexBytes   `json:"from"`
+	To         *hexBytes  `json:"to"`
+	Source     URef       `json:"source"`
+	Target     URef       `json:"target"`
+	Amount     bigIntJSON `json:"amount"`
+	Gas        bigIntJSON `json:"gas"`
+	ID         *uint64    `json:"id"`
+}
+
+func (t Transfer) MarshalJSON() ([]byte, error) {
+	v := transferJSON{
+		DeployHash: t.DeployHash,
+		From:       t.From,
+		Source:     t.Source,
+		Target:     t.Target,
+		Amount:     bigIntJSON{t.Amount},
+		Gas:        bigIntJSON{t.Gas},
+		ID:         t.ID}
+	if t.To != nil {
+		to := hexBytes(t.To)
+		v.To = &to
+	}
+
+	return json.Marshal(v)
+}
+
+func (t *Transfer) UnmarshalJSON(src []byte) error {
+	var v transferJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*t = Transfer{
+		DeployHash: v.DeployHash,
+		From:       v.From,
+		Source:     v.Source,
+		Target:     v.Target,
+		Amount:     v.Amount.value,
+		Gas:        v.Gas.value,
+		ID:         v.ID}
+	if v.To != nil {
+		t.To = *v.To
+	}
+	return nil
+}
+
+type deployInfoJSON struct {
+	DeployHash hexBytes   `json:"deploy_hash"`
+	Transfers  []hexBytes `json:"transfers"`
+	From       hexBytes   `json:"from"`
+	Source     URef       `json:"source"`
+	Gas        bigIntJSON `json:"gas"`
+}
+
+func (d DeployInfo) MarshalJSON() ([]byte, error) {
+	v := deployInfoJSON{
+		DeployHash: d.DeployHash,
+		Transfers:  []hexBytes{},
+		From:       d.From,
+		Source:     d.Source,
+		Gas:        bigIntJSON{d.Gas}}
+	for _, transfer := range d.Transfers {
+		v.Transfers = append(v.Transfers, transfer)
+	}
+
+	return json.Marshal(v)
+}
+
+func (d *DeployInfo) UnmarshalJSON(src []byte) error {
+	var v deployInfoJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*d = DeployInfo{
+		DeployHash: v.DeployHash,
+		Transfers:  [][]byte{},
+		From:       v.From,
+		Source:     v.Source,
+		Gas:        v.Gas.value}
+	for _, transfer := range v.Transfers {
+		d.Transfers = append(d.Transfers, transfer)
+	}
+	return nil
+}
+
+type seigniorageAllocationJSON struct {
+	Delegator *PublicKey `json:"delegator"`
+	Validator PublicKey  `json:"validator"`
+	Amount    bigIntJSON `json:"amount"`
+}
+
+func (s SeigniorageAllocation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(seigniorageAllocationJSON{Delegator: s.Delegator, Validator: s.Validator, Amount: bigIntJSON{s.Amount}})
+}
+
+func (s *SeigniorageAllocation) UnmarshalJSON(src []byte) error {
+	var v seigniorageAllocationJSON
+	if err := json.Unmarshal(src, &v); err != nil {
+		return err
+	}
+
+	*s = SeigniorageAllocation{Delegator: v.Delegator, Validator: v.Validator, Amount: v.Amount.value}
+	return nil
+}
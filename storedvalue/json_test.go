@@ -0,0 +1,170 @@
+package storedvalue
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountJSON(t *testing.T) {
+	src, err := hex.DecodeString("01" + decodeTestAccountHex)
+	assert.NoError(t, err)
+	var storedValue StoredValue
+	storedValue, err, _ = storedValue.FromBytes(src)
+	assert.NoError(t, err)
+
+	res, err := json.Marshal(storedValue)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"account": {
+		"public_key": "0000000000000000000000000000000000000000000000000000000000000000",
+		"named_keys": [
+			{"name": "mint", "key": {"uref": {"address": "6cc261631cd46c959857de59ee0a5f61099457300012267bbde569820625c7f8", "access_rights": "READ"}}},
+			{"name": "pos", "key": {"uref": {"address": "bb0d91b8604970a269bf96ac55de5fa416135e2837d88a0bac938e2eca2d0fe2", "access_rights": "READ"}}}
+		],
+		"main_purse": {"address": "2efe91034583b378b4b9ffcc62b642650f5d455c4665f4206168ed0637ff7a70", "access_rights": "READ_ADD_WRITE"},
+		"associated_keys": [{"public_key": "0000000000000000000000000000000000000000000000000000000000000000", "weight": 1}],
+		"action_thresholds": {"deployment_threshold": 1, "key_management_threshold": 1}
+	}}`, string(res))
+
+	var decoded StoredValue
+	assert.NoError(t, json.Unmarshal(res, &decoded))
+	assert.Equal(t, storedValue, decoded)
+}
+
+func TestCLValueJSON(t *testing.T) {
+	values := []struct {
+		clType string
+		bytes  string
+		json   string
+	}{
+		{"U512", "02e803", `"1000"`},
+		{"I64", "ffffffffffffffff", `-1`},
+		{"Option<String>", "010100000061", `"a"`},
+		{"Option<Unit>", "00", `null`},
+		{"Option<Unit>", "01", `[null]`},
+		{"Option<Option<U8>>", "0100", `[null]`},
+		{"FixedList<U8, 2>", "0102", `"0102"`},
+		{"List<U8>", "020000000102", `"0102"`},
+		{"Result<I32, U8>", "01ffffffff", `{"ok": -1}`},
+		{"Result<I32, U8>", "0007", `{"err": 7}`},
+		{"Map<String, Bool>", "01000000010000006101", `[{"key": "a", "value": true}]`},
+		{"Tuple2<String, List<U32>>", "000000000100000005000000", `["", [5]]`},
+		{"Key", "02" + strings.Repeat("11", ADDRESS_LENGTH) + "07",
+			`{"uref": {"address": "` + strings.Repeat("11", ADDRESS_LENGTH) + `", "access_rights": "READ_ADD_WRITE"}}`},
+	}
+
+	for _, v := range values {
+		clType, err := ParseCLType(v.clType)
+		assert.NoError(t, err)
+		valueBytes, err := hex.DecodeString(v.bytes)
+		assert.NoError(t, err)
+		clValue := NewClValue(valueBytes, clType)
+
+		res, err := json.Marshal(clValue)
+		if !assert.NoError(t, err, v.clType) {
+			continue
+		}
+		assert.JSONEq(t, `{"cl_type": "`+v.clType+`", "value": `+v.json+`, "bytes": "`+v.bytes+`"}`, string(res))
+
+		withoutBytes := `{"cl_type": "` + v.clType + `", "value": ` + v.json + `}`
+		for _, src := range []string{string(res), withoutBytes} {
+			var decoded CLValue
+			assert.NoError(t, json.Unmarshal([]byte(src), &decoded), src)
+			assert.Equal(t, clValue, decoded, src)
+		}
+	}
+}
+
+// 생성한 StoredValue 는 JSON 으로 변환한 후 같은 값으로 복원되어야 하며,
+// CL value 는 bytes 없이 value 만으로도 같은 bytes 로 복원되어야 한다.
+func TestStoredValueJSONRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for i := 0; i < roundTripMaxCount; i++ {
+		storedValue := genStoredValue(r)
+
+		res, err := json.Marshal(storedValue)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		var decoded StoredValue
+		assert.NoError(t, json.Unmarshal(res, &decoded), string(res))
+		assert.Equal(t, storedValue, decoded)
+
+		if storedValue.Type != TYPE_CL_VALUE {
+			continue
+		}
+		value, err := json.Marshal(struct {
+			Type  CLType          `json:"cl_type"`
+			Value json.RawMessage `json:"value"`
+		}{storedValue.ClValue.Type, mustCLValueJSONValue(t, storedValue.ClValue)})
+		assert.NoError(t, err)
+
+		var clValue CLValue
+		assert.NoError(t, json.Unmarshal(value, &clValue), string(value))
+		assert.Equal(t, storedValue.ClValue, clValue, string(value))
+	}
+}
+
+func mustCLValueJSONValue(t *testing.T, clValue CLValue) json.RawMessage {
+	decoded, err := clValue.decodeBytes(STORED_VALUE_FORMAT_V1)
+	assert.NoError(t, err)
+	value, err := clValueToJSON(clValue.Type, decoded)
+	assert.NoError(t, err)
+	return value
+}
+
+func TestParseCLType(t *testing.T) {
+	for _, str := range []string{"U8", "Option<Unit>", "Map<String, List<Key>>", "FixedList<U8, 32>",
+		"Tuple3<Bool, Result<I32, String>, FixedList<Tuple1<URef>, 2>>", "Any"} {
+		clType, err := ParseCLType(str)
+		assert.NoError(t, err)
+		assert.Equal(t, str, clType.String())
+	}
+
+	clType, err := ParseCLType(" Map< String ,U64 > ")
+	assert.NoError(t, err)
+	assert.Equal(t, NewMapCLType(NewSimpleCLType(TAG_STRING), NewSimpleCLType(TAG_U64)), clType)
+
+	for _, str := range []string{"", "u8", "List", "List<U8", "Map<String>", "FixedList<U8>", "FixedList<U8, -1>", "U8>"} {
+		_, err := ParseCLType(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	var storedValue StoredValue
+	assert.Error(t, json.Unmarshal([]byte(`{}`), &storedValue))
+	assert.Error(t, json.Unmarshal([]byte(`{"account": {}, "contract": {}}`), &storedValue))
+
+	var key Key
+	assert.Error(t, json.Unmarshal([]byte(`{"hash": "00", "local": "00"}`), &key))
+	assert.Error(t, json.Unmarshal([]byte(`{"uref": {"address": "00", "access_rights": "ALL"}}`), &key))
+
+	var clValue CLValue
+	assert.Error(t, json.Unmarshal([]byte(`{"cl_type": "U32"}`), &clValue))
+	assert.Error(t, json.Unmarshal([]byte(`{"cl_type": "U8", "value": 256}`), &clValue))
+	assert.Error(t, json.Unmarshal([]byte(`{"cl_type": "U128", "value": 1}`), &clValue))
+	assert.Error(t, json.Unmarshal([]byte(`{"cl_type": "U32", "bytes": "0000"}`), &clValue))
+	assert.Error(t, json.Unmarshal([]byte(`{"cl_type": "Any", "value": 1}`), &clValue))
+	assert.Error(t, json.Unmarshal([]byte(`{"cl_type": "FixedList<String, 2>", "value": ["a"]}`), &clValue))
+
+	// 크기가 0 인 원소의 개수와 큰 정수의 길이는 Decode 와 같이 제한된다.
+	for _, src := range []string{
+		`{"cl_type": "List<Unit>", "bytes": "ffffff03"}`,
+		`{"cl_type": "Map<Unit, Tuple1<Unit>>", "bytes": "ffffffff"}`,
+		`{"cl_type": "FixedList<Unit, 4294967295>", "bytes": "ffffffff"}`,
+		`{"cl_type": "List<U64>", "bytes": "ffffff03"}`,
+		`{"cl_type": "U128", "bytes": "40` + strings.Repeat("ff", 64) + `"}`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(src), &clValue), src)
+	}
+	_, err := json.Marshal(NewClValue(append([]byte{17}, make([]byte, 17)...), NewSimpleCLType(TAG_U128)))
+	assert.Error(t, err)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"cl_type": "Any", "bytes": "0102"}`), &clValue))
+	assert.Equal(t, []byte{1, 2}, clValue.Bytes)
+}
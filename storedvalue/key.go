@@ -1,10 +1,12 @@
 package storedvalue
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -24,91 +26,222 @@ const (
 	KEY_ID_LOCAL
 )
 
+var keyIDNames = map[KEY_ID]string{
+	KEY_ID_ACCOUNT: "account",
+	KEY_ID_HASH:    "hash",
+	KEY_ID_UREF:    "uref",
+	KEY_ID_LOCAL:   "local",
+}
+
+func (id KEY_ID) String() string {
+	if name, ok := keyIDNames[id]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("KEY_ID(%d)", int(id))
+}
+
+// Key 는 global state의 key를 나타내는 type.
+//
+// account, hash, local key는 32 bytes address를, uref key는 address와 access rights를 가진다.
+// 생성자로 검증된 값만 만들 수 있으며, 비교 가능한 값이므로 == 로 비교하거나 map key로 사용할 수 있다.
+// Key{} 는 address가 모두 0인 account key 이다.
 type Key struct {
-	KeyID   KEY_ID  `json:"key_id"`
-	Account Account `json:"account"`
-	Uref    URef    `json:"uref"`
-	Hash    []byte  `json:"hash"`
-	Local   []byte  `json:"local"`
+	keyID        KEY_ID
+	address      [ADDRESS_LENGTH]byte
+	accessRights state.Key_URef_AccessRights
 }
 
-func NewKeyFromURef(uref URef) Key {
-	return Key{
-		KeyID: KEY_ID_UREF,
-		Uref:  uref,
+func newKey(keyID KEY_ID, address []byte, accessRights state.Key_URef_AccessRights) (Key, error) {
+	if _, ok := keyIDNames[keyID]; !ok {
+		return Key{}, fmt.Errorf("Unknown key id %d", keyID)
+	}
+	if len(address) != ADDRESS_LENGTH {
+		return Key{}, fmt.Errorf("%s key address length %d, but %d", keyID, ADDRESS_LENGTH, len(address))
 	}
+	if err := ValidateAccessRights(accessRights); err != nil {
+		return Key{}, err
+	}
+
+	k := Key{keyID: keyID, accessRights: accessRights}
+	copy(k.address[:], address)
+
+	return k, nil
+}
+
+// NewAccountKey 는 32 bytes account address로 account key를 만드는 함수.
+func NewAccountKey(address []byte) (Key, error) {
+	return newKey(KEY_ID_ACCOUNT, address, state.Key_URef_NONE)
 }
 
-func NewKeyFromAccount(account Account) Key {
-	return Key{
-		KeyID:   KEY_ID_ACCOUNT,
-		Account: account,
+// NewHashKey 는 32 bytes contract hash로 hash key를 만드는 함수.
+func NewHashKey(hash []byte) (Key, error) {
+	return newKey(KEY_ID_HASH, hash, state.Key_URef_NONE)
+}
+
+// NewURefKey 는 URef로 uref key를 만드는 함수.
+func NewURefKey(uref URef) (Key, error) {
+	return newKey(KEY_ID_UREF, uref.GetAddress(), uref.GetAccessRights())
+}
+
+// NewLocalKey 는 32 bytes local hash로 local key를 만드는 함수.
+func NewLocalKey(hash []byte) (Key, error) {
+	return newKey(KEY_ID_LOCAL, hash, state.Key_URef_NONE)
+}
+
+// ParseKey 는 String 형식(account-<hex>, hash-<hex>, uref-<hex>-<rights>, local-<hex>)의 문자열을 Key로 변환하는 함수.
+//
+// uref의 access rights는 ParseAccessRights 를 따른다.
+func ParseKey(str string) (Key, error) {
+	parts := strings.Split(str, "-")
+
+	keyID := KEY_ID(-1)
+	for id, name := range keyIDNames {
+		if parts[0] == name {
+			keyID = id
+		}
+	}
+	expectedParts := 2
+	if keyID == KEY_ID_UREF {
+		expectedParts = 3
 	}
+	if keyID < 0 || len(parts) != expectedParts {
+		return Key{}, fmt.Errorf("Invalid key : %q", str)
+	}
+
+	address, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return Key{}, fmt.Errorf("Invalid key address : %q", str)
+	}
+
+	accessRights := state.Key_URef_NONE
+	if keyID == KEY_ID_UREF {
+		accessRights, err = ParseAccessRights(parts[2])
+		if err != nil {
+			return Key{}, err
+		}
+	}
+
+	return newKey(keyID, address, accessRights)
 }
 
-func NewKeyFromHash(hash []byte) Key {
-	return Key{
-		KeyID: KEY_ID_HASH,
-		Hash:  hash,
+// MustParseKey 는 ParseKey 와 같으나 실패하면 panic 하는 함수.
+func MustParseKey(str string) Key {
+	key, err := ParseKey(str)
+	if err != nil {
+		panic(err)
 	}
+
+	return key
+}
+
+func (k Key) KeyID() KEY_ID {
+	return k.keyID
+}
+
+// Address 는 key의 32 bytes address를 복사하여 return 하는 함수.
+func (k Key) Address() []byte {
+	return append([]byte{}, k.address[:]...)
+}
+
+// AccessRights 는 uref key의 access rights를 return 하는 함수. 다른 key는 NONE 이다.
+func (k Key) AccessRights() state.Key_URef_AccessRights {
+	return k.accessRights
 }
 
-func NewKeyFromLocal(local []byte) Key {
-	return Key{
-		KeyID: KEY_ID_LOCAL,
-		Local: local,
+// URef 는 uref key의 URef를 return 하는 함수. uref key가 아니면 ok는 false 이다.
+func (k Key) URef() (uref URef, ok bool) {
+	if k.keyID != KEY_ID_UREF {
+		return URef{}, false
 	}
+
+	return NewURef(k.Address(), k.accessRights), true
+}
+
+func (k Key) Equal(other Key) bool {
+	return k == other
+}
+
+// Compare 는 key id, address, access rights 순서로 비교하여 -1, 0, 1 을 return 하는 함수.
+func (k Key) Compare(other Key) int {
+	switch {
+	case k.keyID < other.keyID:
+		return -1
+	case k.keyID > other.keyID:
+		return 1
+	}
+
+	if res := bytes.Compare(k.address[:], other.address[:]); res != 0 {
+		return res
+	}
+
+	switch {
+	case k.accessRights < other.accessRights:
+		return -1
+	case k.accessRights > other.accessRights:
+		return 1
+	}
+
+	return 0
+}
+
+func (k Key) String() string {
+	if uref, ok := k.URef(); ok {
+		return uref.String()
+	}
+
+	return k.keyID.String() + "-" + hex.EncodeToString(k.address[:])
+}
+
+// MarshalText, UnmarshalText 는 String 형식을 사용하며, JSON object의 key로 Key를 사용할 때 쓰인다.
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *Key) UnmarshalText(src []byte) error {
+	key, err := ParseKey(string(src))
+	if err != nil {
+		return err
+	}
+
+	*k = key
+	return nil
 }
 
 func (k Key) FromBytes(src []byte) (key Key, err error, pos int) {
 	pos = KEY_ID_POS
-	k.KeyID = KEY_ID(src[pos])
-	pos += KEY_ID_LENGTH
-
-	if len(src) < KEY_ID_LENGTH+ADDRESS_LENGTH {
-		return Key{}, fmt.Errorf("Key length more than %d, but %d", KEY_ID_LENGTH+ADDRESS_LENGTH, len(src)), pos
+	if err := checkLength(src, pos, KEY_ID_LENGTH, "Key id"); err != nil {
+		return Key{}, err, pos
 	}
+	keyID := KEY_ID(src[pos])
+	pos += KEY_ID_LENGTH
 
-	switch k.KeyID {
-	case KEY_ID_ACCOUNT:
-		var account Account
-		account, err, length := account.FromBytes(src[pos:])
-		if err != nil {
+	switch keyID {
+	case KEY_ID_ACCOUNT, KEY_ID_HASH, KEY_ID_LOCAL:
+		if err := checkLength(src, pos, ADDRESS_LENGTH, fmt.Sprintf("Key %s address", keyID)); err != nil {
 			return Key{}, err, pos
 		}
-		k.Account = account
-		pos += length
-	case KEY_ID_HASH:
-		k.Hash = src[pos : pos+ADDRESS_LENGTH]
+		k, _ = newKey(keyID, src[pos:pos+ADDRESS_LENGTH], state.Key_URef_NONE)
 		pos += ADDRESS_LENGTH
 	case KEY_ID_UREF:
 		var uref URef
 		uref, err, length := uref.FromBytes(src[pos:])
 		if err != nil {
-			return Key{}, err, pos
+			return Key{}, shiftDecodeError(err, pos), pos
 		}
-		k.Uref = uref
+		k, _ = NewURefKey(uref)
 		pos += length
-	case KEY_ID_LOCAL:
-		k.Local = src[pos : pos+ADDRESS_LENGTH]
-		pos += ADDRESS_LENGTH
+	default:
+		return Key{}, decodeErrorf(KEY_ID_POS, "Unknown key id %d", keyID), pos
 	}
 
 	return k, nil, pos
 }
 
 func (k Key) ToBytes() []byte {
-	res := []byte{byte(k.KeyID)}
-
-	switch k.KeyID {
-	case KEY_ID_ACCOUNT:
-		res = append(res, k.ToBytes()...)
-	case KEY_ID_HASH:
-		res = append(res, k.Hash...)
-	case KEY_ID_UREF:
-		res = append(res, k.Uref.ToBytes()...)
-	case KEY_ID_LOCAL:
-		res = append(res, k.Local...)
+	res := append([]byte{byte(k.keyID)}, k.address[:]...)
+	if k.keyID == KEY_ID_UREF {
+		res = append(res, byte(k.accessRights))
 	}
 
 	return res
@@ -116,15 +249,16 @@ func (k Key) ToBytes() []byte {
 
 func (k Key) ToStateValue() *state.Key {
 	var value *state.Key
-	switch k.KeyID {
+	switch k.keyID {
 	case KEY_ID_ACCOUNT:
-		value = &state.Key{Value: &state.Key_Address_{Address: &state.Key_Address{Account: k.Account.ToBytes()}}}
+		value = &state.Key{Value: &state.Key_Address_{Address: &state.Key_Address{Account: k.Address()}}}
 	case KEY_ID_HASH:
-		value = &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: k.Hash}}}
+		value = &state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: k.Address()}}}
 	case KEY_ID_UREF:
-		value = &state.Key{Value: &state.Key_Uref{Uref: k.Uref.ToStateValue()}}
+		uref, _ := k.URef()
+		value = &state.Key{Value: &state.Key_Uref{Uref: uref.ToStateValue()}}
 	case KEY_ID_LOCAL:
-		value = &state.Key{Value: &state.Key_Local_{Local: &state.Key_Local{Hash: k.Local}}}
+		value = &state.Key{Value: &state.Key_Local_{Local: &state.Key_Local{Hash: k.Address()}}}
 	}
 
 	return value
@@ -133,24 +267,16 @@ func (k Key) ToStateValue() *state.Key {
 func (k Key) FromStateValue(key *state.Key) (Key, error) {
 	switch key.GetValue().(type) {
 	case *state.Key_Address_:
-		var account Account
-		account, err, _ := account.FromBytes(key.GetAddress().GetAccount())
-		if err != nil {
-			return Key{}, err
-		}
-		k = NewKeyFromAccount(account)
+		return NewAccountKey(key.GetAddress().GetAccount())
 	case *state.Key_Hash_:
-		k = NewKeyFromHash(key.GetHash().GetHash())
+		return NewHashKey(key.GetHash().GetHash())
 	case *state.Key_Uref:
-		uref := NewURef(key.GetUref().GetUref(), key.GetUref().GetAccessRights())
-		k = NewKeyFromURef(uref)
+		return NewURefKey(NewURef(key.GetUref().GetUref(), key.GetUref().GetAccessRights()))
 	case *state.Key_Local_:
-		k = NewKeyFromLocal(key.GetLocal().GetHash())
+		return NewLocalKey(key.GetLocal().GetHash())
 	default:
-		errors.New("Key data is invalid.")
+		return Key{}, errors.New("Key data is invalid.")
 	}
-
-	return k, nil
 }
 
 func (k Key) ToCLInstanceValue() *state.CLValueInstance_Value {
@@ -174,26 +300,35 @@ func NewNamedKey(name string, key Key) NamedKey {
 }
 
 func (n NamedKey) FromBytes(src []byte) (namedKey NamedKey, err error, pos int) {
+	return namedKeyFromBytes(src, STORED_VALUE_FORMAT_V1)
+}
+
+func namedKeyFromBytes(src []byte, format STORED_VALUE_FORMAT) (namedKey NamedKey, err error, pos int) {
 	pos = 0
-	nameLength := int(binary.LittleEndian.Uint32(src[pos:SIZE_LENGTH]))
+	nameLength, err := sizeFromBytes(src, pos, "NamedKey name")
+	if err != nil {
+		return NamedKey{}, err, pos
+	}
 	pos += SIZE_LENGTH
 
+	if err := checkLength(src, pos, nameLength, "NamedKey name"); err != nil {
+		return NamedKey{}, err, pos
+	}
 	name := string(src[pos : pos+nameLength])
 	pos += nameLength
 
-	var key Key
-	key, err, length := key.FromBytes(src[pos:])
-	pos += length
+	key, err, length := keyFromBytes(src[pos:], format)
 	if err != nil {
-		return NamedKey{}, err, pos
+		return NamedKey{}, shiftDecodeError(err, pos), pos
 	}
+	pos += length
 
 	return NewNamedKey(name, key), nil, pos
 }
 
 func (n NamedKey) ToBytes() []byte {
 	res := make([]byte, SIZE_LENGTH)
-	binary.BigEndian.PutUint32(res, uint32(len(n.Name)))
+	binary.LittleEndian.PutUint32(res, uint32(len(n.Name)))
 	res = append(res, []byte(n.Name)...)
 
 	res = append(res, n.Key.ToBytes()...)
@@ -238,6 +373,49 @@ func (n NamedKey) ToCLInstanceValue() *state.CLValueInstance_Value {
 
 type NamedKeys []NamedKey
 
+// FromBytes 는 u32 개수와 NamedKey 목록으로 serialize 된 named keys를 decode 하는 함수.
+func (ns NamedKeys) FromBytes(src []byte) (namedKeys NamedKeys, err error, pos int) {
+	return namedKeysFromBytes(src, STORED_VALUE_FORMAT_V1)
+}
+
+func namedKeysFromBytes(src []byte, format STORED_VALUE_FORMAT) (namedKeys NamedKeys, err error, pos int) {
+	pos = 0
+	namedKeysSize, err := sizeFromBytes(src, pos, "NamedKeys")
+	if err != nil {
+		return nil, err, pos
+	}
+	pos += SIZE_LENGTH
+
+	namedKeys = NamedKeys{}
+	for i := 0; i < namedKeysSize; i++ {
+		namedKey, err, length := namedKeyFromBytes(src[pos:], format)
+		if err != nil {
+			return nil, shiftDecodeError(err, pos), pos
+		}
+		// EE 는 BTreeMap 순서로 serialize 하므로, 정렬되지 않았거나 중복된 이름은 손상된 data 이다.
+		if i > 0 && namedKeys[i-1].Name >= namedKey.Name {
+			return nil, decodeErrorf(pos, "Named key %q must come after %q", namedKey.Name, namedKeys[i-1].Name), pos
+		}
+		pos += length
+
+		namedKeys = append(namedKeys, namedKey)
+	}
+
+	return namedKeys, nil, pos
+}
+
+// ToBytes 는 EE 와 같이 Sorted 순서로 named keys를 serialize 하는 함수.
+func (ns NamedKeys) ToBytes() []byte {
+	sorted := ns.Sorted()
+	res := make([]byte, SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(res, uint32(len(sorted)))
+	for _, namedKey := range sorted {
+		res = append(res, namedKey.ToBytes()...)
+	}
+
+	return res
+}
+
 func (ns NamedKeys) ToCLInstanceValue() *state.CLValueInstance_Value {
 	mapEntrys := []*state.CLValueInstance_MapEntry{}
 	for _, n := range ns {
@@ -261,6 +439,82 @@ func (ns NamedKeys) ToCLInstanceValue() *state.CLValueInstance_Value {
 	}
 }
 
+// Get 은 name 인 named key의 Key를 return 하는 함수. 같은 이름이 여러 개이면 첫 번째를 return 한다.
+func (ns NamedKeys) Get(name string) (Key, bool) {
+	for _, namedKey := range ns {
+		if namedKey.Name == name {
+			return namedKey.Key, true
+		}
+	}
+
+	return Key{}, false
+}
+
+// FilterByKeyID 는 Key 종류가 keyID 인 named key만 순서대로 return 하는 함수.
+func (ns NamedKeys) FilterByKeyID(keyID KEY_ID) NamedKeys {
+	res := NamedKeys{}
+	for _, namedKey := range ns {
+		if namedKey.Key.KeyID() == keyID {
+			res = append(res, namedKey)
+		}
+	}
+
+	return res
+}
+
+// Sorted 는 EE의 BTreeMap<String, Key> 과 같이 이름의 byte 순서로 정렬한 named keys를 return 하는 함수.
+//
+// 같은 이름이 여러 개이면 첫 번째만 남기므로, 결과의 ToBytes 는 EE가 serialize 한 named keys와 같다.
+func (ns NamedKeys) Sorted() NamedKeys {
+	res := append(NamedKeys{}, ns...)
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	unique := res[:0]
+	for i, namedKey := range res {
+		if i > 0 && namedKey.Name == res[i-1].Name {
+			continue
+		}
+		unique = append(unique, namedKey)
+	}
+
+	return unique
+}
+
+// IsSorted 는 named keys가 중복 없이 EE의 canonical 순서로 정렬되어 있는지 확인하는 함수.
+func (ns NamedKeys) IsSorted() bool {
+	for i := 1; i < len(ns); i++ {
+		if ns[i-1].Name >= ns[i].Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToMap 은 named keys를 이름으로 조회하는 map으로 변환하는 함수. 같은 이름이 여러 개이면 첫 번째를 사용한다.
+func (ns NamedKeys) ToMap() map[string]Key {
+	res := make(map[string]Key, len(ns))
+	for _, namedKey := range ns {
+		if _, ok := res[namedKey.Name]; !ok {
+			res[namedKey.Name] = namedKey.Key
+		}
+	}
+
+	return res
+}
+
+// NewNamedKeysFromMap 은 map을 EE의 canonical 순서로 정렬된 named keys로 변환하는 함수.
+func NewNamedKeysFromMap(keys map[string]Key) NamedKeys {
+	res := make(NamedKeys, 0, len(keys))
+	for name, key := range keys {
+		res = append(res, NewNamedKey(name, key))
+	}
+
+	return res.Sorted()
+}
+
 const (
 	VALIDATOR_PREFIX_POS = iota
 	VALIDATOR_ADDRESS_POS
@@ -310,137 +564,76 @@ const (
 	REWARD_LENGTH = 3
 )
 
+// GetAllValidators 및 아래의 Get* 함수들은 ParsePoSState 결과를 address hex, 금액 문자열로 변환하여 return 한다.
+// 형식이 잘못된 named key는 무시한다.
 func (ns NamedKeys) GetAllValidators() map[string]string {
 	validators := map[string]string{}
-
-	for _, validator := range ns {
-		values := strings.Split(validator.Name, "_")
-
-		if values[VALIDATOR_PREFIX_POS] != VALIDATOR_PREFIX {
-			continue
-		}
-
-		validators[values[VALIDATOR_ADDRESS_POS]] = values[VALIDATOR_STAKE_POS]
+	for _, validator := range ParsePoSState(ns).Validators {
+		validators[validator.Validator.Hex()] = validator.Stake.String()
 	}
 
 	return validators
 }
 
-func (ns NamedKeys) GetValidatorStake(address []byte) string {
-	validators := ns.GetAllValidators()
-	addressStr := hex.EncodeToString(address)
+func (ns NamedKeys) GetValidatorStake(address Address) string {
+	stake, ok := ParsePoSState(ns).ValidatorStake(address)
+	if !ok {
+		return ""
+	}
 
-	return validators[addressStr]
+	return stake.String()
 }
 
-func (ns NamedKeys) GetDelegateFromValidator(address []byte) map[string]string {
+func (ns NamedKeys) GetDelegateFromValidator(address Address) map[string]string {
 	delegators := map[string]string{}
-	addressStr := hex.EncodeToString(address)
-
-	for _, delegator := range ns {
-		values := strings.Split(delegator.Name, "_")
-
-		if values[DELEGATOR_PREFIX_POS] != DELEGATE_PREFIX {
-			continue
-		}
-
-		if values[DELEGATOR_VALIDATOR_POS] == addressStr {
-			delegators[values[DELEGATOR_DELEGATOR_POS]] = values[DELEGATOR_STAKE_POS]
-		}
+	for _, delegation := range ParsePoSState(ns).DelegationsByValidator(address) {
+		delegators[delegation.Delegator.Hex()] = delegation.Amount.String()
 	}
 
 	return delegators
 }
 
-func (ns NamedKeys) GetDelegateFromDelegator(address []byte) map[string]string {
-	delegators := map[string]string{}
-	addressStr := hex.EncodeToString(address)
-
-	for _, delegator := range ns {
-		values := strings.Split(delegator.Name, "_")
-
-		if values[DELEGATOR_PREFIX_POS] != DELEGATE_PREFIX {
-			continue
-		}
-
-		if values[DELEGATOR_DELEGATOR_POS] == addressStr {
-			delegators[values[DELEGATOR_VALIDATOR_POS]] = values[DELEGATOR_STAKE_POS]
-		}
+func (ns NamedKeys) GetDelegateFromDelegator(address Address) map[string]string {
+	validators := map[string]string{}
+	for _, delegation := range ParsePoSState(ns).DelegationsByDelegator(address) {
+		validators[delegation.Validator.Hex()] = delegation.Amount.String()
 	}
 
-	return delegators
+	return validators
 }
 
-func (ns NamedKeys) GetVotingUserFromDapp(address []byte) map[string]string {
+func (ns NamedKeys) GetVotingUserFromDapp(address Address) map[string]string {
 	users := map[string]string{}
-	addressStr := hex.EncodeToString(address)
-
-	for _, user := range ns {
-		values := strings.Split(user.Name, "_")
-
-		if values[VOTE_PREFIX_POS] != VOTE_PREFIX {
-			continue
-		}
-
-		if values[VOTE_DAPP_POS] == addressStr {
-			users[values[VOTE_USER_POS]] = values[VOTE_AMOUNT_POS]
-		}
+	for _, vote := range ParsePoSState(ns).VotesByDapp(address) {
+		users[vote.User.Hex()] = vote.Amount.String()
 	}
 
 	return users
 }
 
-func (ns NamedKeys) GetVotingDappFromUser(address []byte) map[string]string {
+func (ns NamedKeys) GetVotingDappFromUser(address Address) map[string]string {
 	dapps := map[string]string{}
-	addressStr := hex.EncodeToString(address)
-
-	for _, dapp := range ns {
-		values := strings.Split(dapp.Name, "_")
-
-		if values[VOTE_PREFIX_POS] != VOTE_PREFIX {
-			continue
-		}
-
-		if values[VOTE_USER_POS] == addressStr {
-			dapps[values[VOTE_DAPP_POS]] = values[VOTE_AMOUNT_POS]
-		}
+	for _, vote := range ParsePoSState(ns).VotesByUser(address) {
+		dapps[vote.Dapp.Hex()] = vote.Amount.String()
 	}
 
 	return dapps
 }
 
-func (ns NamedKeys) GetValidatorCommission(address []byte) string {
-	addressStr := hex.EncodeToString(address)
-
-	for _, namedkey := range ns {
-		values := strings.Split(namedkey.Name, "_")
-
-		if values[COMMISSION_PREFIX_POS] != COMMISSION_PREFIX {
-			continue
-		}
-
-		if values[COMMISSION_VALIDATOR_POS] == addressStr {
-			return values[COMMISSION_AMOUNT_POS]
-		}
+func (ns NamedKeys) GetValidatorCommission(address Address) string {
+	amount, ok := ParsePoSState(ns).Commission(address)
+	if !ok {
+		return ""
 	}
 
-	return ""
+	return amount.String()
 }
 
-func (ns NamedKeys) GetUserReward(address []byte) string {
-	addressStr := hex.EncodeToString(address)
-
-	for _, namedkey := range ns {
-		values := strings.Split(namedkey.Name, "_")
-
-		if values[REWARD_PREFIX_POS] != REWARD_PREFIX {
-			continue
-		}
-
-		if values[REWARD_VALIDATOR_POS] == addressStr {
-			return values[REWARD_AMOUNT_POS]
-		}
+func (ns NamedKeys) GetUserReward(address Address) string {
+	amount, ok := ParsePoSState(ns).Reward(address)
+	if !ok {
+		return ""
 	}
 
-	return ""
+	return amount.String()
 }
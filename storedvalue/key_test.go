@@ -1,7 +1,10 @@
 package storedvalue
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -9,7 +12,7 @@ import (
 )
 
 func TestKeyHashToBytes(t *testing.T) {
-	key := NewKeyFromHash(make([]byte, 32))
+	key := mustNewKey(NewHashKey(make([]byte, 32)))
 
 	res := key.ToBytes()
 
@@ -23,7 +26,7 @@ func TestKeyHashToBytes(t *testing.T) {
 }
 
 func TestKeyLocalToBytes(t *testing.T) {
-	key := NewKeyFromLocal(make([]byte, 32))
+	key := mustNewKey(NewLocalKey(make([]byte, 32)))
 
 	res := key.ToBytes()
 
@@ -38,7 +41,7 @@ func TestKeyLocalToBytes(t *testing.T) {
 
 func TestKeyUrefToBytes(t *testing.T) {
 	uref := NewURef(make([]byte, 32), state.Key_URef_READ_ADD_WRITE)
-	key := NewKeyFromURef(uref)
+	key := mustNewKey(NewURefKey(uref))
 
 	res := key.ToBytes()
 
@@ -64,12 +67,12 @@ func TestKeyHashFromBytes(t *testing.T) {
 	assert.Equal(
 		t,
 		KEY_ID_HASH,
-		k.KeyID,
+		k.KeyID(),
 	)
 	assert.Equal(
 		t,
 		make([]byte, 32),
-		k.Hash,
+		k.Address(),
 	)
 	assert.Equal(t, len(src), pos)
 }
@@ -86,12 +89,12 @@ func TestKeyLocalFromBytes(t *testing.T) {
 	assert.Equal(
 		t,
 		KEY_ID_LOCAL,
-		k.KeyID,
+		k.KeyID(),
 	)
 	assert.Equal(
 		t,
 		make([]byte, 32),
-		k.Local,
+		k.Address(),
 	)
 	assert.Equal(t, len(src), pos)
 }
@@ -109,17 +112,17 @@ func TestKeyUrefFromBytes(t *testing.T) {
 	assert.Equal(
 		t,
 		KEY_ID_UREF,
-		k.KeyID,
+		k.KeyID(),
 	)
 	assert.Equal(
 		t,
 		make([]byte, 32),
-		k.Uref.Address,
+		k.Address(),
 	)
 	assert.Equal(
 		t,
 		state.Key_URef_READ_ADD_WRITE,
-		k.Uref.AccessRights,
+		k.AccessRights(),
 	)
 	assert.Equal(t, len(src), pos)
 }
@@ -136,6 +139,208 @@ func TestKeyFromBytesError(t *testing.T) {
 	assert.NotEqual(t, len(src), pos)
 }
 
+func mustNewKey(key Key, err error) Key {
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func TestNewKeyError(t *testing.T) {
+	_, err := NewAccountKey(make([]byte, 31))
+	assert.Error(t, err)
+	_, err = NewHashKey(nil)
+	assert.Error(t, err)
+	_, err = NewLocalKey(make([]byte, 33))
+	assert.Error(t, err)
+	_, err = NewURefKey(NewURef(make([]byte, 32), 8))
+	assert.Error(t, err)
+
+	src := append(append([]byte{2}, make([]byte, 32)...), 8)
+	_, err, _ = Key{}.FromBytes(src)
+	assert.Equal(t, &DecodeError{Offset: 33, Msg: "Invalid access rights 8"}, err)
+}
+
+func TestKeyAccessors(t *testing.T) {
+	address := bytes.Repeat([]byte{0x11}, 32)
+	key := mustNewKey(NewURefKey(NewURef(address, state.Key_URef_READ_WRITE)))
+
+	assert.Equal(t, KEY_ID_UREF, key.KeyID())
+	assert.Equal(t, address, key.Address())
+	assert.Equal(t, state.Key_URef_READ_WRITE, key.AccessRights())
+	uref, ok := key.URef()
+	assert.True(t, ok)
+	assert.Equal(t, NewURef(address, state.Key_URef_READ_WRITE), uref)
+
+	// 생성자와 Address 는 address를 복사하므로 원본을 바꾸어도 key는 바뀌지 않는다.
+	address[0] = 0
+	key.Address()[1] = 0
+	assert.Equal(t, bytes.Repeat([]byte{0x11}, 32), key.Address())
+
+	_, ok = mustNewKey(NewHashKey(address)).URef()
+	assert.False(t, ok)
+}
+
+func TestKeyString(t *testing.T) {
+	address := strings.Repeat("ab", 32)
+	addressBytes := bytes.Repeat([]byte{0xab}, 32)
+	values := []struct {
+		key Key
+		str string
+	}{
+		{mustNewKey(NewAccountKey(addressBytes)), "account-" + address},
+		{mustNewKey(NewHashKey(addressBytes)), "hash-" + address},
+		{mustNewKey(NewURefKey(NewURef(addressBytes, state.Key_URef_READ_ADD_WRITE))), "uref-" + address + "-007"},
+		{mustNewKey(NewURefKey(NewURef(addressBytes, state.Key_URef_NONE))), "uref-" + address + "-000"},
+		{mustNewKey(NewLocalKey(addressBytes)), "local-" + address},
+	}
+
+	for _, v := range values {
+		assert.Equal(t, v.str, v.key.String())
+
+		key, err := ParseKey(v.str)
+		assert.NoError(t, err)
+		assert.Equal(t, v.key, key)
+	}
+
+	for _, str := range []string{"", "account", "account-", "account-" + address + "-007", "hash-zz",
+		"uref-" + address, "uref-" + address + "-7", "uref-" + address + "-010", "uref-" + address + "-008",
+		"local-" + address[2:], "Account-" + address} {
+		_, err := ParseKey(str)
+		assert.Error(t, err, str)
+	}
+
+	assert.Panics(t, func() { MustParseKey("hash-00") })
+}
+
+func TestKeyCompare(t *testing.T) {
+	low := make([]byte, 32)
+	high := bytes.Repeat([]byte{0xff}, 32)
+	sorted := []Key{
+		mustNewKey(NewAccountKey(low)),
+		mustNewKey(NewAccountKey(high)),
+		mustNewKey(NewHashKey(low)),
+		mustNewKey(NewURefKey(NewURef(low, state.Key_URef_READ))),
+		mustNewKey(NewURefKey(NewURef(low, state.Key_URef_READ_ADD_WRITE))),
+		mustNewKey(NewURefKey(NewURef(high, state.Key_URef_NONE))),
+		mustNewKey(NewLocalKey(low)),
+	}
+
+	for i, a := range sorted {
+		for j, b := range sorted {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, a.Compare(b), "%s %s", a, b)
+			assert.Equal(t, i == j, a.Equal(b))
+		}
+	}
+
+	assert.Equal(t, Key{}, mustNewKey(NewAccountKey(low)))
+}
+
+func TestKeyMapKey(t *testing.T) {
+	address := bytes.Repeat([]byte{1}, 32)
+	values := map[Key]int{
+		mustNewKey(NewHashKey(address)):  1,
+		mustNewKey(NewLocalKey(address)): 2,
+	}
+	assert.Equal(t, 1, values[mustNewKey(NewHashKey(bytes.Repeat([]byte{1}, 32)))])
+	assert.Equal(t, 2, values[mustNewKey(NewLocalKey(address))])
+
+	res, err := json.Marshal(values)
+	assert.NoError(t, err)
+	var decoded map[Key]int
+	assert.NoError(t, json.Unmarshal(res, &decoded))
+	assert.Equal(t, values, decoded)
+}
+
+func TestKeyFromStateValue(t *testing.T) {
+	key := mustNewKey(NewURefKey(NewURef(bytes.Repeat([]byte{2}, 32), state.Key_URef_ADD)))
+
+	decoded, err := Key{}.FromStateValue(key.ToStateValue())
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	_, err = Key{}.FromStateValue(&state.Key{Value: &state.Key_Hash_{Hash: &state.Key_Hash{Hash: []byte{1}}}})
+	assert.Error(t, err)
+	_, err = Key{}.FromStateValue(&state.Key{})
+	assert.Error(t, err)
+}
+
+func TestNamedKeysLookup(t *testing.T) {
+	mint := mustNewKey(NewURefKey(NewURef(bytes.Repeat([]byte{1}, 32), state.Key_URef_READ)))
+	proxy := mustNewKey(NewHashKey(bytes.Repeat([]byte{2}, 32)))
+	pos := mustNewKey(NewURefKey(NewURef(bytes.Repeat([]byte{3}, 32), state.Key_URef_READ)))
+	other := mustNewKey(NewLocalKey(bytes.Repeat([]byte{4}, 32)))
+	namedKeys := NamedKeys{
+		NewNamedKey("pos", pos),
+		NewNamedKey("mint", mint),
+		NewNamedKey("client_api_proxy", proxy),
+		NewNamedKey("mint", other),
+	}
+
+	key, ok := namedKeys.Get("mint")
+	assert.True(t, ok)
+	assert.Equal(t, mint, key)
+	_, ok = namedKeys.Get("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, NamedKeys{NewNamedKey("client_api_proxy", proxy)}, namedKeys.FilterByKeyID(KEY_ID_HASH))
+	assert.Equal(t, NamedKeys{NewNamedKey("pos", pos), NewNamedKey("mint", mint)}, namedKeys.FilterByKeyID(KEY_ID_UREF))
+	assert.Equal(t, NamedKeys{}, namedKeys.FilterByKeyID(KEY_ID_ACCOUNT))
+
+	assert.Equal(t, map[string]Key{"pos": pos, "mint": mint, "client_api_proxy": proxy}, namedKeys.ToMap())
+
+	sorted := namedKeys.Sorted()
+	assert.Equal(t, NamedKeys{
+		NewNamedKey("client_api_proxy", proxy),
+		NewNamedKey("mint", mint),
+		NewNamedKey("pos", pos),
+	}, sorted)
+	assert.True(t, sorted.IsSorted())
+	assert.False(t, namedKeys.IsSorted())
+	assert.Equal(t, "pos", namedKeys[0].Name)
+	assert.Equal(t, sorted, NewNamedKeysFromMap(namedKeys.ToMap()))
+}
+
+// EE의 named keys는 BTreeMap 순서(이름의 byte 순서)로 serialize 되어 있다.
+func TestNamedKeysSortedMatchesEE(t *testing.T) {
+	for _, src := range decodeTestVectors(t) {
+		storedValue, err, _ := StoredValue{}.FromBytes(src)
+		assert.NoError(t, err)
+
+		namedKeys := storedValue.Account.NamedKeys
+		if storedValue.Type == TYPE_CONTRACT {
+			namedKeys = storedValue.Contract.NamedKeys
+		}
+		if namedKeys == nil {
+			continue
+		}
+		assert.True(t, namedKeys.IsSorted())
+
+		reversed := NamedKeys{}
+		for i := len(namedKeys) - 1; i >= 0; i-- {
+			reversed = append(reversed, namedKeys[i])
+		}
+		assert.Equal(t, namedKeys.ToBytes(), reversed.ToBytes())
+	}
+
+	unsorted := NamedKeys{NewNamedKey("b", Key{}), NewNamedKey("a", Key{}), NewNamedKey("b", Key{keyID: KEY_ID_HASH})}
+	assert.Equal(t, unsorted.Sorted().ToBytes(), unsorted.ToBytes())
+
+	src := NamedKeys{NewNamedKey("a", Key{})}.ToBytes()
+	src[0] = 2
+	src = append(src, NamedKeys{NewNamedKey("a", Key{})}.ToBytes()[SIZE_LENGTH:]...)
+	_, err, _ := NamedKeys{}.FromBytes(src)
+	assert.Error(t, err)
+
+	assert.True(t, NamedKeys{NewNamedKey("B", Key{}), NewNamedKey("a", Key{}), NewNamedKey("ab", Key{})}.IsSorted())
+}
+
 func TestNamedKeysGetAllValidators(t *testing.T) {
 	namedkeys := NamedKeys{
 		NamedKey{Name: "v_d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84_1000000000000000000", Key: Key{}},
@@ -0,0 +1,232 @@
+package storedvalue
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const POS_NAME_SEPARATOR = "_"
+
+// PoSValidator 는 v_<validator>_<stake> 이름으로 저장된 validator의 stake.
+type PoSValidator struct {
+	Validator Address
+	Stake     *big.Int
+}
+
+// PoSDelegation 은 d_<delegator>_<validator>_<amount> 이름으로 저장된 delegation.
+type PoSDelegation struct {
+	Delegator Address
+	Validator Address
+	Amount    *big.Int
+}
+
+// PoSVote 는 a_<user>_<dapp>_<amount> 이름으로 저장된 user의 dapp 투표.
+type PoSVote struct {
+	User   Address
+	Dapp   Address
+	Amount *big.Int
+}
+
+// PoSCommission 은 c_<validator>_<amount> 이름으로 저장된 validator의 commission.
+type PoSCommission struct {
+	Validator Address
+	Amount    *big.Int
+}
+
+// PoSReward 는 r_<user>_<amount> 이름으로 저장된 user의 reward.
+type PoSReward struct {
+	User   Address
+	Amount *big.Int
+}
+
+// PoSNameError 는 PoS prefix를 가지지만 형식이 잘못된 named key 이름을 나타내는 error.
+type PoSNameError struct {
+	Name string
+	Msg  string
+}
+
+func (e *PoSNameError) Error() string {
+	return fmt.Sprintf("Malformed PoS named key %q : %s", e.Name, e.Msg)
+}
+
+// PoSState 는 PoS contract의 named key 이름에 저장된 validator, delegation, vote, commission, reward 정보.
+//
+// 목록은 named key 순서를 따르며, 형식이 잘못된 이름은 목록에서 제외하고 Malformed 에 기록한다.
+type PoSState struct {
+	Validators  []PoSValidator
+	Delegations []PoSDelegation
+	Votes       []PoSVote
+	Commissions []PoSCommission
+	Rewards     []PoSReward
+
+	Malformed []*PoSNameError
+}
+
+// ParsePoSState 는 PoS contract의 named keys를 PoSState 로 변환하는 함수.
+//
+// v_, d_, a_, c_, r_ prefix가 없는 이름(pos_bonding_purse 등)은 무시한다.
+func ParsePoSState(namedKeys NamedKeys) PoSState {
+	posState := PoSState{}
+	for _, namedKey := range namedKeys {
+		if err := posState.add(namedKey.Name); err != nil {
+			posState.Malformed = append(posState.Malformed, err)
+		}
+	}
+
+	return posState
+}
+
+func (p *PoSState) add(name string) *PoSNameError {
+	values := strings.Split(name, POS_NAME_SEPARATOR)
+
+	var expectedLength int
+	switch values[0] {
+	case VALIDATOR_PREFIX:
+		expectedLength = VALIDATOR_LENGTH
+	case DELEGATE_PREFIX:
+		expectedLength = DELEGATE_LENGTH
+	case VOTE_PREFIX:
+		expectedLength = VOTE_LENGTH
+	case COMMISSION_PREFIX:
+		expectedLength = COMMISSION_LENGTH
+	case REWARD_PREFIX:
+		expectedLength = REWARD_LENGTH
+	default:
+		return nil
+	}
+	if len(values) != expectedLength {
+		return &PoSNameError{Name: name, Msg: fmt.Sprintf("%d parts, but %d", expectedLength, len(values))}
+	}
+
+	// 마지막은 금액이고, 나머지는 address 이다.
+	addresses := make([]Address, expectedLength-2)
+	for i := range addresses {
+		address, err := AddressFromHex(values[i+1])
+		if err != nil {
+			return &PoSNameError{Name: name, Msg: err.Error()}
+		}
+		addresses[i] = address
+	}
+	amount, ok := new(big.Int).SetString(values[expectedLength-1], 10)
+	if !ok || amount.Sign() < 0 {
+		return &PoSNameError{Name: name, Msg: fmt.Sprintf("Invalid amount %q", values[expectedLength-1])}
+	}
+
+	switch values[0] {
+	case VALIDATOR_PREFIX:
+		p.Validators = append(p.Validators, PoSValidator{
+			Validator: addresses[VALIDATOR_ADDRESS_POS-1],
+			Stake:     amount})
+	case DELEGATE_PREFIX:
+		p.Delegations = append(p.Delegations, PoSDelegation{
+			Delegator: addresses[DELEGATOR_DELEGATOR_POS-1],
+			Validator: addresses[DELEGATOR_VALIDATOR_POS-1],
+			Amount:    amount})
+	case VOTE_PREFIX:
+		p.Votes = append(p.Votes, PoSVote{
+			User:   addresses[VOTE_USER_POS-1],
+			Dapp:   addresses[VOTE_DAPP_POS-1],
+			Amount: amount})
+	case COMMISSION_PREFIX:
+		p.Commissions = append(p.Commissions, PoSCommission{
+			Validator: addresses[COMMISSION_VALIDATOR_POS-1],
+			Amount:    amount})
+	case REWARD_PREFIX:
+		p.Rewards = append(p.Rewards, PoSReward{
+			User:   addresses[REWARD_VALIDATOR_POS-1],
+			Amount: amount})
+	}
+
+	return nil
+}
+
+// Err 는 형식이 잘못된 이름이 있으면 첫 번째 error를, 없으면 nil을 return 하는 함수.
+func (p PoSState) Err() error {
+	if len(p.Malformed) == 0 {
+		return nil
+	}
+
+	return p.Malformed[0]
+}
+
+// ValidatorStake 는 validator의 stake를 return 하는 함수. 없으면 ok는 false 이다.
+func (p PoSState) ValidatorStake(validator Address) (stake *big.Int, ok bool) {
+	for _, v := range p.Validators {
+		if v.Validator.Equal(validator) {
+			return v.Stake, true
+		}
+	}
+
+	return nil, false
+}
+
+// DelegationsByValidator 는 validator에게 delegate 된 목록을 return 하는 함수.
+func (p PoSState) DelegationsByValidator(validator Address) []PoSDelegation {
+	res := []PoSDelegation{}
+	for _, d := range p.Delegations {
+		if d.Validator.Equal(validator) {
+			res = append(res, d)
+		}
+	}
+
+	return res
+}
+
+// DelegationsByDelegator 는 delegator가 delegate 한 목록을 return 하는 함수.
+func (p PoSState) DelegationsByDelegator(delegator Address) []PoSDelegation {
+	res := []PoSDelegation{}
+	for _, d := range p.Delegations {
+		if d.Delegator.Equal(delegator) {
+			res = append(res, d)
+		}
+	}
+
+	return res
+}
+
+// VotesByUser 는 user가 투표한 목록을 return 하는 함수.
+func (p PoSState) VotesByUser(user Address) []PoSVote {
+	res := []PoSVote{}
+	for _, v := range p.Votes {
+		if v.User.Equal(user) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// VotesByDapp 은 dapp이 받은 투표 목록을 return 하는 함수.
+func (p PoSState) VotesByDapp(dapp Address) []PoSVote {
+	res := []PoSVote{}
+	for _, v := range p.Votes {
+		if v.Dapp.Equal(dapp) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// Commission 은 validator의 commission을 return 하는 함수. 없으면 ok는 false 이다.
+func (p PoSState) Commission(validator Address) (amount *big.Int, ok bool) {
+	for _, c := range p.Commissions {
+		if c.Validator.Equal(validator) {
+			return c.Amount, true
+		}
+	}
+
+	return nil, false
+}
+
+// Reward 는 user의 reward를 return 하는 함수. 없으면 ok는 false 이다.
+func (p PoSState) Reward(user Address) (amount *big.Int, ok bool) {
+	for _, r := range p.Rewards {
+		if r.User.Equal(user) {
+			return r.Amount, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,99 @@
+package storedvalue
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	posTestAddress1 = "d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84"
+	posTestAddress2 = "93236a9263d2ac6198c5ed211774c745d5dc62a910cb84276f8a7c4959208915"
+	posTestDapp     = "0193236a9263d2ac6198c5ed211774c745d5dc62a910cb84276f8a7c4959208915"
+)
+
+func posTestNamedKeys(names ...string) NamedKeys {
+	namedKeys := NamedKeys{}
+	for _, name := range names {
+		namedKeys = append(namedKeys, NewNamedKey(name, Key{}))
+	}
+	return namedKeys
+}
+
+func TestParsePoSState(t *testing.T) {
+	posState := ParsePoSState(posTestNamedKeys(
+		"pos_bonding_purse",
+		"v_"+posTestAddress1+"_4000",
+		"d_"+posTestAddress2+"_"+posTestAddress1+"_1000",
+		"d_"+posTestAddress1+"_"+posTestAddress1+"_3000",
+		"a_"+posTestAddress1+"_"+posTestDapp+"_500",
+		"c_"+posTestAddress1+"_20",
+		"r_"+posTestAddress2+"_30",
+		"mint",
+	))
+
+	assert.NoError(t, posState.Err())
+	address1 := MustParseAddress(posTestAddress1)
+	address2 := MustParseAddress(posTestAddress2)
+	dapp := MustParseAddress(posTestDapp)
+
+	assert.Equal(t, []PoSValidator{{Validator: address1, Stake: big.NewInt(4000)}}, posState.Validators)
+	stake, ok := posState.ValidatorStake(address1)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(4000), stake)
+	_, ok = posState.ValidatorStake(address2)
+	assert.False(t, ok)
+
+	assert.Equal(t, []PoSDelegation{
+		{Delegator: address2, Validator: address1, Amount: big.NewInt(1000)},
+		{Delegator: address1, Validator: address1, Amount: big.NewInt(3000)},
+	}, posState.DelegationsByValidator(address1))
+	assert.Equal(t, []PoSDelegation{{Delegator: address2, Validator: address1, Amount: big.NewInt(1000)}},
+		posState.DelegationsByDelegator(address2))
+	assert.Empty(t, posState.DelegationsByValidator(address2))
+
+	vote := PoSVote{User: address1, Dapp: dapp, Amount: big.NewInt(500)}
+	assert.Equal(t, []PoSVote{vote}, posState.VotesByUser(address1))
+	assert.Equal(t, []PoSVote{vote}, posState.VotesByDapp(dapp))
+	assert.Empty(t, posState.VotesByDapp(address2))
+
+	commission, ok := posState.Commission(address1)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(20), commission)
+	reward, ok := posState.Reward(address2)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(30), reward)
+	_, ok = posState.Reward(address1)
+	assert.False(t, ok)
+}
+
+// prefix는 같지만 형식이 잘못된 이름은 panic 하지 않고 Malformed 에 기록된다.
+func TestParsePoSStateMalformed(t *testing.T) {
+	malformed := []string{
+		"v",
+		"v_" + posTestAddress1,
+		"d_" + posTestAddress1 + "_1000",
+		"a_" + posTestAddress1 + "_" + posTestDapp + "_1_2",
+		"c_zz_10",
+		"r_" + posTestAddress1[2:] + "_10",
+		"v_" + posTestAddress1 + "_-1",
+		"c_" + posTestAddress1 + "_1e3",
+	}
+	posState := ParsePoSState(posTestNamedKeys(append(malformed, "r_"+posTestAddress1+"_7")...))
+
+	assert.Len(t, posState.Malformed, len(malformed))
+	for i, err := range posState.Malformed {
+		assert.Equal(t, malformed[i], err.Name)
+	}
+	assert.Equal(t, posState.Malformed[0], posState.Err())
+	assert.Equal(t, `Malformed PoS named key "v" : 3 parts, but 1`, posState.Err().Error())
+
+	assert.Empty(t, posState.Validators)
+	assert.Equal(t, []PoSReward{{User: MustParseAddress(posTestAddress1), Amount: big.NewInt(7)}}, posState.Rewards)
+
+	namedKeys := posTestNamedKeys(malformed...)
+	assert.Empty(t, namedKeys.GetAllValidators())
+	assert.Empty(t, namedKeys.GetDelegateFromValidator(MustParseAddress(posTestAddress1)))
+	assert.Equal(t, "", namedKeys.GetValidatorCommission(MustParseAddress(posTestAddress1)))
+}
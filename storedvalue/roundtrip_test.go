@@ -0,0 +1,294 @@
+package storedvalue
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// 임의로 생성한 값에 대해 FromBytes(ToBytes(x)) == x 와 ToBytes(FromBytes(b)) == b 를 검사한다.
+// 생성기는 decoder와 같은 모양(빈 slice는 nil이 아닌 빈 slice)의 값을 만든다.
+
+const roundTripMaxCount = 200
+
+func roundTripConfig() *quick.Config {
+	return &quick.Config{MaxCount: roundTripMaxCount, Rand: rand.New(rand.NewSource(39))}
+}
+
+func genBytes(r *rand.Rand, length int) []byte {
+	res := make([]byte, length)
+	r.Read(res)
+	return res
+}
+
+func genURef(r *rand.Rand) URef {
+	return NewURef(genBytes(r, ADDRESS_LENGTH), state.Key_URef_AccessRights(r.Intn(8)))
+}
+
+func genKey(r *rand.Rand) Key {
+	switch KEY_ID(r.Intn(4)) {
+	case KEY_ID_ACCOUNT:
+		return mustNewKey(NewAccountKey(genBytes(r, ADDRESS_LENGTH)))
+	case KEY_ID_HASH:
+		return mustNewKey(NewHashKey(genBytes(r, ADDRESS_LENGTH)))
+	case KEY_ID_UREF:
+		return mustNewKey(NewURefKey(genURef(r)))
+	default:
+		return mustNewKey(NewLocalKey(genBytes(r, ADDRESS_LENGTH)))
+	}
+}
+
+func genString(r *rand.Rand, maxLength int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz_0123456789"
+	res := make([]byte, r.Intn(maxLength+1))
+	for i := range res {
+		res[i] = letters[r.Intn(len(letters))]
+	}
+	return string(res)
+}
+
+func genNamedKeys(r *rand.Rand) NamedKeys {
+	namedKeys := NamedKeys{}
+	for i := r.Intn(4); i > 0; i-- {
+		namedKeys = append(namedKeys, NewNamedKey(genString(r, 12), genKey(r)))
+	}
+	// EE 와 같이 정렬된 named keys 만 round trip 된다.
+	return namedKeys.Sorted()
+}
+
+func genAccount(r *rand.Rand) Account {
+	associatedKeys := []AssociatedKey{}
+	for i := r.Intn(4); i > 0; i-- {
+		associatedKeys = append(associatedKeys, NewAssociatedKey(genBytes(r, ADDRESS_LENGTH), uint32(r.Intn(256))))
+	}
+
+	return NewAccount(
+		genBytes(r, ADDRESS_LENGTH),
+		genNamedKeys(r),
+		genURef(r),
+		associatedKeys,
+		NewActionThresholds(uint32(r.Intn(256)), uint32(r.Intn(256))))
+}
+
+func genContract(r *rand.Rand) Contract {
+	return NewContract(
+		genBytes(r, r.Intn(64)),
+		genNamedKeys(r),
+		NewProtocolVersion(r.Uint32(), r.Uint32(), r.Uint32()))
+}
+
+// genCLType 은 ANY를 제외한 CLType을 depth 단계까지 생성한다.
+func genCLType(r *rand.Rand, depth int) CLType {
+	if depth <= 0 || r.Intn(2) == 0 {
+		return NewSimpleCLType(CL_TYPE_TAG(r.Intn(int(TAG_UREF) + 1)))
+	}
+
+	switch tag := TAG_OPTION + CL_TYPE_TAG(r.Intn(int(TAG_TUPLE3-TAG_OPTION)+1)); tag {
+	case TAG_OPTION:
+		return NewOptionCLType(genCLType(r, depth-1))
+	case TAG_LIST:
+		return NewListCLType(genCLType(r, depth-1))
+	case TAG_FIXED_LIST:
+		return NewFixedListCLType(genCLType(r, depth-1), uint32(r.Intn(4)))
+	case TAG_RESULT:
+		return NewResultCLType(genCLType(r, depth-1), genCLType(r, depth-1))
+	case TAG_MAP:
+		return NewMapCLType(genCLType(r, depth-1), genCLType(r, depth-1))
+	default:
+		elements := []CLType{}
+		for i := TAG_TUPLE1; i <= tag; i++ {
+			elements = append(elements, genCLType(r, depth-1))
+		}
+		clType, _ := NewTupleCLType(elements...)
+		return clType
+	}
+}
+
+func genSizeBytes(size int) []byte {
+	res := make([]byte, SIZE_LENGTH)
+	binary.LittleEndian.PutUint32(res, uint32(size))
+	return res
+}
+
+// genBigIntBytes 는 마지막 byte가 0이 아닌 canonical 한 EE 큰 정수를 생성한다.
+func genBigIntBytes(r *rand.Rand, maxLength int) []byte {
+	length := r.Intn(maxLength + 1)
+	value := genBytes(r, length)
+	if length > 0 && value[length-1] == 0 {
+		value[length-1] = 1
+	}
+	return append([]byte{byte(length)}, value...)
+}
+
+// genCLValueBytes 는 clType 의 serialize 된 값을 생성한다.
+func genCLValueBytes(r *rand.Rand, clType CLType) []byte {
+	switch clType.Tag {
+	case TAG_BOOL, TAG_U8:
+		if clType.Tag == TAG_BOOL {
+			return []byte{byte(r.Intn(2))}
+		}
+		return genBytes(r, 1)
+	case TAG_I32, TAG_U32:
+		return genBytes(r, 4)
+	case TAG_I64, TAG_U64:
+		return genBytes(r, 8)
+	case TAG_U128:
+		return genBigIntBytes(r, 16)
+	case TAG_U256:
+		return genBigIntBytes(r, 32)
+	case TAG_U512:
+		return genBigIntBytes(r, 64)
+	case TAG_UNIT:
+		return []byte{}
+	case TAG_STRING:
+		str := genString(r, 8)
+		return append(genSizeBytes(len(str)), str...)
+	case TAG_KEY:
+		return genKey(r).ToBytes()
+	case TAG_UREF:
+		return genURef(r).ToBytes()
+	case TAG_OPTION:
+		if r.Intn(2) == 0 {
+			return []byte{0}
+		}
+		return append([]byte{1}, genCLValueBytes(r, clType.Inner[0])...)
+	case TAG_LIST:
+		count := r.Intn(3)
+		res := genSizeBytes(count)
+		for i := 0; i < count; i++ {
+			res = append(res, genCLValueBytes(r, clType.Inner[0])...)
+		}
+		return res
+	case TAG_FIXED_LIST:
+		// [u8; N] 는 bytes 그대로, 그 외의 array는 길이가 앞에 붙는다.
+		res := genSizeBytes(int(clType.Length))
+		if clType.Inner[0].Tag == TAG_U8 {
+			res = []byte{}
+		}
+		for i := uint32(0); i < clType.Length; i++ {
+			res = append(res, genCLValueBytes(r, clType.Inner[0])...)
+		}
+		return res
+	case TAG_RESULT:
+		if r.Intn(2) == 0 {
+			return append([]byte{RESULT_ERR_TAG}, genCLValueBytes(r, clType.Inner[1])...)
+		}
+		return append([]byte{RESULT_OK_TAG}, genCLValueBytes(r, clType.Inner[0])...)
+	case TAG_MAP:
+		count := r.Intn(3)
+		res := genSizeBytes(count)
+		for i := 0; i < count; i++ {
+			res = append(res, genCLValueBytes(r, clType.Inner[0])...)
+			res = append(res, genCLValueBytes(r, clType.Inner[1])...)
+		}
+		return res
+	default:
+		res := []byte{}
+		for _, inner := range clType.Inner {
+			res = append(res, genCLValueBytes(r, inner)...)
+		}
+		return res
+	}
+}
+
+func genCLValue(r *rand.Rand) CLValue {
+	clType := genCLType(r, 3)
+	return NewClValue(genCLValueBytes(r, clType), clType)
+}
+
+func genStoredValue(r *rand.Rand) StoredValue {
+	switch r.Intn(3) {
+	case 0:
+		return StoredValue{Type: TYPE_CL_VALUE, ClValue: genCLValue(r)}
+	case 1:
+		return StoredValue{Type: TYPE_ACCOUNT, Account: genAccount(r)}
+	default:
+		return StoredValue{Type: TYPE_CONTRACT, Contract: genContract(r)}
+	}
+}
+
+// roundTripCheck 는 gen 으로 만든 값의 ToBytes 를 decode 하여 같은 값, 같은 bytes가 되는지 검사한다.
+func roundTripCheck(t *testing.T, gen func(r *rand.Rand) interface{},
+	toBytes func(value interface{}) []byte, fromBytes func(src []byte) (interface{}, error, int)) {
+	property := func(value interface{}) bool {
+		src := toBytes(value)
+
+		decoded, err, pos := fromBytes(src)
+		if !assert.NoError(t, err) || !assert.Equal(t, len(src), pos) || !assert.Equal(t, value, decoded) {
+			return false
+		}
+
+		return assert.Equal(t, src, toBytes(decoded))
+	}
+
+	config := roundTripConfig()
+	config.Values = func(values []reflect.Value, r *rand.Rand) {
+		values[0] = reflect.ValueOf(gen(r))
+	}
+	assert.NoError(t, quick.Check(property, config))
+}
+
+func TestAccountRoundTrip(t *testing.T) {
+	roundTripCheck(t,
+		func(r *rand.Rand) interface{} { return genAccount(r) },
+		func(value interface{}) []byte { return value.(Account).ToBytes() },
+		func(src []byte) (interface{}, error, int) { return Account{}.FromBytes(src) })
+}
+
+func TestContractRoundTrip(t *testing.T) {
+	roundTripCheck(t,
+		func(r *rand.Rand) interface{} { return genContract(r) },
+		func(value interface{}) []byte { return value.(Contract).ToBytes() },
+		func(src []byte) (interface{}, error, int) { return Contract{}.FromBytes(src) })
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	roundTripCheck(t,
+		func(r *rand.Rand) interface{} { return genKey(r) },
+		func(value interface{}) []byte { return value.(Key).ToBytes() },
+		func(src []byte) (interface{}, error, int) { return Key{}.FromBytes(src) })
+}
+
+func TestCLValueRoundTrip(t *testing.T) {
+	roundTripCheck(t,
+		func(r *rand.Rand) interface{} { return genCLValue(r) },
+		func(value interface{}) []byte { return value.(CLValue).ToBytes() },
+		func(src []byte) (interface{}, error, int) { return CLValue{}.FromBytes(src) })
+}
+
+func TestStoredValueRoundTrip(t *testing.T) {
+	roundTripCheck(t,
+		func(r *rand.Rand) interface{} { return genStoredValue(r) },
+		func(value interface{}) []byte {
+			res, err := value.(StoredValue).ToBytes()
+			assert.NoError(t, err)
+			return res
+		},
+		func(src []byte) (interface{}, error, int) { return StoredValue{}.FromBytes(src) })
+}
+
+// 생성한 CL value는 CLValueInstance 로 decode 할 수 있는 EE 형식이어야 한다.
+func TestGeneratedCLValueIsValid(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	for i := 0; i < roundTripMaxCount; i++ {
+		clValue := genCLValue(r)
+		_, err := clValue.ToCLInstanceValue()
+		assert.NoError(t, err, clValue.Type.String())
+	}
+}
+
+func TestDecodeTestVectorsRoundTrip(t *testing.T) {
+	for _, src := range decodeTestVectors(t) {
+		storedValue, err, pos := StoredValue{}.FromBytes(src)
+		assert.NoError(t, err)
+		assert.Equal(t, len(src), pos)
+		res, err := storedValue.ToBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, src, res)
+	}
+}
@@ -1,7 +1,9 @@
 package storedvalue
 
 import (
-	"errors"
+	"fmt"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 )
 
 type STORED_VALUE_TYPE = int
@@ -10,6 +12,14 @@ const (
 	TYPE_CL_VALUE = iota
 	TYPE_ACCOUNT
 	TYPE_CONTRACT
+
+	// STORED_VALUE_FORMAT_V2 에만 있는 type. serialize 된 type byte와는 다르다.
+	TYPE_CONTRACT_WASM
+	TYPE_CONTRACT_V2
+	TYPE_CONTRACT_PACKAGE
+	TYPE_TRANSFER
+	TYPE_DEPLOY_INFO
+	TYPE_ERA_INFO
 )
 
 const (
@@ -25,6 +35,13 @@ type StoredValue struct {
 	ClValue  CLValue           `json:"cl_value"`
 	Account  Account           `json:"account"`
 	Contract Contract          `json:"contract"`
+
+	ContractWasm    ContractWasm    `json:"contract_wasm"`
+	ContractV2      ContractV2      `json:"contract_v2"`
+	ContractPackage ContractPackage `json:"contract_package"`
+	Transfer        Transfer        `json:"transfer"`
+	DeployInfo      DeployInfo      `json:"deploy_info"`
+	EraInfo         EraInfo         `json:"era_info"`
 }
 
 func NewStoredValueFromClValue(clValue CLValue) StoredValue {
@@ -48,44 +65,98 @@ func NewStoredValueFromContract(contract Contract) StoredValue {
 	}
 }
 
+// FromBytes 는 STORED_VALUE_FORMAT_V1 형식으로 serialize 된 StoredValue를 decode 하는 함수.
 func (s StoredValue) FromBytes(src []byte) (storedvalue StoredValue, err error, pos int) {
-	pos = STORED_VALUE_TYPE_POS
-	pos += STORED_VALUE_TYPE_LENGTH
+	return s.FromBytesWithFormat(src, STORED_VALUE_FORMAT_V1)
+}
 
-	switch STORED_VALUE_TYPE(src[STORED_VALUE_TYPE_POS]) {
+// ToBytes 는 StoredValue를 STORED_VALUE_FORMAT_V1 형식으로 serialize 하는 함수.
+//
+// Transfer 등 V1 에 없는 type이면 error를 return 한다. 그런 값은 ToBytesWithFormat 으로 V2 형식을 사용한다.
+func (s StoredValue) ToBytes() ([]byte, error) {
+	return s.ToBytesWithFormat(STORED_VALUE_FORMAT_V1)
+}
+
+// ToStateValue 는 transforms.TransformWrite 등에서 사용하는 state.StoredValue 로 변환하는 함수.
+func (s StoredValue) ToStateValue() (*state.StoredValue, error) {
+	switch s.Type {
 	case TYPE_CL_VALUE:
-		var clValue CLValue
-		clValue, err, length := clValue.FromBytes(src[pos:])
+		clValue, err := s.ClValue.ToStateCLValue()
 		if err != nil {
-			return StoredValue{}, err, pos
+			return nil, err
 		}
-
-		s.Type = TYPE_CL_VALUE
-		s.ClValue = clValue
-		pos += length
+		return &state.StoredValue{Variants: &state.StoredValue_ClValue{ClValue: clValue}}, nil
 	case TYPE_ACCOUNT:
+		return &state.StoredValue{Variants: &state.StoredValue_Account{Account: s.Account.ToStateValue()}}, nil
+	case TYPE_CONTRACT:
+		return &state.StoredValue{Variants: &state.StoredValue_Contract{Contract: s.Contract.ToStateValue()}}, nil
+	default:
+		return nil, fmt.Errorf("Unknown StoredValue type %d", s.Type)
+	}
+}
+
+// FromStateValue 는 state.StoredValue 를 StoredValue 로 변환하는 함수.
+func (s StoredValue) FromStateValue(storedValue *state.StoredValue) (StoredValue, error) {
+	switch storedValue.GetVariants().(type) {
+	case *state.StoredValue_ClValue:
+		var clValue CLValue
+		clValue, err := clValue.FromStateCLValue(storedValue.GetClValue())
+		if err != nil {
+			return StoredValue{}, err
+		}
+		return NewStoredValueFromClValue(clValue), nil
+	case *state.StoredValue_Account:
 		var account Account
-		account, err, length := account.FromBytes(src[pos:])
+		account, err := account.FromStateValue(storedValue.GetAccount())
 		if err != nil {
-			return StoredValue{}, err, pos
+			return StoredValue{}, err
 		}
-
-		s.Type = TYPE_ACCOUNT
-		s.Account = account
-		pos += length
-	case TYPE_CONTRACT:
+		return NewStoredValueFromAccount(account), nil
+	case *state.StoredValue_Contract:
 		var contract Contract
-		contract, err, length := contract.FromBytes(src[pos:])
+		contract, err := contract.FromStateValue(storedValue.GetContract())
 		if err != nil {
-			return StoredValue{}, err, pos
+			return StoredValue{}, err
 		}
+		return NewStoredValueFromContract(contract), nil
+	default:
+		return StoredValue{}, fmt.Errorf("StoredValue data is invalid.")
+	}
+}
 
-		s.Type = TYPE_CONTRACT
-		s.Contract = contract
-		pos += length
+// ToStateValueInstance 는 CL value를 type에 맞게 decode 한 state.StoredValueInstance 로 변환하는 함수.
+func (s StoredValue) ToStateValueInstance() (*state.StoredValueInstance, error) {
+	switch s.Type {
+	case TYPE_CL_VALUE:
+		clValue, err := s.ClValue.ToCLValueInstance()
+		if err != nil {
+			return nil, err
+		}
+		return &state.StoredValueInstance{Value: &state.StoredValueInstance_ClValue{ClValue: clValue}}, nil
+	case TYPE_ACCOUNT:
+		return &state.StoredValueInstance{Value: &state.StoredValueInstance_Account{Account: s.Account.ToStateValue()}}, nil
+	case TYPE_CONTRACT:
+		return &state.StoredValueInstance{Value: &state.StoredValueInstance_Contract{Contract: s.Contract.ToStateValue()}}, nil
 	default:
-		return StoredValue{}, errors.New(`StoredValue data is invalid.`), pos
+		return nil, fmt.Errorf("Unknown StoredValue type %d", s.Type)
 	}
+}
 
-	return s, nil, pos
+// FromStateValueInstance 는 state.StoredValueInstance 를 StoredValue 로 변환하는 함수.
+func (s StoredValue) FromStateValueInstance(storedValue *state.StoredValueInstance) (StoredValue, error) {
+	switch storedValue.GetValue().(type) {
+	case *state.StoredValueInstance_ClValue:
+		var clValue CLValue
+		clValue, err := clValue.FromCLValueInstance(storedValue.GetClValue())
+		if err != nil {
+			return StoredValue{}, err
+		}
+		return NewStoredValueFromClValue(clValue), nil
+	case *state.StoredValueInstance_Account:
+		return s.FromStateValue(&state.StoredValue{Variants: &state.StoredValue_Account{Account: storedValue.GetAccount()}})
+	case *state.StoredValueInstance_Contract:
+		return s.FromStateValue(&state.StoredValue{Variants: &state.StoredValue_Contract{Contract: storedValue.GetContract()}})
+	default:
+		return StoredValue{}, fmt.Errorf("StoredValueInstance data is invalid.")
+	}
 }
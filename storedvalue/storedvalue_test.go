@@ -2,9 +2,12 @@ package storedvalue
 
 import (
 	"encoding/hex"
+	"math/rand"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc/transforms"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,10 +35,10 @@ func TestStoredValueAbi(t *testing.T) {
 		account.NamedKeys[0].Name)
 	assert.Equal(t,
 		mintAddressBytes,
-		account.NamedKeys[0].Key.Uref.Address)
+		account.NamedKeys[0].Key.Address())
 	assert.Equal(t,
 		state.Key_URef_READ,
-		account.NamedKeys[0].Key.Uref.AccessRights)
+		account.NamedKeys[0].Key.AccessRights())
 
 	posAddressBytes, err := hex.DecodeString("bb0d91b8604970a269bf96ac55de5fa416135e2837d88a0bac938e2eca2d0fe2")
 	assert.NoError(t, err)
@@ -44,10 +47,10 @@ func TestStoredValueAbi(t *testing.T) {
 		account.NamedKeys[1].Name)
 	assert.Equal(t,
 		posAddressBytes,
-		account.NamedKeys[1].Key.Uref.Address)
+		account.NamedKeys[1].Key.Address())
 	assert.Equal(t,
 		state.Key_URef_READ,
-		account.NamedKeys[1].Key.Uref.AccessRights)
+		account.NamedKeys[1].Key.AccessRights())
 
 	purseIdAddress, err := hex.DecodeString("2efe91034583b378b4b9ffcc62b642650f5d455c4665f4206168ed0637ff7a70")
 	assert.NoError(t, err)
@@ -71,3 +74,72 @@ func TestStoredValueAbi(t *testing.T) {
 		uint32(1),
 		account.ActionThresholds.KeyManagementThreshold)
 }
+
+func TestStoredValueStateValueRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < roundTripMaxCount; i++ {
+		storedValue := genStoredValue(r)
+
+		stateValue, err := storedValue.ToStateValue()
+		assert.NoError(t, err)
+		// gogo proto 로 marshal 한 후에도 같은 값으로 변환되어야 한다.
+		marshaled, err := proto.Marshal(stateValue)
+		assert.NoError(t, err)
+		unmarshaled := &state.StoredValue{}
+		assert.NoError(t, proto.Unmarshal(marshaled, unmarshaled))
+
+		expected, err := storedValue.ToBytes()
+		assert.NoError(t, err)
+
+		converted, err := StoredValue{}.FromStateValue(unmarshaled)
+		assert.NoError(t, err)
+		res, err := converted.ToBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+
+		instance, err := storedValue.ToStateValueInstance()
+		assert.NoError(t, err)
+		converted, err = StoredValue{}.FromStateValueInstance(instance)
+		assert.NoError(t, err)
+		res, err = converted.ToBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+	}
+}
+
+func TestStoredValueFromTransformWrite(t *testing.T) {
+	src, err := hex.DecodeString("01" + decodeTestAccountHex)
+	assert.NoError(t, err)
+	var storedValue StoredValue
+	storedValue, err, _ = storedValue.FromBytes(src)
+	assert.NoError(t, err)
+
+	stateValue, err := storedValue.ToStateValue()
+	assert.NoError(t, err)
+	transform := &transforms.Transform{TransformInstance: &transforms.Transform_Write{
+		Write: &transforms.TransformWrite{Value: stateValue}}}
+
+	converted, err := StoredValue{}.FromStateValue(transform.GetWrite().GetValue())
+	assert.NoError(t, err)
+	assert.Equal(t, storedValue, converted)
+	assert.Equal(t, "mint", converted.Account.NamedKeys[0].Name)
+}
+
+func TestStoredValueStateValueError(t *testing.T) {
+	_, err := StoredValue{}.FromStateValue(&state.StoredValue{})
+	assert.Error(t, err)
+	_, err = StoredValue{}.FromStateValue(&state.StoredValue{Variants: &state.StoredValue_ClValue{ClValue: &state.CLValue{}}})
+	assert.Error(t, err)
+	_, err = StoredValue{}.FromStateValueInstance(&state.StoredValueInstance{})
+	assert.Error(t, err)
+
+	// Account 의 named key 변환 error 는 그대로 return 해야 한다.
+	_, err = StoredValue{}.FromStateValue(&state.StoredValue{Variants: &state.StoredValue_Account{Account: &state.Account{
+		NamedKeys: []*state.NamedKey{{Name: "invalid", Key: &state.Key{}}}}}})
+	assert.Error(t, err)
+
+	_, err = StoredValue{Type: 3}.ToStateValue()
+	assert.Error(t, err)
+	_, err = NewStoredValueFromClValue(NewClValue([]byte{2}, NewSimpleCLType(TAG_BOOL))).ToStateValueInstance()
+	assert.Error(t, err)
+}
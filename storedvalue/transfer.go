@@ -0,0 +1,316 @@
+package storedvalue
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+const (
+	U512_MAX_LENGTH = 64
+
+	OPTION_NONE = 0
+	OPTION_SOME = 1
+)
+
+// PUBLIC_KEY_ALGORITHM 은 PublicKey 의 tag.
+type PUBLIC_KEY_ALGORITHM int
+
+const (
+	PUBLIC_KEY_SYSTEM PUBLIC_KEY_ALGORITHM = iota
+	PUBLIC_KEY_ED25519
+	PUBLIC_KEY_SECP256K1
+)
+
+// publicKeyLengths 는 algorithm 별 public key bytes 길이.
+var publicKeyLengths = map[PUBLIC_KEY_ALGORITHM]int{
+	PUBLIC_KEY_SYSTEM:    0,
+	PUBLIC_KEY_ED25519:   32,
+	PUBLIC_KEY_SECP256K1: 33,
+}
+
+// PublicKey 는 STORED_VALUE_FORMAT_V2 에서 algorithm tag 와 bytes 로 serialize 되는 public key.
+type PublicKey struct {
+	Algorithm PUBLIC_KEY_ALGORITHM
+	Bytes     []byte
+}
+
+func NewPublicKey(algorithm PUBLIC_KEY_ALGORITHM, bytes []byte) (PublicKey, error) {
+	length, ok := publicKeyLengths[algorithm]
+	if !ok {
+		return PublicKey{}, fmt.Errorf("Unknown PublicKey algorithm %d", algorithm)
+	}
+	if len(bytes) != length {
+		return PublicKey{}, fmt.Errorf("PublicKey bytes length must be %d, but %d", length, len(bytes))
+	}
+
+	return PublicKey{Algorithm: algorithm, Bytes: bytes}, nil
+}
+
+func (p PublicKey) FromBytes(src []byte) (publicKey PublicKey, err error, pos int) {
+	if err := checkLength(src, pos, TAG_LENGTH, "PublicKey"); err != nil {
+		return PublicKey{}, err, pos
+	}
+	algorithm := PUBLIC_KEY_ALGORITHM(src[pos])
+	length, ok := publicKeyLengths[algorithm]
+	if !ok {
+		return PublicKey{}, decodeErrorf(pos, "Unknown PublicKey algorithm %d", src[pos]), pos
+	}
+	pos += TAG_LENGTH
+
+	bytes, pos, err := bytesFromBytes(src, pos, length, "PublicKey bytes")
+	if err != nil {
+		return PublicKey{}, err, pos
+	}
+
+	return PublicKey{Algorithm: algorithm, Bytes: bytes}, nil, pos
+}
+
+func (p PublicKey) ToBytes() []byte {
+	return append([]byte{byte(p.Algorithm)}, p.Bytes...)
+}
+
+// Transfer 는 STORED_VALUE_FORMAT_V2 에서 deploy가 실행한 transfer 기록. To 가 없으면 nil 이다.
+type Transfer struct {
+	DeployHash []byte   `json:"deploy_hash"`
+	From       []byte   `json:"from"`
+	To         []byte   `json:"to"`
+	Source     URef     `json:"source"`
+	Target     URef     `json:"target"`
+	Amount     *big.Int `json:"amount"`
+	Gas        *big.Int `json:"gas"`
+	ID         *uint64  `json:"id"`
+}
+
+func (t Transfer) FromBytes(src []byte) (transfer Transfer, err error, pos int) {
+	transfer.DeployHash, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "Transfer deploy hash")
+	if err != nil {
+		return Transfer{}, err, pos
+	}
+	transfer.From, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "Transfer from")
+	if err != nil {
+		return Transfer{}, err, pos
+	}
+
+	some, pos, err := optionFromBytes(src, pos, "Transfer to")
+	if err != nil {
+		return Transfer{}, err, pos
+	}
+	if some {
+		transfer.To, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "Transfer to")
+		if err != nil {
+			return Transfer{}, err, pos
+		}
+	}
+
+	for _, uref := range []*URef{&transfer.Source, &transfer.Target} {
+		var length int
+		*uref, err, length = URef{}.FromBytes(src[pos:])
+		if err != nil {
+			return Transfer{}, shiftDecodeError(err, pos), pos
+		}
+		pos += length
+	}
+
+	transfer.Amount, pos, err = bigIntFromBytes(src, pos, U512_MAX_LENGTH, "Transfer amount")
+	if err != nil {
+		return Transfer{}, err, pos
+	}
+	transfer.Gas, pos, err = bigIntFromBytes(src, pos, U512_MAX_LENGTH, "Transfer gas")
+	if err != nil {
+		return Transfer{}, err, pos
+	}
+
+	some, pos, err = optionFromBytes(src, pos, "Transfer id")
+	if err != nil {
+		return Transfer{}, err, pos
+	}
+	if some {
+		id, next, err := bytesFromBytes(src, pos, LONG_LENGTH, "Transfer id")
+		if err != nil {
+			return Transfer{}, err, pos
+		}
+		value := binary.LittleEndian.Uint64(id)
+		transfer.ID, pos = &value, next
+	}
+
+	return transfer, nil, pos
+}
+
+func (t Transfer) ToBytes() []byte {
+	res := append([]byte{}, t.DeployHash...)
+	res = append(res, t.From...)
+	if t.To == nil {
+		res = append(res, OPTION_NONE)
+	} else {
+		res = append(res, OPTION_SOME)
+		res = append(res, t.To...)
+	}
+	res = append(res, t.Source.ToBytes()...)
+	res = append(res, t.Target.ToBytes()...)
+	res = append(res, bigIntToBytes(t.Amount)...)
+	res = append(res, bigIntToBytes(t.Gas)...)
+	if t.ID == nil {
+		return append(res, OPTION_NONE)
+	}
+	id := make([]byte, LONG_LENGTH)
+	binary.LittleEndian.PutUint64(id, *t.ID)
+
+	return append(append(res, OPTION_SOME), id...)
+}
+
+// DeployInfo 는 STORED_VALUE_FORMAT_V2 에서 실행된 deploy의 정보. Transfers 는 Transfer key의 address 목록이다.
+type DeployInfo struct {
+	DeployHash []byte   `json:"deploy_hash"`
+	Transfers  [][]byte `json:"transfers"`
+	From       []byte   `json:"from"`
+	Source     URef     `json:"source"`
+	Gas        *big.Int `json:"gas"`
+}
+
+func (d DeployInfo) FromBytes(src []byte) (deployInfo DeployInfo, err error, pos int) {
+	deployInfo.DeployHash, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "DeployInfo deploy hash")
+	if err != nil {
+		return DeployInfo{}, err, pos
+	}
+
+	transfersSize, err := sizeFromBytes(src, pos, "DeployInfo transfers")
+	if err != nil {
+		return DeployInfo{}, err, pos
+	}
+	pos += SIZE_LENGTH
+	deployInfo.Transfers = [][]byte{}
+	for i := 0; i < transfersSize; i++ {
+		var transfer []byte
+		transfer, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "DeployInfo transfer")
+		if err != nil {
+			return DeployInfo{}, err, pos
+		}
+		deployInfo.Transfers = append(deployInfo.Transfers, transfer)
+	}
+
+	deployInfo.From, pos, err = bytesFromBytes(src, pos, ADDRESS_LENGTH, "DeployInfo from")
+	if err != nil {
+		return DeployInfo{}, err, pos
+	}
+
+	source, err, length := URef{}.FromBytes(src[pos:])
+	if err != nil {
+		return DeployInfo{}, shiftDecodeError(err, pos), pos
+	}
+	pos += length
+	deployInfo.Source = source
+
+	deployInfo.Gas, pos, err = bigIntFromBytes(src, pos, U512_MAX_LENGTH, "DeployInfo gas")
+	if err != nil {
+		return DeployInfo{}, err, pos
+	}
+
+	return deployInfo, nil, pos
+}
+
+func (d DeployInfo) ToBytes() []byte {
+	res := append([]byte{}, d.DeployHash...)
+	res = append(res, sizeToBytes(len(d.Transfers))...)
+	for _, transfer := range d.Transfers {
+		res = append(res, transfer...)
+	}
+	res = append(res, d.From...)
+	res = append(res, d.Source.ToBytes()...)
+
+	return append(res, bigIntToBytes(d.Gas)...)
+}
+
+const (
+	SEIGNIORAGE_ALLOCATION_VALIDATOR = 0
+	SEIGNIORAGE_ALLOCATION_DELEGATOR = 1
+)
+
+// SeigniorageAllocation 은 era 에서 validator 또는 delegator 에게 할당된 보상.
+// validator 에 대한 할당이면 Delegator 는 nil 이다.
+type SeigniorageAllocation struct {
+	Delegator *PublicKey `json:"delegator"`
+	Validator PublicKey  `json:"validator"`
+	Amount    *big.Int   `json:"amount"`
+}
+
+// EraInfo 는 STORED_VALUE_FORMAT_V2 에서 era 의 seigniorage 할당 목록.
+type EraInfo struct {
+	SeigniorageAllocations []SeigniorageAllocation `json:"seigniorage_allocations"`
+}
+
+func (e EraInfo) FromBytes(src []byte) (eraInfo EraInfo, err error, pos int) {
+	allocationsSize, err := sizeFromBytes(src, pos, "EraInfo seigniorage allocations")
+	if err != nil {
+		return EraInfo{}, err, pos
+	}
+	pos += SIZE_LENGTH
+
+	eraInfo.SeigniorageAllocations = []SeigniorageAllocation{}
+	for i := 0; i < allocationsSize; i++ {
+		if err := checkLength(src, pos, TAG_LENGTH, "SeigniorageAllocation"); err != nil {
+			return EraInfo{}, err, pos
+		}
+		tag := src[pos]
+		if tag != SEIGNIORAGE_ALLOCATION_VALIDATOR && tag != SEIGNIORAGE_ALLOCATION_DELEGATOR {
+			return EraInfo{}, decodeErrorf(pos, "SeigniorageAllocation tag must be 0 or 1, but %d", tag), pos
+		}
+		pos += TAG_LENGTH
+
+		var allocation SeigniorageAllocation
+		if tag == SEIGNIORAGE_ALLOCATION_DELEGATOR {
+			delegator, err, length := PublicKey{}.FromBytes(src[pos:])
+			if err != nil {
+				return EraInfo{}, shiftDecodeError(err, pos), pos
+			}
+			pos += length
+			allocation.Delegator = &delegator
+		}
+		validator, err, length := PublicKey{}.FromBytes(src[pos:])
+		if err != nil {
+			return EraInfo{}, shiftDecodeError(err, pos), pos
+		}
+		pos += length
+		allocation.Validator = validator
+
+		allocation.Amount, pos, err = bigIntFromBytes(src, pos, U512_MAX_LENGTH, "SeigniorageAllocation amount")
+		if err != nil {
+			return EraInfo{}, err, pos
+		}
+
+		eraInfo.SeigniorageAllocations = append(eraInfo.SeigniorageAllocations, allocation)
+	}
+
+	return eraInfo, nil, pos
+}
+
+func (e EraInfo) ToBytes() []byte {
+	res := sizeToBytes(len(e.SeigniorageAllocations))
+	for _, allocation := range e.SeigniorageAllocations {
+		if allocation.Delegator == nil {
+			res = append(res, SEIGNIORAGE_ALLOCATION_VALIDATOR)
+		} else {
+			res = append(res, SEIGNIORAGE_ALLOCATION_DELEGATOR)
+			res = append(res, allocation.Delegator.ToBytes()...)
+		}
+		res = append(res, allocation.Validator.ToBytes()...)
+		res = append(res, bigIntToBytes(allocation.Amount)...)
+	}
+
+	return res
+}
+
+// optionFromBytes 는 pos 위치의 Option tag 를 읽어 값이 있는지 return 하는 함수.
+func optionFromBytes(src []byte, pos int, name string) (bool, int, error) {
+	if err := checkLength(src, pos, OPTION_SIZE_LENGTH, name); err != nil {
+		return false, pos, err
+	}
+	switch src[pos] {
+	case OPTION_NONE:
+		return false, pos + OPTION_SIZE_LENGTH, nil
+	case OPTION_SOME:
+		return true, pos + OPTION_SIZE_LENGTH, nil
+	default:
+		return false, pos, decodeErrorf(pos, "%s Option tag must be 0 or 1, but %d", name, src[pos])
+	}
+}
@@ -0,0 +1,458 @@
+package storedvalue
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+)
+
+const (
+	U128_BIT_WIDTH = 128
+	U256_BIT_WIDTH = 256
+	U512_BIT_WIDTH = 512
+)
+
+// UintRangeError 는 U128, U256, U512 의 범위를 벗어난 값 또는 연산 결과를 나타내는 error.
+type UintRangeError struct {
+	Type  string
+	Value *big.Int
+}
+
+func (e *UintRangeError) Error() string {
+	if e.Value.Sign() < 0 {
+		return fmt.Sprintf("%s underflow : %s", e.Type, e.Value)
+	}
+
+	return fmt.Sprintf("%s overflow : %s", e.Type, e.Value)
+}
+
+// uintN 은 U128, U256, U512 가 공유하는 unsigned integer 값. zero value 는 0 이며, 값은 변경되지 않는다.
+type uintN struct {
+	value *big.Int
+}
+
+// uintKind 는 uintN 의 bit width, CL type tag 와 error 에 쓰이는 이름.
+type uintKind struct {
+	bitWidth int
+	tag      CL_TYPE_TAG
+	name     string
+}
+
+var (
+	u128Kind = uintKind{bitWidth: U128_BIT_WIDTH, tag: TAG_U128, name: "U128"}
+	u256Kind = uintKind{bitWidth: U256_BIT_WIDTH, tag: TAG_U256, name: "U256"}
+	u512Kind = uintKind{bitWidth: U512_BIT_WIDTH, tag: TAG_U512, name: "U512"}
+)
+
+// check 는 value 가 k 의 범위인지 검사하고 복사본을 return 하는 함수. nil 은 0 이다.
+func (k uintKind) check(value *big.Int) (uintN, error) {
+	if value == nil {
+		return uintN{value: new(big.Int)}, nil
+	}
+	if value.Sign() < 0 || value.BitLen() > k.bitWidth {
+		return uintN{}, &UintRangeError{Type: k.name, Value: new(big.Int).Set(value)}
+	}
+
+	return uintN{value: new(big.Int).Set(value)}, nil
+}
+
+func (k uintKind) parse(str string) (uintN, error) {
+	value, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return uintN{}, fmt.Errorf("Invalid %s %q", k.name, str)
+	}
+
+	return k.check(value)
+}
+
+// operation 은 a op b 를 계산하고 결과가 k 의 범위인지 검사하는 함수. 0으로 나누면 error 이다.
+func (k uintKind) operation(a, b uintN, op byte) (uintN, error) {
+	x, y := a.bigInt(), b.bigInt()
+	res := new(big.Int)
+	switch op {
+	case '+':
+		res.Add(x, y)
+	case '-':
+		res.Sub(x, y)
+	case '*':
+		res.Mul(x, y)
+	case '/', '%':
+		if y.Sign() == 0 {
+			return uintN{}, fmt.Errorf("%s division by zero", k.name)
+		}
+		if op == '/' {
+			res.Quo(x, y)
+		} else {
+			res.Rem(x, y)
+		}
+	}
+
+	return k.check(res)
+}
+
+func (k uintKind) fromBytes(src []byte) (uintN, error, int) {
+	value, pos, err := bigIntFromBytes(src, 0, k.bitWidth/8, k.name)
+	if err != nil {
+		return uintN{}, err, pos
+	}
+
+	return uintN{value: value}, nil, pos
+}
+
+func (k uintKind) toCLValue(u uintN) CLValue {
+	return NewClValue(u.ToBytes(), NewSimpleCLType(k.tag))
+}
+
+func (k uintKind) toStateBigInt(u uintN) *state.BigInt {
+	return &state.BigInt{Value: u.String(), BitWidth: uint32(k.bitWidth)}
+}
+
+// fromStateBigInt 는 state.BigInt 를 변환하는 함수. BitWidth 는 k 의 bit width 이어야 한다.
+func (k uintKind) fromStateBigInt(bigInt *state.BigInt) (uintN, error) {
+	if int(bigInt.GetBitWidth()) != k.bitWidth {
+		return uintN{}, fmt.Errorf("%s bit width must be %d, but %d", k.name, k.bitWidth, bigInt.GetBitWidth())
+	}
+
+	return k.parse(bigInt.GetValue())
+}
+
+func uintFromUint64(value uint64) uintN {
+	return uintN{value: new(big.Int).SetUint64(value)}
+}
+
+// bigInt 은 zero value 의 nil 을 0 으로 바꾸는 함수.
+func (u uintN) bigInt() *big.Int {
+	if u.value == nil {
+		return new(big.Int)
+	}
+
+	return u.value
+}
+
+// BigInt 는 값의 복사본을 return 하는 함수.
+func (u uintN) BigInt() *big.Int {
+	return new(big.Int).Set(u.bigInt())
+}
+
+func (u uintN) String() string {
+	return u.bigInt().String()
+}
+
+func (u uintN) IsZero() bool {
+	return u.bigInt().Sign() == 0
+}
+
+// cmp 는 u 가 other 보다 작으면 -1, 같으면 0, 크면 1 을 return 하는 함수.
+func (u uintN) cmp(other uintN) int {
+	return u.bigInt().Cmp(other.bigInt())
+}
+
+// ToBytes 는 1 byte 길이와 최소 길이의 little endian bytes 로 serialize 하는 함수.
+func (u uintN) ToBytes() []byte {
+	return bigIntToBytes(u.bigInt())
+}
+
+func (u uintN) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// U128 는 EE 의 U128 에 해당하는 128 bit unsigned integer. zero value 는 0 이다.
+//
+// 값은 변경되지 않으며, 연산은 새 값을 return 한다. 범위를 벗어나는 연산은 *UintRangeError 를 return 한다.
+type U128 struct {
+	uintN
+}
+
+// NewU128 는 value 를 U128 로 변환하는 함수. nil 은 0 이며, 범위를 벗어나면 *UintRangeError 를 return 한다.
+func NewU128(value *big.Int) (U128, error) {
+	res, err := u128Kind.check(value)
+	return U128{res}, err
+}
+
+func U128FromUint64(value uint64) U128 {
+	return U128{uintFromUint64(value)}
+}
+
+// ParseU128 는 10진수 문자열을 U128 로 변환하는 함수.
+func ParseU128(str string) (U128, error) {
+	res, err := u128Kind.parse(str)
+	return U128{res}, err
+}
+
+func MustParseU128(str string) U128 {
+	res, err := ParseU128(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// Cmp 는 u 가 other 보다 작으면 -1, 같으면 0, 크면 1 을 return 하는 함수.
+func (u U128) Cmp(other U128) int {
+	return u.cmp(other.uintN)
+}
+
+func (u U128) Equal(other U128) bool {
+	return u.cmp(other.uintN) == 0
+}
+
+func (u U128) Add(other U128) (U128, error) {
+	return u.operation(other, '+')
+}
+
+func (u U128) Sub(other U128) (U128, error) {
+	return u.operation(other, '-')
+}
+
+func (u U128) Mul(other U128) (U128, error) {
+	return u.operation(other, '*')
+}
+
+func (u U128) Div(other U128) (U128, error) {
+	return u.operation(other, '/')
+}
+
+func (u U128) Rem(other U128) (U128, error) {
+	return u.operation(other, '%')
+}
+
+func (u U128) operation(other U128, op byte) (U128, error) {
+	res, err := u128Kind.operation(u.uintN, other.uintN, op)
+	return U128{res}, err
+}
+
+func (u U128) FromBytes(src []byte) (res U128, err error, pos int) {
+	value, err, pos := u128Kind.fromBytes(src)
+	return U128{value}, err, pos
+}
+
+func (u U128) ToCLValue() CLValue {
+	return u128Kind.toCLValue(u.uintN)
+}
+
+func (u U128) ToStateBigInt() *state.BigInt {
+	return u128Kind.toStateBigInt(u.uintN)
+}
+
+// FromStateBigInt 는 state.BigInt 를 U128 로 변환하는 함수. BitWidth 는 128 이어야 한다.
+func (u U128) FromStateBigInt(bigInt *state.BigInt) (U128, error) {
+	res, err := u128Kind.fromStateBigInt(bigInt)
+	return U128{res}, err
+}
+
+func (u U128) ToCLInstanceValue() *state.CLValueInstance_Value {
+	return &state.CLValueInstance_Value{
+		Value: &state.CLValueInstance_Value_U128{U128: &state.CLValueInstance_U128{Value: u.String()}}}
+}
+
+func (u *U128) UnmarshalText(text []byte) error {
+	res, err := ParseU128(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = res
+	return nil
+}
+
+// U256 는 EE 의 U256 에 해당하는 256 bit unsigned integer. zero value 는 0 이다.
+//
+// 값은 변경되지 않으며, 연산은 새 값을 return 한다. 범위를 벗어나는 연산은 *UintRangeError 를 return 한다.
+type U256 struct {
+	uintN
+}
+
+// NewU256 는 value 를 U256 로 변환하는 함수. nil 은 0 이며, 범위를 벗어나면 *UintRangeError 를 return 한다.
+func NewU256(value *big.Int) (U256, error) {
+	res, err := u256Kind.check(value)
+	return U256{res}, err
+}
+
+func U256FromUint64(value uint64) U256 {
+	return U256{uintFromUint64(value)}
+}
+
+// ParseU256 는 10진수 문자열을 U256 로 변환하는 함수.
+func ParseU256(str string) (U256, error) {
+	res, err := u256Kind.parse(str)
+	return U256{res}, err
+}
+
+func MustParseU256(str string) U256 {
+	res, err := ParseU256(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// Cmp 는 u 가 other 보다 작으면 -1, 같으면 0, 크면 1 을 return 하는 함수.
+func (u U256) Cmp(other U256) int {
+	return u.cmp(other.uintN)
+}
+
+func (u U256) Equal(other U256) bool {
+	return u.cmp(other.uintN) == 0
+}
+
+func (u U256) Add(other U256) (U256, error) {
+	return u.operation(other, '+')
+}
+
+func (u U256) Sub(other U256) (U256, error) {
+	return u.operation(other, '-')
+}
+
+func (u U256) Mul(other U256) (U256, error) {
+	return u.operation(other, '*')
+}
+
+func (u U256) Div(other U256) (U256, error) {
+	return u.operation(other, '/')
+}
+
+func (u U256) Rem(other U256) (U256, error) {
+	return u.operation(other, '%')
+}
+
+func (u U256) operation(other U256, op byte) (U256, error) {
+	res, err := u256Kind.operation(u.uintN, other.uintN, op)
+	return U256{res}, err
+}
+
+func (u U256) FromBytes(src []byte) (res U256, err error, pos int) {
+	value, err, pos := u256Kind.fromBytes(src)
+	return U256{value}, err, pos
+}
+
+func (u U256) ToCLValue() CLValue {
+	return u256Kind.toCLValue(u.uintN)
+}
+
+func (u U256) ToStateBigInt() *state.BigInt {
+	return u256Kind.toStateBigInt(u.uintN)
+}
+
+// FromStateBigInt 는 state.BigInt 를 U256 로 변환하는 함수. BitWidth 는 256 이어야 한다.
+func (u U256) FromStateBigInt(bigInt *state.BigInt) (U256, error) {
+	res, err := u256Kind.fromStateBigInt(bigInt)
+	return U256{res}, err
+}
+
+func (u U256) ToCLInstanceValue() *state.CLValueInstance_Value {
+	return &state.CLValueInstance_Value{
+		Value: &state.CLValueInstance_Value_U256{U256: &state.CLValueInstance_U256{Value: u.String()}}}
+}
+
+func (u *U256) UnmarshalText(text []byte) error {
+	res, err := ParseU256(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = res
+	return nil
+}
+
+// U512 는 EE 의 U512 에 해당하는 512 bit unsigned integer. zero value 는 0 이다.
+//
+// 값은 변경되지 않으며, 연산은 새 값을 return 한다. 범위를 벗어나는 연산은 *UintRangeError 를 return 한다.
+type U512 struct {
+	uintN
+}
+
+// NewU512 는 value 를 U512 로 변환하는 함수. nil 은 0 이며, 범위를 벗어나면 *UintRangeError 를 return 한다.
+func NewU512(value *big.Int) (U512, error) {
+	res, err := u512Kind.check(value)
+	return U512{res}, err
+}
+
+func U512FromUint64(value uint64) U512 {
+	return U512{uintFromUint64(value)}
+}
+
+// ParseU512 는 10진수 문자열을 U512 로 변환하는 함수.
+func ParseU512(str string) (U512, error) {
+	res, err := u512Kind.parse(str)
+	return U512{res}, err
+}
+
+func MustParseU512(str string) U512 {
+	res, err := ParseU512(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// Cmp 는 u 가 other 보다 작으면 -1, 같으면 0, 크면 1 을 return 하는 함수.
+func (u U512) Cmp(other U512) int {
+	return u.cmp(other.uintN)
+}
+
+func (u U512) Equal(other U512) bool {
+	return u.cmp(other.uintN) == 0
+}
+
+func (u U512) Add(other U512) (U512, error) {
+	return u.operation(other, '+')
+}
+
+func (u U512) Sub(other U512) (U512, error) {
+	return u.operation(other, '-')
+}
+
+func (u U512) Mul(other U512) (U512, error) {
+	return u.operation(other, '*')
+}
+
+func (u U512) Div(other U512) (U512, error) {
+	return u.operation(other, '/')
+}
+
+func (u U512) Rem(other U512) (U512, error) {
+	return u.operation(other, '%')
+}
+
+func (u U512) operation(other U512, op byte) (U512, error) {
+	res, err := u512Kind.operation(u.uintN, other.uintN, op)
+	return U512{res}, err
+}
+
+func (u U512) FromBytes(src []byte) (res U512, err error, pos int) {
+	value, err, pos := u512Kind.fromBytes(src)
+	return U512{value}, err, pos
+}
+
+func (u U512) ToCLValue() CLValue {
+	return u512Kind.toCLValue(u.uintN)
+}
+
+func (u U512) ToStateBigInt() *state.BigInt {
+	return u512Kind.toStateBigInt(u.uintN)
+}
+
+// FromStateBigInt 는 state.BigInt 를 U512 로 변환하는 함수. BitWidth 는 512 이어야 한다.
+func (u U512) FromStateBigInt(bigInt *state.BigInt) (U512, error) {
+	res, err := u512Kind.fromStateBigInt(bigInt)
+	return U512{res}, err
+}
+
+func (u U512) ToCLInstanceValue() *state.CLValueInstance_Value {
+	return &state.CLValueInstance_Value{
+		Value: &state.CLValueInstance_Value_U512{U512: &state.CLValueInstance_U512{Value: u.String()}}}
+}
+
+func (u *U512) UnmarshalText(text []byte) error {
+	res, err := ParseU512(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = res
+	return nil
+}
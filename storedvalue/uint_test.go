@@ -0,0 +1,174 @@
+package storedvalue
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func maxUint(bitWidth int) *big.Int {
+	return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bitWidth)), big.NewInt(1))
+}
+
+func TestUintRange(t *testing.T) {
+	max128 := maxUint(U128_BIT_WIDTH)
+	u, err := NewU128(max128)
+	assert.NoError(t, err)
+	assert.Equal(t, max128.String(), u.String())
+
+	_, err = NewU128(new(big.Int).Add(max128, big.NewInt(1)))
+	assert.EqualError(t, err, "U128 overflow : 340282366920938463463374607431768211456")
+	_, err = NewU256(big.NewInt(-1))
+	assert.EqualError(t, err, "U256 underflow : -1")
+	_, ok := err.(*UintRangeError)
+	assert.True(t, ok)
+
+	zero, err := NewU512(nil)
+	assert.NoError(t, err)
+	assert.True(t, zero.IsZero())
+	assert.True(t, U512{}.Equal(zero))
+	assert.Equal(t, "0", U512{}.String())
+
+	// 입력 값을 변경해도 영향을 받지 않는다.
+	value := big.NewInt(7)
+	u512, err := NewU512(value)
+	assert.NoError(t, err)
+	value.SetInt64(8)
+	assert.Equal(t, "7", u512.String())
+	u512.BigInt().SetInt64(9)
+	assert.Equal(t, "7", u512.String())
+}
+
+func TestUintArithmetic(t *testing.T) {
+	a := U256FromUint64(10)
+	b := U256FromUint64(3)
+
+	for _, v := range []struct {
+		op       func(U256) (U256, error)
+		expected string
+	}{
+		{a.Add, "13"}, {a.Sub, "7"}, {a.Mul, "30"}, {a.Div, "3"}, {a.Rem, "1"},
+	} {
+		res, err := v.op(b)
+		assert.NoError(t, err)
+		assert.Equal(t, v.expected, res.String())
+	}
+	assert.Equal(t, "10", a.String())
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+
+	_, err := b.Sub(a)
+	assert.EqualError(t, err, "U256 underflow : -7")
+	max, err := NewU256(maxUint(U256_BIT_WIDTH))
+	assert.NoError(t, err)
+	_, err = max.Add(U256FromUint64(1))
+	assert.Error(t, err)
+	_, err = max.Mul(U256FromUint64(2))
+	assert.Error(t, err)
+	_, err = a.Div(U256{})
+	assert.EqualError(t, err, "U256 division by zero")
+	_, err = a.Rem(U256{})
+	assert.Error(t, err)
+
+	// U128 의 최대값은 U512 에서는 overflow 가 아니다.
+	u512, err := NewU512(maxUint(U128_BIT_WIDTH))
+	assert.NoError(t, err)
+	u512, err = u512.Add(U512FromUint64(1))
+	assert.NoError(t, err)
+	_, err = NewU128(u512.BigInt())
+	assert.Error(t, err)
+}
+
+func TestParseUint(t *testing.T) {
+	u, err := ParseU512("50000000000000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, "50000000000000000000000", u.String())
+
+	for _, str := range []string{"", "-1", "1.5", "0x10", "abc", maxUint(U128_BIT_WIDTH).String() + "0"} {
+		_, err := ParseU128(str)
+		assert.Error(t, err, str)
+	}
+	assert.Panics(t, func() { MustParseU256("-1") })
+}
+
+func TestUintBytes(t *testing.T) {
+	values := []struct {
+		value string
+		bytes string
+	}{
+		{"0", "00"},
+		{"1000", "02e803"},
+		{maxUint(U128_BIT_WIDTH).String(), "10" + strings.Repeat("ff", 16)},
+	}
+	for _, v := range values {
+		u := MustParseU128(v.value)
+		assert.Equal(t, v.bytes, hex.EncodeToString(u.ToBytes()))
+
+		src, err := hex.DecodeString(v.bytes)
+		assert.NoError(t, err)
+		decoded, err, pos := U128{}.FromBytes(src)
+		assert.NoError(t, err)
+		assert.Equal(t, len(src), pos)
+		assert.True(t, u.Equal(decoded), v.value)
+	}
+
+	// U128 은 16 bytes 보다 길 수 없다.
+	_, err, _ := U128{}.FromBytes(append([]byte{17}, make([]byte, 17)...))
+	assert.Error(t, err)
+	_, err, _ = U512{}.FromBytes([]byte{2, 1})
+	assert.Error(t, err)
+	// 상위 0 byte가 있는 bytes는 같은 bytes로 serialize 되지 않는다.
+	_, err, _ = U512{}.FromBytes([]byte{2, 1, 0})
+	assert.Error(t, err)
+
+	clValue := MustParseU256("1000").ToCLValue()
+	assert.Equal(t, NewSimpleCLType(TAG_U256), clValue.Type)
+	var value U256
+	assert.NoError(t, clValue.Decode(&value))
+	assert.Equal(t, "1000", value.String())
+	var u128 U128
+	assert.Error(t, clValue.Decode(&u128))
+
+	encoded, err := Encode(MustParseU128("1000"))
+	assert.NoError(t, err)
+	assert.Equal(t, MustParseU128("1000").ToCLValue(), encoded)
+	encoded, err = Encode(MustParseU512("1000"))
+	assert.NoError(t, err)
+	var bigInt *big.Int
+	assert.NoError(t, encoded.Decode(&bigInt))
+	assert.Equal(t, big.NewInt(1000), bigInt)
+}
+
+func TestUintStateValue(t *testing.T) {
+	u := MustParseU512("123456789101112131415161718")
+	assert.Equal(t, &state.BigInt{Value: "123456789101112131415161718", BitWidth: 512}, u.ToStateBigInt())
+	assert.Equal(t,
+		&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U512{
+			U512: &state.CLValueInstance_U512{Value: "123456789101112131415161718"}}},
+		u.ToCLInstanceValue())
+	assert.Equal(t,
+		&state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U128{
+			U128: &state.CLValueInstance_U128{Value: "5"}}},
+		U128FromUint64(5).ToCLInstanceValue())
+
+	decoded, err := U512{}.FromStateBigInt(u.ToStateBigInt())
+	assert.NoError(t, err)
+	assert.True(t, u.Equal(decoded))
+	_, err = U256{}.FromStateBigInt(u.ToStateBigInt())
+	assert.Error(t, err)
+	_, err = U128{}.FromStateBigInt(&state.BigInt{Value: "-1", BitWidth: 128})
+	assert.Error(t, err)
+
+	res, err := json.Marshal(struct{ Amount U512 }{u})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Amount": "123456789101112131415161718"}`, string(res))
+	var v struct{ Amount U128 }
+	assert.NoError(t, json.Unmarshal([]byte(`{"Amount": "5"}`), &v))
+	assert.Equal(t, "5", v.Amount.String())
+	assert.Error(t, json.Unmarshal([]byte(`{"Amount": "-5"}`), &v))
+}
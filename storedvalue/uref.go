@@ -1,15 +1,73 @@
 package storedvalue
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 )
 
 const (
 	UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH = 1
+
+	// UREF_ACCESS_RIGHTS_STRING_LENGTH 는 uref 문자열에서 3자리 8진수로 표기하는 access rights의 길이.
+	UREF_ACCESS_RIGHTS_STRING_LENGTH = 3
 )
 
+// ValidateAccessRights 는 rights 가 READ, WRITE, ADD bit 만으로 이루어졌는지 확인하는 함수.
+func ValidateAccessRights(rights state.Key_URef_AccessRights) error {
+	if rights < state.Key_URef_NONE || rights > state.Key_URef_READ_ADD_WRITE {
+		return fmt.Errorf("Invalid access rights %d", rights)
+	}
+
+	return nil
+}
+
+// HasAccessRights 는 rights 가 required 의 bit를 모두 가지는지 확인하는 함수.
+func HasAccessRights(rights, required state.Key_URef_AccessRights) bool {
+	return rights&required == required
+}
+
+// UnionAccessRights 는 rights 의 bit를 모두 합친 access rights를 return 하는 함수.
+func UnionAccessRights(rights ...state.Key_URef_AccessRights) state.Key_URef_AccessRights {
+	res := state.Key_URef_NONE
+	for _, r := range rights {
+		res |= r
+	}
+
+	return res
+}
+
+// IntersectAccessRights 는 a, b 가 공통으로 가지는 bit만 남긴 access rights를 return 하는 함수.
+func IntersectAccessRights(a, b state.Key_URef_AccessRights) state.Key_URef_AccessRights {
+	return a & b
+}
+
+// FormatAccessRights 는 access rights를 EE와 같이 3자리 8진수 문자열(예: 007)로 변환하는 함수.
+func FormatAccessRights(rights state.Key_URef_AccessRights) string {
+	return fmt.Sprintf("%03o", int32(rights))
+}
+
+// ParseAccessRights 는 FormatAccessRights 형식의 문자열을 access rights로 변환하는 함수.
+func ParseAccessRights(str string) (state.Key_URef_AccessRights, error) {
+	if len(str) != UREF_ACCESS_RIGHTS_STRING_LENGTH {
+		return state.Key_URef_NONE, fmt.Errorf("Invalid access rights : %q", str)
+	}
+	value, err := strconv.ParseUint(str, 8, 8)
+	if err != nil {
+		return state.Key_URef_NONE, fmt.Errorf("Invalid access rights : %q", str)
+	}
+
+	rights := state.Key_URef_AccessRights(value)
+	if err := ValidateAccessRights(rights); err != nil {
+		return state.Key_URef_NONE, err
+	}
+
+	return rights, nil
+}
+
 type URef struct {
 	Address      []byte                      `json:"address"`
 	AccessRights state.Key_URef_AccessRights `json:"access_rights"`
@@ -29,21 +87,82 @@ func (u URef) GetAccessRights() state.Key_URef_AccessRights {
 	return u.AccessRights
 }
 
+// ParseURef 는 String 형식(uref-<hex>-<rights>)의 문자열을 URef로 변환하는 함수.
+func ParseURef(str string) (URef, error) {
+	parts := strings.Split(str, "-")
+	if len(parts) != 3 || parts[0] != keyIDNames[KEY_ID_UREF] {
+		return URef{}, fmt.Errorf("Invalid uref : %q", str)
+	}
+
+	address, err := hex.DecodeString(parts[1])
+	if err != nil || len(address) != ADDRESS_LENGTH {
+		return URef{}, fmt.Errorf("Invalid uref address : %q", str)
+	}
+	rights, err := ParseAccessRights(parts[2])
+	if err != nil {
+		return URef{}, err
+	}
+
+	return NewURef(address, rights), nil
+}
+
+// MustParseURef 는 ParseURef 와 같으나 실패하면 panic 하는 함수.
+func MustParseURef(str string) URef {
+	uref, err := ParseURef(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return uref
+}
+
+func (u URef) IsReadable() bool {
+	return HasAccessRights(u.AccessRights, state.Key_URef_READ)
+}
+
+func (u URef) IsWriteable() bool {
+	return HasAccessRights(u.AccessRights, state.Key_URef_WRITE)
+}
+
+func (u URef) IsAddable() bool {
+	return HasAccessRights(u.AccessRights, state.Key_URef_ADD)
+}
+
+// HasAccessRights 는 URef가 required 의 권한을 모두 가지는지 확인하는 함수.
+func (u URef) HasAccessRights(required state.Key_URef_AccessRights) bool {
+	return HasAccessRights(u.AccessRights, required)
+}
+
+// Attenuate 는 URef의 권한 중 rights 에 포함된 권한만 남긴 URef를 return 하는 함수.
+//
+// 권한을 늘릴 수는 없으므로 rights 에만 있는 bit는 무시된다.
+func (u URef) Attenuate(rights state.Key_URef_AccessRights) URef {
+	return NewURef(u.Address, IntersectAccessRights(u.AccessRights, rights))
+}
+
+// String 은 URef를 EE와 같은 uref-<hex>-<rights> 형식의 문자열로 변환하는 함수.
+func (u URef) String() string {
+	return keyIDNames[KEY_ID_UREF] + "-" + hex.EncodeToString(u.Address) + "-" + FormatAccessRights(u.AccessRights)
+}
+
 func (u URef) ToBytes() []byte {
-	res := u.Address
+	res := make([]byte, 0, ADDRESS_LENGTH+UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH)
+	res = append(res, u.Address...)
 
-	res = append(res, byte(u.AccessRights))
-	return res
+	return append(res, byte(u.AccessRights))
 }
 
 func (u URef) FromBytes(src []byte) (uref URef, err error, pos int) {
-	if len(src) < ADDRESS_LENGTH+UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH {
-		return URef{}, fmt.Errorf("URef bytes more than %d, but %d", ADDRESS_LENGTH+UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH, len(src)), pos
+	if err := checkLength(src, pos, ADDRESS_LENGTH+UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH, "URef"); err != nil {
+		return URef{}, err, pos
 	}
 
 	u.Address = src[:ADDRESS_LENGTH]
 	pos = ADDRESS_LENGTH
 	u.AccessRights = state.Key_URef_AccessRights(src[pos])
+	if err := ValidateAccessRights(u.AccessRights); err != nil {
+		return URef{}, decodeErrorf(pos, "%s", err), pos
+	}
 	pos += UREF_ACCESS_RIGHTS_SERIALIZED_LENGTH
 
 	return u, nil, pos
@@ -57,7 +176,11 @@ func (u URef) ToStateValue() *state.Key_URef {
 }
 
 func (u URef) FromStateValue(uref *state.Key_URef) (URef, error) {
-	return NewURef(uref.GetUref(), u.AccessRights), nil
+	if err := ValidateAccessRights(uref.GetAccessRights()); err != nil {
+		return URef{}, err
+	}
+
+	return NewURef(uref.GetUref(), uref.GetAccessRights()), nil
 }
 
 func (u URef) ToCLInstanceValue() *state.CLValueInstance_Value {
@@ -1,6 +1,8 @@
 package storedvalue
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
@@ -81,3 +83,79 @@ func TestURefFromByteError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 0, pos)
 }
+
+func TestURefFromByteInvalidAccessRights(t *testing.T) {
+	src := append(make([]byte, 32), 8)
+
+	_, err, pos := URef{}.FromBytes(src)
+
+	assert.Equal(t, &DecodeError{Offset: 32, Msg: "Invalid access rights 8"}, err)
+	assert.Equal(t, 32, pos)
+}
+
+func TestAccessRightsOperations(t *testing.T) {
+	assert.True(t, HasAccessRights(state.Key_URef_READ_ADD_WRITE, state.Key_URef_READ_WRITE))
+	assert.True(t, HasAccessRights(state.Key_URef_READ, state.Key_URef_NONE))
+	assert.False(t, HasAccessRights(state.Key_URef_READ_ADD, state.Key_URef_ADD_WRITE))
+
+	assert.Equal(t, state.Key_URef_NONE, UnionAccessRights())
+	assert.Equal(t, state.Key_URef_READ_ADD, UnionAccessRights(state.Key_URef_READ, state.Key_URef_ADD))
+	assert.Equal(t, state.Key_URef_READ_ADD_WRITE, UnionAccessRights(state.Key_URef_READ_WRITE, state.Key_URef_ADD_WRITE))
+	assert.Equal(t, state.Key_URef_WRITE, IntersectAccessRights(state.Key_URef_READ_WRITE, state.Key_URef_ADD_WRITE))
+
+	assert.NoError(t, ValidateAccessRights(state.Key_URef_READ_ADD_WRITE))
+	assert.Error(t, ValidateAccessRights(8))
+	assert.Error(t, ValidateAccessRights(-1))
+}
+
+func TestURefAccessRights(t *testing.T) {
+	uref := NewURef(make([]byte, 32), state.Key_URef_READ_ADD)
+
+	assert.True(t, uref.IsReadable())
+	assert.False(t, uref.IsWriteable())
+	assert.True(t, uref.IsAddable())
+	assert.True(t, uref.HasAccessRights(state.Key_URef_READ_ADD))
+	assert.False(t, uref.HasAccessRights(state.Key_URef_READ_WRITE))
+
+	// Attenuate 는 권한을 줄이기만 하고 늘리지 않는다.
+	assert.Equal(t, state.Key_URef_READ, uref.Attenuate(state.Key_URef_READ_WRITE).GetAccessRights())
+	assert.Equal(t, state.Key_URef_NONE, uref.Attenuate(state.Key_URef_WRITE).GetAccessRights())
+	assert.Equal(t, state.Key_URef_READ_ADD, uref.Attenuate(state.Key_URef_READ_ADD_WRITE).GetAccessRights())
+	assert.Equal(t, state.Key_URef_READ_ADD, uref.GetAccessRights())
+}
+
+func TestURefString(t *testing.T) {
+	address := strings.Repeat("0f", 32)
+	for rights, str := range map[state.Key_URef_AccessRights]string{
+		state.Key_URef_NONE:           "000",
+		state.Key_URef_READ:           "001",
+		state.Key_URef_ADD_WRITE:      "006",
+		state.Key_URef_READ_ADD_WRITE: "007",
+	} {
+		uref := NewURef(bytes.Repeat([]byte{0x0f}, 32), rights)
+		assert.Equal(t, str, FormatAccessRights(rights))
+		assert.Equal(t, "uref-"+address+"-"+str, uref.String())
+
+		parsed, err := ParseURef(uref.String())
+		assert.NoError(t, err)
+		assert.Equal(t, uref, parsed)
+	}
+
+	for _, str := range []string{"", "uref-" + address, "hash-" + address + "-007", "uref-0f-007",
+		"uref-" + address + "-7", "uref-" + address + "-010", "uref-" + address + "-rwx"} {
+		_, err := ParseURef(str)
+		assert.Error(t, err, str)
+	}
+	assert.Panics(t, func() { MustParseURef("uref-00-000") })
+}
+
+func TestURefStateValue(t *testing.T) {
+	uref := NewURef(bytes.Repeat([]byte{1}, 32), state.Key_URef_ADD_WRITE)
+
+	decoded, err := URef{}.FromStateValue(uref.ToStateValue())
+	assert.NoError(t, err)
+	assert.Equal(t, uref, decoded)
+
+	_, err = URef{}.FromStateValue(&state.Key_URef{Uref: make([]byte, 32), AccessRights: 9})
+	assert.Error(t, err)
+}
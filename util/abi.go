@@ -0,0 +1,253 @@
+package util
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+)
+
+// DeployArgSchema 는 ABI argument 하나의 이름과 CL type을 정의한다.
+type DeployArgSchema struct {
+	Name   string
+	ClType *state.CLType
+}
+
+// AbiBytesToDeployArgs 는 AbiDeployArgsTobytes 로 만들어진 ABI bytes를 Deploy_Arg list로 되돌리는 함수.
+//
+// ABI bytes에는 argument 이름이 없으므로 schema로 이름을 붙인다.
+// schema가 nil이면 "arg0", "arg1", ... 이름을 사용하고,
+// schema가 있으면 argument 개수와 각 argument의 CL type이 schema와 같아야 한다.
+func AbiBytesToDeployArgs(src []byte, schema []DeployArgSchema) ([]*consensus.Deploy_Arg, error) {
+	if len(src) < storedvalue.SIZE_LENGTH {
+		return nil, fmt.Errorf("ABI bytes more than %d, but %d", storedvalue.SIZE_LENGTH, len(src))
+	}
+	count := int(binary.LittleEndian.Uint32(src[:storedvalue.SIZE_LENGTH]))
+	pos := storedvalue.SIZE_LENGTH
+
+	if schema != nil && len(schema) != count {
+		return nil, fmt.Errorf("ABI has %d arguments, but schema has %d", count, len(schema))
+	}
+
+	deployArgs := []*consensus.Deploy_Arg{}
+	for idx := 0; idx < count; idx++ {
+		instance, err, length := storedvalue.CLValueInstanceFromBytes(src[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("Argument %d at offset %d : %s", idx, pos, err.Error())
+		}
+		pos += length
+
+		name := fmt.Sprintf("arg%d", idx)
+		if schema != nil {
+			name = schema[idx].Name
+			if !proto.Equal(schema[idx].ClType, instance.GetClType()) {
+				return nil, fmt.Errorf("Argument %q type mismatch : expected (%s), but (%s)",
+					name, FormatCLType(schema[idx].ClType), FormatCLType(instance.GetClType()))
+			}
+		}
+
+		deployArgs = append(deployArgs, &consensus.Deploy_Arg{Name: name, Value: instance})
+	}
+
+	if pos != len(src) {
+		return nil, fmt.Errorf("ABI bytes have %d trailing bytes", len(src)-pos)
+	}
+
+	return deployArgs, nil
+}
+
+// CLValuesToDeployArgs 는 이름과 CL type을 가진 serialized value (state.CLValue) 로 Deploy_Arg list를 만드는 함수.
+func CLValuesToDeployArgs(names []string, values []*state.CLValue) ([]*consensus.Deploy_Arg, error) {
+	if len(names) != len(values) {
+		return nil, fmt.Errorf("Names have %d entries, but values have %d", len(names), len(values))
+	}
+
+	deployArgs := []*consensus.Deploy_Arg{}
+	for idx, clValue := range values {
+		value, err, length := storedvalue.CLValueInstanceValueFromBytes(clValue.GetClType(), clValue.GetSerializedValue())
+		if err != nil {
+			return nil, fmt.Errorf("Argument %q : %s", names[idx], err.Error())
+		}
+		if length != len(clValue.GetSerializedValue()) {
+			return nil, fmt.Errorf("Argument %q has %d trailing bytes", names[idx], len(clValue.GetSerializedValue())-length)
+		}
+
+		deployArgs = append(deployArgs, &consensus.Deploy_Arg{
+			Name:  names[idx],
+			Value: &state.CLValueInstance{ClType: clValue.GetClType(), Value: value},
+		})
+	}
+
+	return deployArgs, nil
+}
+
+// FormatCLType 은 CL type을 "Option<List<U512>>" 형태의 문자열로 변환하는 함수.
+//
+// 형식은 storedvalue.CLType.String 과 같고, 변환할 수 없는 CL type이면 "Unknown" 이다.
+func FormatCLType(clType *state.CLType) string {
+	res, err := storedvalue.CLType{}.FromStateValue(clType)
+	if err != nil {
+		return "Unknown"
+	}
+
+	return res.String()
+}
+
+// FormatCLValueInstanceValue 는 CL value를 사람이 읽을 수 있는 문자열로 변환하는 함수.
+func FormatCLValueInstanceValue(value *state.CLValueInstance_Value) string {
+	switch value.GetValue().(type) {
+	case *state.CLValueInstance_Value_BoolValue:
+		return fmt.Sprintf("%t", value.GetBoolValue())
+	case *state.CLValueInstance_Value_I32:
+		return fmt.Sprintf("%d", value.GetI32())
+	case *state.CLValueInstance_Value_I64:
+		return fmt.Sprintf("%d", value.GetI64())
+	case *state.CLValueInstance_Value_U8:
+		return fmt.Sprintf("%d", value.GetU8())
+	case *state.CLValueInstance_Value_U32:
+		return fmt.Sprintf("%d", value.GetU32())
+	case *state.CLValueInstance_Value_U64:
+		return fmt.Sprintf("%d", value.GetU64())
+	case *state.CLValueInstance_Value_U128:
+		return value.GetU128().GetValue()
+	case *state.CLValueInstance_Value_U256:
+		return value.GetU256().GetValue()
+	case *state.CLValueInstance_Value_U512:
+		return value.GetU512().GetValue()
+	case *state.CLValueInstance_Value_Unit:
+		return "()"
+	case *state.CLValueInstance_Value_StrValue:
+		return fmt.Sprintf("%q", value.GetStrValue())
+	case *state.CLValueInstance_Value_Key:
+		return formatKey(value.GetKey())
+	case *state.CLValueInstance_Value_Uref:
+		return formatURef(value.GetUref())
+	case *state.CLValueInstance_Value_OptionValue:
+		if value.GetOptionValue().GetValue().GetValue() == nil {
+			return "None"
+		}
+		return fmt.Sprintf("Some(%s)", FormatCLValueInstanceValue(value.GetOptionValue().GetValue()))
+	case *state.CLValueInstance_Value_ListValue:
+		return formatValues(value.GetListValue().GetValues())
+	case *state.CLValueInstance_Value_FixedListValue:
+		return formatValues(value.GetFixedListValue().GetValues())
+	case *state.CLValueInstance_Value_ResultValue:
+		if value.GetResultValue().GetOk() != nil {
+			return fmt.Sprintf("Ok(%s)", FormatCLValueInstanceValue(value.GetResultValue().GetOk()))
+		}
+		return fmt.Sprintf("Err(%s)", FormatCLValueInstanceValue(value.GetResultValue().GetErr()))
+	case *state.CLValueInstance_Value_MapValue:
+		entries := []string{}
+		for _, entry := range value.GetMapValue().GetValues() {
+			entries = append(entries, fmt.Sprintf("%s: %s", FormatCLValueInstanceValue(entry.GetKey()), FormatCLValueInstanceValue(entry.GetValue())))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case *state.CLValueInstance_Value_Tuple1Value:
+		return fmt.Sprintf("(%s,)", FormatCLValueInstanceValue(value.GetTuple1Value().GetValue_1()))
+	case *state.CLValueInstance_Value_Tuple2Value:
+		return fmt.Sprintf("(%s, %s)",
+			FormatCLValueInstanceValue(value.GetTuple2Value().GetValue_1()), FormatCLValueInstanceValue(value.GetTuple2Value().GetValue_2()))
+	case *state.CLValueInstance_Value_Tuple3Value:
+		return fmt.Sprintf("(%s, %s, %s)",
+			FormatCLValueInstanceValue(value.GetTuple3Value().GetValue_1()), FormatCLValueInstanceValue(value.GetTuple3Value().GetValue_2()),
+			FormatCLValueInstanceValue(value.GetTuple3Value().GetValue_3()))
+	case *state.CLValueInstance_Value_BytesValue:
+		return "0x" + EncodeToHexString(value.GetBytesValue())
+	default:
+		return "<empty>"
+	}
+}
+
+func formatValues(values []*state.CLValueInstance_Value) string {
+	strs := []string{}
+	for _, value := range values {
+		strs = append(strs, FormatCLValueInstanceValue(value))
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
+
+func formatKey(key *state.Key) string {
+	switch key.GetValue().(type) {
+	case *state.Key_Address_:
+		return "account-" + EncodeToHexString(key.GetAddress().GetAccount())
+	case *state.Key_Hash_:
+		return "hash-" + EncodeToHexString(key.GetHash().GetHash())
+	case *state.Key_Uref:
+		return formatURef(key.GetUref())
+	case *state.Key_Local_:
+		return "local-" + EncodeToHexString(key.GetLocal().GetHash())
+	default:
+		return "<empty key>"
+	}
+}
+
+func formatURef(uref *state.Key_URef) string {
+	return "uref-" + EncodeToHexString(uref.GetUref()) + "-" + storedvalue.FormatAccessRights(uref.GetAccessRights())
+}
+
+// DeployItemToString 은 서명 전에 사용자에게 보여줄 수 있도록 DeployItem을 사람이 읽을 수 있는 문자열로 변환하는 함수.
+//
+// session, payment 각각의 종류(wasm, stored hash/name/uref)와 대상, ABI args를 decode한 결과,
+// gas price, authorization keys를 포함한다.
+func DeployItemToString(deploy *ipc.DeployItem) (string, error) {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "Deploy hash : %s\n", EncodeToHexString(deploy.GetDeployHash()))
+	fmt.Fprintf(builder, "Address : %s\n", EncodeToHexString(deploy.GetAddress()))
+
+	if err := writeDeployPayload(builder, "Session", deploy.GetSession()); err != nil {
+		return "", err
+	}
+	if err := writeDeployPayload(builder, "Payment", deploy.GetPayment()); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(builder, "Gas price : %d\n", deploy.GetGasPrice())
+	fmt.Fprintf(builder, "Authorization keys :\n")
+	for _, authorizationKey := range deploy.GetAuthorizationKeys() {
+		fmt.Fprintf(builder, "  %s\n", EncodeToHexString(authorizationKey))
+	}
+
+	return builder.String(), nil
+}
+
+func writeDeployPayload(builder *strings.Builder, title string, payload *ipc.DeployPayload) error {
+	var args []byte
+	switch payload.GetPayload().(type) {
+	case *ipc.DeployPayload_DeployCode:
+		fmt.Fprintf(builder, "%s : wasm (%d bytes, blake2b256 %s)\n", title,
+			len(payload.GetDeployCode().GetCode()), EncodeToHexString(Blake2b256(payload.GetDeployCode().GetCode())))
+		args = payload.GetDeployCode().GetArgs()
+	case *ipc.DeployPayload_StoredContractHash:
+		fmt.Fprintf(builder, "%s : stored contract hash %s\n", title, EncodeToHexString(payload.GetStoredContractHash().GetHash()))
+		args = payload.GetStoredContractHash().GetArgs()
+	case *ipc.DeployPayload_StoredContractName:
+		fmt.Fprintf(builder, "%s : stored contract name %q\n", title, payload.GetStoredContractName().GetStoredContractName())
+		args = payload.GetStoredContractName().GetArgs()
+	case *ipc.DeployPayload_StoredContractUref:
+		fmt.Fprintf(builder, "%s : stored contract uref %s\n", title, EncodeToHexString(payload.GetStoredContractUref().GetUref()))
+		args = payload.GetStoredContractUref().GetArgs()
+	default:
+		fmt.Fprintf(builder, "%s : <empty>\n", title)
+		return nil
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	deployArgs, err := AbiBytesToDeployArgs(args, nil)
+	if err != nil {
+		return fmt.Errorf("%s args : %s", title, err.Error())
+	}
+	for _, deployArg := range deployArgs {
+		fmt.Fprintf(builder, "  %s : %s = %s\n",
+			deployArg.GetName(), FormatCLType(deployArg.GetValue().GetClType()), FormatCLValueInstanceValue(deployArg.GetValue().GetValue()))
+	}
+
+	return nil
+}
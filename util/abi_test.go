@@ -0,0 +1,101 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"github.com/stretchr/testify/assert"
+)
+
+const abiTestJson = `[
+	{"name" : "amount", "value" : {"cl_type" : {"simple_type" : "U512"}, "value" : {"u512" : {"value" : "1000"}}}},
+	{"name" : "names", "value" : {"cl_type" : {"list_type" : {"inner" : {"simple_type" : "STRING"}}}, "value" : {"list_value" : {"values" : [{"str_value" : "A"}, {"str_value" : "B"}]}}}},
+	{"name" : "flag", "value" : {"cl_type" : {"option_type" : {"inner" : {"simple_type" : "BOOL"}}}, "value" : {"option_value" : {"value" : {"bool_value" : true}}}}}
+]`
+
+func TestAbiBytesToDeployArgs(t *testing.T) {
+	deployArgs, err := JsonStringToDeployArgs(abiTestJson)
+	assert.NoError(t, err)
+	abi, err := AbiDeployArgsTobytes(deployArgs)
+	assert.NoError(t, err)
+
+	schema := []DeployArgSchema{}
+	for _, deployArg := range deployArgs {
+		schema = append(schema, DeployArgSchema{Name: deployArg.GetName(), ClType: deployArg.GetValue().GetClType()})
+	}
+
+	decoded, err := AbiBytesToDeployArgs(abi, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, len(deployArgs), len(decoded))
+	for idx := range deployArgs {
+		assert.True(t, proto.Equal(deployArgs[idx], decoded[idx]), deployArgs[idx].GetName())
+	}
+
+	decoded, err = AbiBytesToDeployArgs(abi, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "arg1", decoded[1].GetName())
+
+	_, err = AbiBytesToDeployArgs(abi, schema[:2])
+	assert.Error(t, err)
+
+	schema[0].ClType = &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: state.CLType_U64}}
+	_, err = AbiBytesToDeployArgs(abi, schema)
+	assert.Error(t, err)
+
+	_, err = AbiBytesToDeployArgs(abi[:len(abi)-1], nil)
+	assert.Error(t, err)
+}
+
+func TestCLValuesToDeployArgs(t *testing.T) {
+	deployArgs, err := JsonStringToDeployArgs(abiTestJson)
+	assert.NoError(t, err)
+
+	names := []string{}
+	values := []*state.CLValue{}
+	for _, deployArg := range deployArgs {
+		serialized, err := storedvalue.CLValueInstanceValueToBytes(deployArg.GetValue().GetClType(), deployArg.GetValue().GetValue())
+		assert.NoError(t, err)
+		names = append(names, deployArg.GetName())
+		values = append(values, &state.CLValue{ClType: deployArg.GetValue().GetClType(), SerializedValue: serialized})
+	}
+
+	decoded, err := CLValuesToDeployArgs(names, values)
+	assert.NoError(t, err)
+	for idx := range deployArgs {
+		assert.True(t, proto.Equal(deployArgs[idx], decoded[idx]), deployArgs[idx].GetName())
+	}
+
+	_, err = CLValuesToDeployArgs(names[:1], values)
+	assert.Error(t, err)
+}
+
+func TestDeployItemToString(t *testing.T) {
+	address := MustDecodeHexString("93236a9263d2ac6198c5ed211774c745d5dc62a910cb84276f8a7c4c0b3bc6b7")
+	deploy, err := MakeDeploy(address, NAME, []byte("counter"), abiTestJson, WASM, []byte{0, 97, 115, 109}, "", 10, 1000, "test")
+	assert.NoError(t, err)
+
+	str, err := DeployItemToString(deploy)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(str, `Session : stored contract name "counter"`))
+	assert.True(t, strings.Contains(str, "arg0 : U512 = 1000"))
+	assert.True(t, strings.Contains(str, `arg1 : List<String> = ["A", "B"]`))
+	assert.True(t, strings.Contains(str, "arg2 : Option<Bool> = Some(true)"))
+	assert.True(t, strings.Contains(str, "Payment : wasm (4 bytes"))
+	assert.True(t, strings.Contains(str, "Gas price : 10"))
+	assert.True(t, strings.Contains(str, "  "+EncodeToHexString(address)))
+}
+
+func TestFormatCLType(t *testing.T) {
+	for _, str := range []string{"Option<List<U512>>", "Map<String, Key>", "FixedList<U8, 32>", "Tuple2<Bool, URef>", "Any"} {
+		clType, err := storedvalue.ParseCLType(str)
+		assert.NoError(t, err)
+		stateType, err := clType.ToStateValue()
+		assert.NoError(t, err)
+		assert.Equal(t, str, FormatCLType(stateType))
+	}
+
+	assert.Equal(t, "Unknown", FormatCLType(&state.CLType{}))
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	SIG_ALGORITHM_ED25519 = "ed25519"
+)
+
+// SignDeploy 는 ed25519 private key로 deploy hash에 서명하여 approval을 추가한 deploy를 return 하는 함수.
+//
+// 원본 deploy는 변경하지 않으며, 이미 같은 key의 approval이 있으면 error를 return 한다.
+func SignDeploy(deploy *consensus.Deploy, privateKey ed25519.PrivateKey) (*consensus.Deploy, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("Private key length %d, but %d", ed25519.PrivateKeySize, len(privateKey))
+	}
+
+	approval := &consensus.Approval{
+		ApproverPublicKey: []byte(privateKey.Public().(ed25519.PublicKey)),
+		Signature: &consensus.Signature{
+			SigAlgorithm: SIG_ALGORITHM_ED25519,
+			Sig:          ed25519.Sign(privateKey, deploy.GetDeployHash())}}
+
+	signedDeploy := proto.Clone(deploy).(*consensus.Deploy)
+	err := AddApproval(signedDeploy, approval)
+	if err != nil {
+		return nil, err
+	}
+
+	return signedDeploy, nil
+}
+
+// AddApproval 은 서명을 검증한 후 deploy에 approval을 추가하는 함수.
+//
+// 같은 key, 같은 서명의 approval은 한번만 추가되며, 같은 key의 다른 서명은 error로 처리한다.
+func AddApproval(deploy *consensus.Deploy, approval *consensus.Approval) error {
+	err := VerifyApproval(deploy.GetDeployHash(), approval)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range deploy.GetApprovals() {
+		if !bytes.Equal(existing.GetApproverPublicKey(), approval.GetApproverPublicKey()) {
+			continue
+		}
+		if proto.Equal(existing.GetSignature(), approval.GetSignature()) {
+			return nil
+		}
+		return fmt.Errorf("Approval of %s already exists with a different signature",
+			EncodeToHexString(approval.GetApproverPublicKey()))
+	}
+
+	deploy.Approvals = append(deploy.Approvals, approval)
+
+	return nil
+}
+
+// VerifyApproval 은 approval의 서명이 deploy hash에 대한 approver public key의 서명인지 검증하는 함수.
+func VerifyApproval(deployHash []byte, approval *consensus.Approval) error {
+	publicKey := approval.GetApproverPublicKey()
+
+	switch algorithm := approval.GetSignature().GetSigAlgorithm(); algorithm {
+	case SIG_ALGORITHM_ED25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("Approver public key length %d, but %d", ed25519.PublicKeySize, len(publicKey))
+		}
+		if !ed25519.Verify(ed25519.PublicKey(publicKey), deployHash, approval.GetSignature().GetSig()) {
+			return fmt.Errorf("Invalid signature of %s", EncodeToHexString(publicKey))
+		}
+	default:
+		return fmt.Errorf("Signature algorithm %q is not supported", algorithm)
+	}
+
+	return nil
+}
+
+// MergeDeployApprovals 는 같은 deploy에 각 signer가 따로 서명한 deploy들의 approval을 하나로 합치는 함수.
+//
+// 모든 deploy의 header, body가 첫번째 deploy와 같아야 하며, 입력 deploy는 변경하지 않는다.
+func MergeDeployApprovals(deploys ...*consensus.Deploy) (*consensus.Deploy, error) {
+	if len(deploys) == 0 {
+		return nil, fmt.Errorf("No deploy to merge")
+	}
+
+	merged := proto.Clone(deploys[0]).(*consensus.Deploy)
+	merged.Approvals = nil
+
+	for idx, deploy := range deploys {
+		if !bytes.Equal(merged.GetDeployHash(), deploy.GetDeployHash()) ||
+			!proto.Equal(merged.GetHeader(), deploy.GetHeader()) ||
+			!proto.Equal(merged.GetBody(), deploy.GetBody()) {
+			return nil, fmt.Errorf("Deploy %d is not the same deploy : %s", idx, EncodeToHexString(deploy.GetDeployHash()))
+		}
+
+		for _, approval := range deploy.GetApprovals() {
+			err := AddApproval(merged, proto.Clone(approval).(*consensus.Approval))
+			if err != nil {
+				return nil, fmt.Errorf("Deploy %d : %s", idx, err.Error())
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// MergeDeployFiles 는 부분 서명된 deploy 파일들을 읽어 approval을 합친 deploy를 return 하는 함수.
+func MergeDeployFiles(paths ...string) (*consensus.Deploy, error) {
+	deploys := []*consensus.Deploy{}
+	for _, path := range paths {
+		deploy, err := LoadDeployFile(path)
+		if err != nil {
+			return nil, err
+		}
+		deploys = append(deploys, deploy)
+	}
+
+	return MergeDeployApprovals(deploys...)
+}
+
+// CheckDeployApprovals 는 state에서 읽은 account로 deploy approval key들의 weight 합을 계산하는 함수.
+//
+// deploy 실행 가능 여부는 CanDeploy, key management 가능 여부는 CanManageKeys 로 확인한다.
+func CheckDeployApprovals(deploy *consensus.Deploy, account storedvalue.Account) storedvalue.AuthorizationStatus {
+	signers := [][]byte{}
+	for _, approval := range deploy.GetApprovals() {
+		signers = append(signers, approval.GetApproverPublicKey())
+	}
+
+	return account.Authorize(signers)
+}
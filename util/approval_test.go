@@ -0,0 +1,108 @@
+package util
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+func makeTestPrivateKey(seed string) ed25519.PrivateKey {
+	hash := sha256.Sum256([]byte(seed))
+	return ed25519.NewKeyFromSeed(hash[:])
+}
+
+func TestSignDeploy(t *testing.T) {
+	deploy := makeTestDeploy(t)
+	alice := makeTestPrivateKey("alice")
+
+	signed, err := SignDeploy(deploy, alice)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(deploy.GetApprovals()))
+	assert.Equal(t, 1, len(signed.GetApprovals()))
+	assert.NoError(t, VerifyApproval(signed.GetDeployHash(), signed.GetApprovals()[0]))
+
+	// 같은 서명은 한번만 추가된다.
+	assert.NoError(t, AddApproval(signed, signed.GetApprovals()[0]))
+	assert.Equal(t, 1, len(signed.GetApprovals()))
+
+	tampered := *signed.GetApprovals()[0]
+	tampered.ApproverPublicKey = []byte(makeTestPrivateKey("bob").Public().(ed25519.PublicKey))
+	assert.Error(t, AddApproval(signed, &tampered))
+
+	_, err = SignDeploy(deploy, alice[:10])
+	assert.Error(t, err)
+}
+
+func TestMergeDeployFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "approval")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	deploy := makeTestDeploy(t)
+	paths := []string{}
+	for idx, name := range []string{"alice", "bob", "carol"} {
+		signed, err := SignDeploy(deploy, makeTestPrivateKey(name))
+		assert.NoError(t, err)
+
+		path := filepath.Join(dir, name)
+		assert.NoError(t, SaveDeployFile(path, signed, DeployFileFormat(idx%2)))
+		paths = append(paths, path)
+	}
+	// alice의 서명이 중복된 파일도 합칠 수 있다.
+	paths = append(paths, paths[0])
+
+	merged, err := MergeDeployFiles(paths...)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(merged.GetApprovals()))
+
+	deployItem, err := DeployToDeployItem(merged)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(deployItem.GetAuthorizationKeys()))
+
+	other, _, err := NewDeployBuilder(Blake2b256([]byte("other")), "hdac").
+		WithSessionJson(WASM, []byte{0, 97, 115, 109}, deployTestArgs).
+		WithPayment(HASH, make([]byte, 32), nil).
+		Build()
+	assert.NoError(t, err)
+	_, err = MergeDeployApprovals(merged, other)
+	assert.Error(t, err)
+
+	_, err = MergeDeployApprovals()
+	assert.Error(t, err)
+}
+
+func TestCheckDeployApprovals(t *testing.T) {
+	deploy := makeTestDeploy(t)
+	alice, bob, carol := makeTestPrivateKey("alice"), makeTestPrivateKey("bob"), makeTestPrivateKey("carol")
+
+	account := storedvalue.Account{
+		AssociatedKeys: []storedvalue.AssociatedKey{
+			storedvalue.NewAssociatedKey([]byte(alice.Public().(ed25519.PublicKey)), 1),
+			storedvalue.NewAssociatedKey([]byte(bob.Public().(ed25519.PublicKey)), 2)},
+		ActionThresholds: storedvalue.NewActionThresholds(3, 3)}
+
+	signed, err := SignDeploy(deploy, alice)
+	assert.NoError(t, err)
+	status := CheckDeployApprovals(signed, account)
+	assert.Equal(t, uint32(1), status.Weight)
+	assert.Equal(t, uint32(3), status.ActionThresholds.DeploymentThreshold)
+	assert.False(t, status.CanDeploy())
+
+	signed, err = SignDeploy(signed, bob)
+	assert.NoError(t, err)
+	status = CheckDeployApprovals(signed, account)
+	assert.Equal(t, uint32(3), status.Weight)
+	assert.True(t, status.CanDeploy())
+
+	signed, err = SignDeploy(signed, carol)
+	assert.NoError(t, err)
+	status = CheckDeployApprovals(signed, account)
+	assert.Equal(t, [][]byte{[]byte(carol.Public().(ed25519.PublicKey))}, status.UnknownKeys)
+	assert.False(t, status.CanDeploy())
+}
@@ -0,0 +1,242 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/storedvalue"
+)
+
+const (
+	DEPLOY_HASH_LENGTH = 32
+)
+
+// DeployBuilder 는 consensus.Deploy 와 ipc.DeployItem 을 같은 deploy hash로 만들기 위한 builder.
+//
+// 각 With* 함수는 builder를 return 하여 chain 형태로 사용할 수 있으며,
+// 잘못된 입력은 Build 에서 error로 return 된다.
+type DeployBuilder struct {
+	fromAddress     storedvalue.Address
+	chainName       string
+	session         *consensus.Deploy_Code
+	payment         *consensus.Deploy_Code
+	gasPrice        uint64
+	timestampMillis uint64
+	ttlMillis       uint32
+	dependencies    [][]byte
+	err             error
+}
+
+// NewDeployBuilder 는 deploy를 실행할 account address와 chain 이름으로 DeployBuilder 를 만드는 함수.
+//
+// timestamp의 기본값은 현재 시간(millisecond)이다.
+func NewDeployBuilder(fromAddress storedvalue.Address, chainName string) *DeployBuilder {
+	return &DeployBuilder{
+		fromAddress:     fromAddress,
+		chainName:       chainName,
+		timestampMillis: uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+	}
+}
+
+// WithSession 은 session code와 argument를 설정하는 함수.
+func (b *DeployBuilder) WithSession(contractType ContractType, data []byte, args []*consensus.Deploy_Arg) *DeployBuilder {
+	b.session = b.makeDeployCode("Session", contractType, data, args)
+	return b
+}
+
+// WithSessionJson 은 session argument를 JSON 문자열로 받아 설정하는 함수.
+func (b *DeployBuilder) WithSessionJson(contractType ContractType, data []byte, argsStr string) *DeployBuilder {
+	args, err := JsonStringToDeployArgs(argsStr)
+	if err != nil {
+		b.setError(fmt.Errorf("Session args : %s", err.Error()))
+		return b
+	}
+	return b.WithSession(contractType, data, args)
+}
+
+// WithPayment 는 payment code와 argument를 설정하는 함수.
+func (b *DeployBuilder) WithPayment(contractType ContractType, data []byte, args []*consensus.Deploy_Arg) *DeployBuilder {
+	b.payment = b.makeDeployCode("Payment", contractType, data, args)
+	return b
+}
+
+// WithPaymentJson 은 payment argument를 JSON 문자열로 받아 설정하는 함수.
+func (b *DeployBuilder) WithPaymentJson(contractType ContractType, data []byte, argsStr string) *DeployBuilder {
+	args, err := JsonStringToDeployArgs(argsStr)
+	if err != nil {
+		b.setError(fmt.Errorf("Payment args : %s", err.Error()))
+		return b
+	}
+	return b.WithPayment(contractType, data, args)
+}
+
+func (b *DeployBuilder) WithGasPrice(gasPrice uint64) *DeployBuilder {
+	b.gasPrice = gasPrice
+	return b
+}
+
+// WithTimestampMillis 는 deploy header의 timestamp를 millisecond 단위로 설정하는 함수.
+func (b *DeployBuilder) WithTimestampMillis(timestampMillis uint64) *DeployBuilder {
+	b.timestampMillis = timestampMillis
+	return b
+}
+
+// WithTimestamp 는 time.Time 을 millisecond 단위 timestamp로 변환하여 설정하는 함수.
+func (b *DeployBuilder) WithTimestamp(timestamp time.Time) *DeployBuilder {
+	if timestamp.Before(time.Unix(0, 0)) {
+		b.setError(fmt.Errorf("Timestamp before unix epoch : %s", timestamp))
+		return b
+	}
+	return b.WithTimestampMillis(uint64(timestamp.UnixNano() / int64(time.Millisecond)))
+}
+
+// WithTtlMillis 는 deploy의 TTL을 millisecond 단위로 설정하는 함수. 0이면 node의 기본값이 사용된다.
+func (b *DeployBuilder) WithTtlMillis(ttlMillis uint32) *DeployBuilder {
+	b.ttlMillis = ttlMillis
+	return b
+}
+
+// WithTtl 은 time.Duration 을 millisecond 단위 TTL로 변환하여 설정하는 함수.
+func (b *DeployBuilder) WithTtl(ttl time.Duration) *DeployBuilder {
+	millis := ttl / time.Millisecond
+	if millis < 0 || int64(millis) > int64(^uint32(0)) {
+		b.setError(fmt.Errorf("TTL out of range : %s", ttl))
+		return b
+	}
+	return b.WithTtlMillis(uint32(millis))
+}
+
+// WithDependencies 는 이 deploy 이전에 실행되어야 하는 deploy hash 목록을 추가하는 함수.
+func (b *DeployBuilder) WithDependencies(deployHashes ...[]byte) *DeployBuilder {
+	for _, deployHash := range deployHashes {
+		if len(deployHash) != DEPLOY_HASH_LENGTH {
+			b.setError(fmt.Errorf("Dependency deploy hash length %d, but %d", DEPLOY_HASH_LENGTH, len(deployHash)))
+			return b
+		}
+		b.dependencies = append(b.dependencies, deployHash)
+	}
+	return b
+}
+
+// Build 는 설정된 값으로 consensus.Deploy 와 ipc.DeployItem 을 만드는 함수.
+//
+// Deploy Body를 Marshal한 값의 Blake2b256 Hash를 Body Hash로 Header에 넣고,
+// Header를 Marshal한 값의 Blake2b256 Hash를 두 결과의 Deploy Hash로 사용한다.
+func (b *DeployBuilder) Build() (*consensus.Deploy, *ipc.DeployItem, error) {
+	if b.err != nil {
+		return nil, nil, b.err
+	}
+	if !b.fromAddress.IsAccount() {
+		return nil, nil, fmt.Errorf("Deploy address must be an account address : %s", b.fromAddress.Hex())
+	}
+	if b.session == nil {
+		return nil, nil, fmt.Errorf("Session code is not set")
+	}
+	if b.payment == nil {
+		return nil, nil, fmt.Errorf("Payment code is not set")
+	}
+
+	deployBody := &consensus.Deploy_Body{
+		Session: b.session,
+		Payment: b.payment}
+
+	marshalDeployBody, err := proto.Marshal(deployBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deployHeader := &consensus.Deploy_Header{
+		AccountPublicKey: b.fromAddress.Bytes(),
+		Timestamp:        b.timestampMillis,
+		GasPrice:         b.gasPrice,
+		BodyHash:         Blake2b256(marshalDeployBody),
+		TtlMillis:        b.ttlMillis,
+		Dependencies:     b.dependencies,
+		ChainName:        b.chainName}
+
+	marshalDeployHeader, err := proto.Marshal(deployHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deploy := &consensus.Deploy{
+		DeployHash: Blake2b256(marshalDeployHeader),
+		Header:     deployHeader,
+		Body:       deployBody}
+
+	deployItem, err := DeployToDeployItem(deploy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deploy, deployItem, nil
+}
+
+func (b *DeployBuilder) makeDeployCode(
+	title string, contractType ContractType, data []byte, args []*consensus.Deploy_Arg) *consensus.Deploy_Code {
+	deployCode := MakeDeployCode(contractType, data, args)
+	if deployCode == nil {
+		b.setError(fmt.Errorf("%s contract type %d is not supported", title, contractType))
+	}
+	return deployCode
+}
+
+func (b *DeployBuilder) setError(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// DeployToDeployItem 은 consensus.Deploy 를 Execution Engine이 실행하는 ipc.DeployItem 으로 변환하는 함수.
+//
+// authorization key는 approval의 public key들이며, approval이 없으면 account public key를 사용한다.
+func DeployToDeployItem(deploy *consensus.Deploy) (*ipc.DeployItem, error) {
+	session, err := DeployCodeToDeployPayload(deploy.GetBody().GetSession())
+	if err != nil {
+		return nil, fmt.Errorf("Session : %s", err.Error())
+	}
+	payment, err := DeployCodeToDeployPayload(deploy.GetBody().GetPayment())
+	if err != nil {
+		return nil, fmt.Errorf("Payment : %s", err.Error())
+	}
+
+	authorizationKeys := [][]byte{}
+	for _, approval := range deploy.GetApprovals() {
+		authorizationKeys = append(authorizationKeys, approval.GetApproverPublicKey())
+	}
+	if len(authorizationKeys) == 0 {
+		authorizationKeys = append(authorizationKeys, deploy.GetHeader().GetAccountPublicKey())
+	}
+
+	return &ipc.DeployItem{
+		Address:           deploy.GetHeader().GetAccountPublicKey(),
+		Session:           session,
+		Payment:           payment,
+		GasPrice:          deploy.GetHeader().GetGasPrice(),
+		AuthorizationKeys: authorizationKeys,
+		DeployHash:        deploy.GetDeployHash()}, nil
+}
+
+// DeployCodeToDeployPayload 는 consensus.Deploy_Code 의 argument를 ABI bytes로 변환하여 ipc.DeployPayload 를 만드는 함수.
+func DeployCodeToDeployPayload(deployCode *consensus.Deploy_Code) (*ipc.DeployPayload, error) {
+	abi, err := AbiDeployArgsTobytes(deployCode.GetArgs())
+	if err != nil {
+		return nil, err
+	}
+
+	switch deployCode.GetContract().(type) {
+	case *consensus.Deploy_Code_Wasm:
+		return MakeDeployPayload(WASM, deployCode.GetWasm(), abi), nil
+	case *consensus.Deploy_Code_Hash:
+		return MakeDeployPayload(HASH, deployCode.GetHash(), abi), nil
+	case *consensus.Deploy_Code_Name:
+		return MakeDeployPayload(NAME, []byte(deployCode.GetName()), abi), nil
+	case *consensus.Deploy_Code_Uref:
+		return MakeDeployPayload(UREF, deployCode.GetUref(), abi), nil
+	default:
+		return nil, fmt.Errorf("Deploy code has no contract")
+	}
+}
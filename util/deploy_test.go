@@ -0,0 +1,92 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/stretchr/testify/assert"
+)
+
+const deployTestArgs = `[{"name":"amount","value":{"cl_type":{"simple_type":"U512"},"value":{"u512":{"value":"100"}}}}]`
+
+func TestMakeDeployHash(t *testing.T) {
+	deploy, err := MakeDeploy(make([]byte, 32), WASM, []byte{0, 97, 115, 109}, deployTestArgs, HASH, make([]byte, 32), "", 10, 1583712000000, "hdac")
+	assert.NoError(t, err)
+	assert.Equal(t, "76ece7936df7a52ac5c417d07cf1e838338f9c30f0ed5c0ef090d1db55b05b2f", EncodeToHexString(deploy.GetDeployHash()))
+}
+
+func TestMakeDeployInvalidAddress(t *testing.T) {
+	dapp := MustDecodeHexString("0193236a9263d2ac6198c5ed211774c745d5dc62a910cb84276f8a7c4c0b3bc6b7")
+	_, err := MakeDeploy(dapp, WASM, []byte{0, 97, 115, 109}, "", WASM, []byte{0, 97, 115, 109}, "", 10, 1000, "test")
+	assert.Error(t, err)
+}
+
+func TestDeployBuilder(t *testing.T) {
+	address := Blake2b256([]byte("address"))
+	dependency := Blake2b256([]byte("dependency"))
+	timestamp := time.Unix(1583712000, 123000000)
+
+	deploy, deployItem, err := NewDeployBuilder(address, "hdac").
+		WithSessionJson(WASM, []byte{0, 97, 115, 109}, deployTestArgs).
+		WithPayment(HASH, make([]byte, 32), nil).
+		WithGasPrice(10).
+		WithTimestamp(timestamp).
+		WithTtl(time.Hour).
+		WithDependencies(dependency).
+		Build()
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1583712000123), deploy.GetHeader().GetTimestamp())
+	assert.Equal(t, uint32(3600000), deploy.GetHeader().GetTtlMillis())
+	assert.Equal(t, [][]byte{dependency}, deploy.GetHeader().GetDependencies())
+
+	marshalBody, err := proto.Marshal(deploy.GetBody())
+	assert.NoError(t, err)
+	assert.Equal(t, Blake2b256(marshalBody), deploy.GetHeader().GetBodyHash())
+	marshalHeader, err := proto.Marshal(deploy.GetHeader())
+	assert.NoError(t, err)
+	assert.Equal(t, Blake2b256(marshalHeader), deploy.GetDeployHash())
+
+	assert.Equal(t, deploy.GetDeployHash(), deployItem.GetDeployHash())
+	assert.Equal(t, address, deployItem.GetAddress())
+	assert.Equal(t, [][]byte{address}, deployItem.GetAuthorizationKeys())
+	assert.Equal(t, uint64(10), deployItem.GetGasPrice())
+
+	sessionAbi, err := AbiDeployArgsTobytes(deploy.GetBody().GetSession().GetArgs())
+	assert.NoError(t, err)
+	assert.Equal(t, sessionAbi, deployItem.GetSession().GetDeployCode().GetArgs())
+
+	// approval이 있으면 approver public key가 authorization key가 된다.
+	approver := Blake2b256([]byte("approver"))
+	deploy.Approvals = []*consensus.Approval{{ApproverPublicKey: approver}}
+	deployItem, err = DeployToDeployItem(deploy)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{approver}, deployItem.GetAuthorizationKeys())
+}
+
+func TestDeployBuilderError(t *testing.T) {
+	address := Blake2b256([]byte("address"))
+
+	_, _, err := NewDeployBuilder(address, "hdac").WithPayment(HASH, make([]byte, 32), nil).Build()
+	assert.Error(t, err)
+
+	_, _, err = NewDeployBuilder(address, "hdac").
+		WithSession(WASM, []byte{}, nil).WithPayment(HASH, make([]byte, 32), nil).
+		WithDependencies([]byte{1, 2, 3}).Build()
+	assert.Error(t, err)
+
+	_, _, err = NewDeployBuilder(address, "hdac").
+		WithSession(LOCAL, []byte{}, nil).WithPayment(HASH, make([]byte, 32), nil).Build()
+	assert.Error(t, err)
+
+	_, _, err = NewDeployBuilder(address, "hdac").
+		WithSessionJson(WASM, []byte{}, "[{").WithPayment(HASH, make([]byte, 32), nil).Build()
+	assert.Error(t, err)
+
+	_, _, err = NewDeployBuilder(address, "hdac").
+		WithSession(WASM, []byte{}, nil).WithPayment(HASH, make([]byte, 32), nil).
+		WithTtl(-time.Second).Build()
+	assert.Error(t, err)
+}
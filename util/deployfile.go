@@ -0,0 +1,163 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+)
+
+// DeployFileFormat 은 deploy 파일의 serialize 형식.
+type DeployFileFormat int
+
+const (
+	// DEPLOY_FILE_BINARY 는 CasperLabs client의 make-deploy, sign-deploy, send-deploy 가 사용하는 protobuf binary 형식.
+	DEPLOY_FILE_BINARY DeployFileFormat = iota
+	// DEPLOY_FILE_JSON 은 protobuf JSON mapping 형식.
+	DEPLOY_FILE_JSON
+)
+
+// MarshalDeploy 는 consensus.Deploy 를 format에 맞게 serialize 하는 함수.
+func MarshalDeploy(deploy *consensus.Deploy, format DeployFileFormat) ([]byte, error) {
+	switch format {
+	case DEPLOY_FILE_BINARY:
+		return proto.Marshal(deploy)
+	case DEPLOY_FILE_JSON:
+		m := &jsonpb.Marshaler{OrigName: true, Indent: "  "}
+		str, err := m.MarshalToString(deploy)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(str), nil
+	default:
+		return nil, fmt.Errorf("Unknown deploy file format %d", format)
+	}
+}
+
+// UnmarshalDeploy 는 binary 또는 JSON 형식의 deploy를 consensus.Deploy 로 변환하는 함수.
+//
+// 유효한 JSON object 이면 JSON, 그 외에는 protobuf binary로 판단한다.
+func UnmarshalDeploy(src []byte) (*consensus.Deploy, error) {
+	deploy := &consensus.Deploy{}
+
+	trimmed := bytes.TrimSpace(src)
+	if len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+		err := jsonpb.Unmarshal(bytes.NewReader(trimmed), deploy)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid deploy JSON : %s", err.Error())
+		}
+		return deploy, nil
+	}
+
+	err := proto.Unmarshal(src, deploy)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid deploy protobuf : %s", err.Error())
+	}
+
+	return deploy, nil
+}
+
+// SaveDeployFile 은 deploy를 format에 맞게 파일로 저장하는 함수.
+func SaveDeployFile(path string, deploy *consensus.Deploy, format DeployFileFormat) error {
+	src, err := MarshalDeploy(deploy, format)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, src, 0644)
+}
+
+// LoadDeployFile 은 SaveDeployFile 이나 CasperLabs client로 저장한 deploy 파일을 읽어 hash를 검증한 후 return 하는 함수.
+func LoadDeployFile(path string) (*consensus.Deploy, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	deploy, err := UnmarshalDeploy(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %s", path, err.Error())
+	}
+
+	err = VerifyDeployHashes(deploy)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %s", path, err.Error())
+	}
+
+	return deploy, nil
+}
+
+// LoadDeployItemFile 은 deploy 파일을 읽어 grpc.Execute 에 사용할 ipc.DeployItem 으로 변환하는 함수.
+func LoadDeployItemFile(path string) (*ipc.DeployItem, error) {
+	deploy, err := LoadDeployFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeployToDeployItem(deploy)
+}
+
+// VerifyDeployHashes 는 deploy의 body hash와 deploy hash가 body, header와 일치하는지 검사하는 함수.
+func VerifyDeployHashes(deploy *consensus.Deploy) error {
+	if deploy.GetHeader() == nil || deploy.GetBody() == nil {
+		return fmt.Errorf("Deploy has no header or body")
+	}
+
+	marshalDeployBody, err := proto.Marshal(deploy.GetBody())
+	if err != nil {
+		return err
+	}
+	if bodyHash := Blake2b256(marshalDeployBody); !bytes.Equal(bodyHash, deploy.GetHeader().GetBodyHash()) {
+		return fmt.Errorf("Body hash mismatch : expected %s, but %s",
+			EncodeToHexString(bodyHash), EncodeToHexString(deploy.GetHeader().GetBodyHash()))
+	}
+
+	marshalDeployHeader, err := proto.Marshal(deploy.GetHeader())
+	if err != nil {
+		return err
+	}
+	if deployHash := Blake2b256(marshalDeployHeader); !bytes.Equal(deployHash, deploy.GetDeployHash()) {
+		return fmt.Errorf("Deploy hash mismatch : expected %s, but %s",
+			EncodeToHexString(deployHash), EncodeToHexString(deploy.GetDeployHash()))
+	}
+
+	return nil
+}
+
+// InspectDeploy 는 deploy 파일의 내용을 서명 전에 확인할 수 있도록 사람이 읽을 수 있는 문자열로 변환하는 함수.
+func InspectDeploy(deploy *consensus.Deploy) (string, error) {
+	deployItem, err := DeployToDeployItem(deploy)
+	if err != nil {
+		return "", err
+	}
+	deployItemStr, err := DeployItemToString(deployItem)
+	if err != nil {
+		return "", err
+	}
+
+	header := deploy.GetHeader()
+	builder := &strings.Builder{}
+	builder.WriteString(deployItemStr)
+	fmt.Fprintf(builder, "Chain name : %s\n", header.GetChainName())
+	fmt.Fprintf(builder, "Timestamp : %d (%s)\n", header.GetTimestamp(),
+		time.Unix(0, int64(header.GetTimestamp())*int64(time.Millisecond)).UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(builder, "TTL : %d ms\n", header.GetTtlMillis())
+	fmt.Fprintf(builder, "Dependencies :\n")
+	for _, dependency := range header.GetDependencies() {
+		fmt.Fprintf(builder, "  %s\n", EncodeToHexString(dependency))
+	}
+	fmt.Fprintf(builder, "Approvals :\n")
+	for _, approval := range deploy.GetApprovals() {
+		fmt.Fprintf(builder, "  %s (%s)\n",
+			EncodeToHexString(approval.GetApproverPublicKey()), approval.GetSignature().GetSigAlgorithm())
+	}
+
+	return builder.String(), nil
+}
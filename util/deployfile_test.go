@@ -0,0 +1,88 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestDeploy(t *testing.T) *consensus.Deploy {
+	deploy, _, err := NewDeployBuilder(Blake2b256([]byte("address")), "hdac").
+		WithSessionJson(WASM, []byte{0, 97, 115, 109}, deployTestArgs).
+		WithPayment(HASH, make([]byte, 32), nil).
+		WithGasPrice(10).
+		WithTimestampMillis(1583712000000).
+		WithTtlMillis(3600000).
+		WithDependencies(Blake2b256([]byte("dependency"))).
+		Build()
+	assert.NoError(t, err)
+
+	return deploy
+}
+
+func TestDeployFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deployfile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	deploy := makeTestDeploy(t)
+	deploy.Approvals = []*consensus.Approval{{
+		ApproverPublicKey: Blake2b256([]byte("address")),
+		Signature:         &consensus.Signature{SigAlgorithm: "ed25519", Sig: make([]byte, 64)}}}
+
+	for _, format := range []DeployFileFormat{DEPLOY_FILE_BINARY, DEPLOY_FILE_JSON} {
+		path := filepath.Join(dir, "deploy")
+		assert.NoError(t, SaveDeployFile(path, deploy, format))
+
+		loaded, err := LoadDeployFile(path)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(deploy, loaded))
+
+		deployItem, err := LoadDeployItemFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, deploy.GetDeployHash(), deployItem.GetDeployHash())
+		assert.Equal(t, [][]byte{Blake2b256([]byte("address"))}, deployItem.GetAuthorizationKeys())
+	}
+
+	// CasperLabs client가 만든 파일은 plain protobuf binary 이다.
+	src, err := proto.Marshal(deploy)
+	assert.NoError(t, err)
+	loaded, err := UnmarshalDeploy(src)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(deploy, loaded))
+
+	jsonSrc, err := MarshalDeploy(deploy, DEPLOY_FILE_JSON)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(jsonSrc), `"deploy_hash"`))
+	assert.True(t, strings.Contains(string(jsonSrc), `"ttl_millis": 3600000`))
+}
+
+func TestVerifyDeployHashes(t *testing.T) {
+	deploy := makeTestDeploy(t)
+	assert.NoError(t, VerifyDeployHashes(deploy))
+
+	deploy.Header.GasPrice = 11
+	assert.Error(t, VerifyDeployHashes(deploy))
+
+	deploy = makeTestDeploy(t)
+	deploy.Body.Payment = deploy.Body.Session
+	assert.Error(t, VerifyDeployHashes(deploy))
+
+	_, err := UnmarshalDeploy([]byte{0xff, 0xff})
+	assert.Error(t, err)
+}
+
+func TestInspectDeploy(t *testing.T) {
+	str, err := InspectDeploy(makeTestDeploy(t))
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(str, "TTL : 3600000 ms"))
+	assert.True(t, strings.Contains(str, "2020-03-09T00:00:00Z"))
+	assert.True(t, strings.Contains(str, EncodeToHexString(Blake2b256([]byte("dependency")))))
+	assert.True(t, strings.Contains(str, "arg0 : U512 = 100"))
+}
@@ -0,0 +1,185 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+)
+
+// legacyArgValueFields 는 Deploy_LegacyArg.Value 의 JSON field 이름 목록으로, 신규 Deploy_Arg(CLValueInstance)와 겹치지 않는다.
+var legacyArgValueFields = map[string]bool{
+	"optional_value": true, "optionalValue": true,
+	"bytes_value": true, "bytesValue": true,
+	"int_value": true, "intValue": true,
+	"int_list": true, "intList": true,
+	"string_value": true, "stringValue": true,
+	"string_list": true, "stringList": true,
+	"long_value": true, "longValue": true,
+	"big_int": true, "bigInt": true,
+	"key": true,
+}
+
+// IsLegacyDeployArgJson 은 하나의 deploy argument JSON object가 legacy 형식(Deploy_LegacyArg)인지 판별하는 함수.
+func IsLegacyDeployArgJson(src []byte) (bool, error) {
+	var arg struct {
+		Value map[string]json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(src, &arg); err != nil {
+		return false, err
+	}
+
+	for field := range arg.Value {
+		if legacyArgValueFields[field] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// jsonToDeployArg 는 deploy argument JSON object 하나를 형식에 맞게 Deploy_Arg로 변환하는 함수.
+func jsonToDeployArg(src []byte) (*consensus.Deploy_Arg, error) {
+	isLegacy, err := IsLegacyDeployArgJson(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isLegacy {
+		arg := &consensus.Deploy_Arg{}
+		if err := jsonpb.Unmarshal(bytes.NewReader(src), arg); err != nil {
+			return nil, err
+		}
+		return arg, nil
+	}
+
+	legacyArg := &consensus.Deploy_LegacyArg{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(src), legacyArg); err != nil {
+		return nil, err
+	}
+
+	return LegacyDeployArgToDeployArg(legacyArg)
+}
+
+// LegacyDeployArgToDeployArg 는 Deploy_LegacyArg를 CL type을 가진 Deploy_Arg로 변환하는 함수.
+//
+// 변환 규칙은 storedvalue.CLValue.FromStateValue 와 동일하다.
+// int_value 는 I32, long_value 는 I64, bytes_value 는 FixedList(U8), int_list 는 List(I32),
+// string_list 는 List(String), big_int 는 bit width에 따라 U128/U256/U512 로 변환되며
+// 값이 없는 optional_value 는 Option(Any) 로 변환된다.
+func LegacyDeployArgToDeployArg(legacyArg *consensus.Deploy_LegacyArg) (*consensus.Deploy_Arg, error) {
+	value, err := legacyArgValueToCLValueInstance(legacyArg.GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("Legacy argument %q : %s", legacyArg.GetName(), err.Error())
+	}
+
+	return &consensus.Deploy_Arg{
+		Name:  legacyArg.GetName(),
+		Value: value,
+	}, nil
+}
+
+func legacyArgValueToCLValueInstance(value *consensus.Deploy_LegacyArg_Value) (*state.CLValueInstance, error) {
+	switch value.GetValue().(type) {
+	case *consensus.Deploy_LegacyArg_Value_OptionalValue:
+		inner := value.GetOptionalValue()
+		if inner.GetValue() == nil {
+			return &state.CLValueInstance{
+				ClType: &state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{
+					Inner: &state.CLType{Variants: &state.CLType_AnyType{AnyType: &state.CLType_Any{}}}}}},
+				Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{
+					OptionValue: &state.CLValueInstance_Option{}}},
+			}, nil
+		}
+
+		innerValue, err := legacyArgValueToCLValueInstance(inner)
+		if err != nil {
+			return nil, err
+		}
+		return &state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_OptionType{OptionType: &state.CLType_Option{Inner: innerValue.GetClType()}}},
+			Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_OptionValue{
+				OptionValue: &state.CLValueInstance_Option{Value: innerValue.GetValue()}}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_BytesValue:
+		bytesValue := value.GetBytesValue()
+		return &state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_FixedListType{FixedListType: &state.CLType_FixedList{
+				Inner: simpleCLType(state.CLType_U8),
+				Len:   uint32(len(bytesValue))}}},
+			Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_BytesValue{BytesValue: bytesValue}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_IntValue:
+		return &state.CLValueInstance{
+			ClType: simpleCLType(state.CLType_I32),
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I32{I32: value.GetIntValue()}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_IntList:
+		values := []*state.CLValueInstance_Value{}
+		for _, intValue := range value.GetIntList().GetValues() {
+			values = append(values, &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I32{I32: intValue}})
+		}
+		return &state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: simpleCLType(state.CLType_I32)}}},
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{Values: values}}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_StringValue:
+		return &state.CLValueInstance{
+			ClType: simpleCLType(state.CLType_STRING),
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: value.GetStringValue()}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_StringList:
+		values := []*state.CLValueInstance_Value{}
+		for _, stringValue := range value.GetStringList().GetValues() {
+			values = append(values, &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_StrValue{StrValue: stringValue}})
+		}
+		return &state.CLValueInstance{
+			ClType: &state.CLType{Variants: &state.CLType_ListType{ListType: &state.CLType_List{Inner: simpleCLType(state.CLType_STRING)}}},
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_ListValue{ListValue: &state.CLValueInstance_List{Values: values}}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_LongValue:
+		return &state.CLValueInstance{
+			ClType: simpleCLType(state.CLType_I64),
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_I64{I64: value.GetLongValue()}},
+		}, nil
+	case *consensus.Deploy_LegacyArg_Value_BigInt:
+		bigInt := value.GetBigInt()
+		switch bigInt.GetBitWidth() {
+		case 128:
+			return &state.CLValueInstance{
+				ClType: simpleCLType(state.CLType_U128),
+				Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U128{
+					U128: &state.CLValueInstance_U128{Value: bigInt.GetValue()}}},
+			}, nil
+		case 256:
+			return &state.CLValueInstance{
+				ClType: simpleCLType(state.CLType_U256),
+				Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U256{
+					U256: &state.CLValueInstance_U256{Value: bigInt.GetValue()}}},
+			}, nil
+		case 512:
+			return &state.CLValueInstance{
+				ClType: simpleCLType(state.CLType_U512),
+				Value: &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_U512{
+					U512: &state.CLValueInstance_U512{Value: bigInt.GetValue()}}},
+			}, nil
+		default:
+			return nil, fmt.Errorf("Bigint bit width must be 128, 256 or 512, but %d", bigInt.GetBitWidth())
+		}
+	case *consensus.Deploy_LegacyArg_Value_Key:
+		return &state.CLValueInstance{
+			ClType: simpleCLType(state.CLType_KEY),
+			Value:  &state.CLValueInstance_Value{Value: &state.CLValueInstance_Value_Key{Key: value.GetKey()}},
+		}, nil
+	default:
+		return nil, errors.New("Legacy argument value is empty.")
+	}
+}
+
+func simpleCLType(simpleType state.CLType_Simple) *state.CLType {
+	return &state.CLType{Variants: &state.CLType_SimpleType{SimpleType: simpleType}}
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLegacyDeployArgJson(t *testing.T) {
+	isLegacy, err := IsLegacyDeployArgJson([]byte(`{"name":"amount","value":{"int_value":10}}`))
+	assert.NoError(t, err)
+	assert.True(t, isLegacy)
+
+	isLegacy, err = IsLegacyDeployArgJson([]byte(`{"name":"amount","value":{"value":{"i32":10}}}`))
+	assert.NoError(t, err)
+	assert.False(t, isLegacy)
+
+	isLegacy, err = IsLegacyDeployArgJson([]byte(`{"name":"amount","value":{"cl_type":{"simple_type":"I32"},"value":{"i32":10}}}`))
+	assert.NoError(t, err)
+	assert.False(t, isLegacy)
+}
+
+func TestLegacyJsonStringToDeployArgs(t *testing.T) {
+	js := `[
+		{"name":"method","value":{"string_value":"transfer_to_account"}},
+		{"name":"int","value":{"int_value":314}},
+		{"name":"long","value":{"long_value":"2342"}},
+		{"name":"address","value":{"bytes_value":"1wJD3Z0NZG/W3ygqj3qPoFpmKb7AHYAkw2EescH7n4Q="}},
+		{"name":"ints","value":{"int_list":{"values":[1,2,3]}}},
+		{"name":"strings","value":{"string_list":{"values":["a","b"]}}},
+		{"name":"amount","value":{"big_int":{"value":"123456789101112131415161718","bit_width":512}}},
+		{"name":"some","value":{"optional_value":{"big_int":{"value":"100","bit_width":512}}}},
+		{"name":"none","value":{"optional_value":{}}},
+		{"name":"hash","value":{"key":{"hash":{"hash":"1wJD3Z0NZG/W3ygqj3qPoFpmKb7AHYAkw2EescH7n4Q="}}}},
+		{"name":"new","value":{"cl_type":{"simple_type":"I32"},"value":{"i32":7}}}
+	]`
+
+	args, err := JsonStringToDeployArgs(js)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(args))
+
+	assert.Equal(t, "method", args[0].GetName())
+	assert.Equal(t, state.CLType_STRING, args[0].GetValue().GetClType().GetSimpleType())
+	assert.Equal(t, "transfer_to_account", args[0].GetValue().GetValue().GetStrValue())
+
+	assert.Equal(t, state.CLType_I32, args[1].GetValue().GetClType().GetSimpleType())
+	assert.Equal(t, int32(314), args[1].GetValue().GetValue().GetI32())
+
+	assert.Equal(t, state.CLType_I64, args[2].GetValue().GetClType().GetSimpleType())
+	assert.Equal(t, int64(2342), args[2].GetValue().GetValue().GetI64())
+
+	assert.Equal(t, state.CLType_U8, args[3].GetValue().GetClType().GetFixedListType().GetInner().GetSimpleType())
+	assert.Equal(t, uint32(32), args[3].GetValue().GetClType().GetFixedListType().GetLen())
+	assert.Equal(t, "d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84", EncodeToHexString(args[3].GetValue().GetValue().GetBytesValue()))
+
+	assert.Equal(t, state.CLType_I32, args[4].GetValue().GetClType().GetListType().GetInner().GetSimpleType())
+	assert.Equal(t, int32(3), args[4].GetValue().GetValue().GetListValue().GetValues()[2].GetI32())
+
+	assert.Equal(t, state.CLType_STRING, args[5].GetValue().GetClType().GetListType().GetInner().GetSimpleType())
+	assert.Equal(t, "b", args[5].GetValue().GetValue().GetListValue().GetValues()[1].GetStrValue())
+
+	assert.Equal(t, state.CLType_U512, args[6].GetValue().GetClType().GetSimpleType())
+	assert.Equal(t, "123456789101112131415161718", args[6].GetValue().GetValue().GetU512().GetValue())
+
+	assert.Equal(t, state.CLType_U512, args[7].GetValue().GetClType().GetOptionType().GetInner().GetSimpleType())
+	assert.Equal(t, "100", args[7].GetValue().GetValue().GetOptionValue().GetValue().GetU512().GetValue())
+
+	assert.NotNil(t, args[8].GetValue().GetClType().GetOptionType().GetInner().GetAnyType())
+	assert.Nil(t, args[8].GetValue().GetValue().GetOptionValue().GetValue())
+
+	assert.Equal(t, state.CLType_KEY, args[9].GetValue().GetClType().GetSimpleType())
+	assert.Equal(t, "d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84", EncodeToHexString(args[9].GetValue().GetValue().GetKey().GetHash().GetHash()))
+
+	assert.Equal(t, state.CLType_I32, args[10].GetValue().GetClType().GetSimpleType())
+	assert.Equal(t, int32(7), args[10].GetValue().GetValue().GetI32())
+}
+
+func TestLegacyDeployArgsAbi(t *testing.T) {
+	legacyArgs, err := JsonStringToDeployArgs(`[{"name":"method","value":{"string_value":"bond"}},{"name":"amount","value":{"big_int":{"value":"256","bit_width":512}}}]`)
+	assert.NoError(t, err)
+	legacyAbi, err := AbiDeployArgsTobytes(legacyArgs)
+	assert.NoError(t, err)
+
+	args, err := JsonStringToDeployArgs(`[{"name":"method","value":{"cl_type":{"simple_type":"STRING"},"value":{"str_value":"bond"}}},{"name":"amount","value":{"cl_type":{"simple_type":"U512"},"value":{"u512":{"value":"256"}}}}]`)
+	assert.NoError(t, err)
+	abi, err := AbiDeployArgsTobytes(args)
+	assert.NoError(t, err)
+
+	assert.Equal(t, abi, legacyAbi)
+}
+
+func TestLegacyBigIntInvalidBitWidth(t *testing.T) {
+	_, err := JsonStringToDeployArgs(`[{"name":"amount","value":{"big_int":{"value":"256","bit_width":64}}}]`)
+	assert.Error(t, err)
+}
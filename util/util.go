@@ -5,11 +5,11 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"strings"
 
 	"github.com/gogo/protobuf/jsonpb"
-	"github.com/gogo/protobuf/proto"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/casper/consensus/state"
 	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
@@ -21,8 +21,13 @@ import (
 const StrEmptyStateHash = "3307a54ca6d5bfbafc0ef1b003f3ec4941c011ee7f79889e44416754de2f091d"
 
 // LoadWasmFile 은 wasm 파일을 byte array로 return 해주는 함수.
-func LoadWasmFile(path string) []byte {
-	wasmCode, err := ioutil.ReadFile(path)
+func LoadWasmFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+// MustLoadWasmFile 은 LoadWasmFile 과 같으나 파일을 읽지 못하면 panic 하는 함수.
+func MustLoadWasmFile(path string) []byte {
+	wasmCode, err := LoadWasmFile(path)
 	if err != nil {
 		panic(err)
 	}
@@ -36,8 +41,15 @@ func EncodeToHexString(src []byte) string {
 }
 
 // DecodeHexString 는 hex string을 byte array로 변경해주는 함수.
-func DecodeHexString(str string) []byte {
-	res, err := hex.DecodeString(str)
+func DecodeHexString(str string) ([]byte, error) {
+	return hex.DecodeString(str)
+}
+
+// MustDecodeHexString 은 DecodeHexString 과 같으나 hex string이 잘못된 경우 panic 하는 함수.
+//
+// 상수로 정의된 hex string 처럼 잘못될 수 없는 입력에만 사용한다.
+func MustDecodeHexString(str string) []byte {
+	res, err := DecodeHexString(str)
 	if err != nil {
 		panic(err)
 	}
@@ -46,14 +58,11 @@ func DecodeHexString(str string) []byte {
 }
 
 // Blake2b256 는 blake2b 256 hash 결과 값을 return 해주는 함수.
+//
+// key 없이 hash 하므로 실패하지 않는다.
 func Blake2b256(ob []byte) []byte {
-	hash, err := blake2b.New256(nil)
-	if err != nil {
-		panic(err)
-	}
-
-	hash.Write(ob)
-	return hash.Sum(nil)
+	hash := blake2b.Sum256(ob)
+	return hash[:]
 }
 
 // MakeProtocolVersion 은 major, minor, patch의 값을 받아 ProtocolVersion 을 만들어주는 함수
@@ -61,22 +70,28 @@ func MakeProtocolVersion(major uint32, minor uint32, patch uint32) *state.Protoc
 	return &state.ProtocolVersion{Major: uint32(major), Minor: uint32(minor), Patch: uint32(patch)}
 }
 
+// AbiDeployArgsTobytes 는 Deploy_Arg list를 Execution Engine이 받는 ABI bytes로 변환하는 함수.
+//
+// 각 argument는 cl_type에 따라 serialize 되며, cl_type이 없는 경우 value로부터 type을 추론한다.
 func AbiDeployArgsTobytes(src []*consensus.Deploy_Arg) ([]byte, error) {
 	res := make([]byte, 4)
 	binary.LittleEndian.PutUint32(res, uint32(len(src)))
 
 	for _, deployArg := range src {
-		var clValue storedvalue.CLValue
-		clValue, err := clValue.FromCLValueInstanceValue(deployArg.GetValue().GetValue())
+		clValueBytes, err := storedvalue.CLValueInstanceToBytes(deployArg.GetValue())
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("Argument %q : %s", deployArg.GetName(), err.Error())
 		}
-		res = append(res, clValue.ToBytes()...)
+		res = append(res, clValueBytes...)
 	}
 
 	return res, nil
 }
 
+// JsonStringToDeployArgs 는 deploy argument JSON array를 Deploy_Arg list로 변환하는 함수.
+//
+// 각 argument는 신규 형식(CLValueInstance)과 legacy 형식(Deploy_LegacyArg) 모두 허용하며,
+// legacy 형식은 자동으로 감지되어 CL type을 가진 Deploy_Arg로 변환된다.
 func JsonStringToDeployArgs(str string) (deployArgs []*consensus.Deploy_Arg, err error) {
 	if str == "" {
 		return []*consensus.Deploy_Arg{}, nil
@@ -89,12 +104,17 @@ func JsonStringToDeployArgs(str string) (deployArgs []*consensus.Deploy_Arg, err
 	}
 
 	for jsonDecoder.More() {
-		arg := consensus.Deploy_Arg{}
-		err := jsonpb.UnmarshalNext(jsonDecoder, &arg)
+		var rawArg json.RawMessage
+		err := jsonDecoder.Decode(&rawArg)
 		if err != nil {
 			return nil, err
 		}
-		deployArgs = append(deployArgs, &arg)
+
+		arg, err := jsonToDeployArg(rawArg)
+		if err != nil {
+			return nil, err
+		}
+		deployArgs = append(deployArgs, arg)
 	}
 
 	return deployArgs, nil
@@ -120,11 +140,9 @@ func DeployArgsToJsonString(args []*consensus.Deploy_Arg) (string, error) {
 
 // MakeDeploy 는 address, sessionCode, sessionArgs, paymentCode, paymentArgs, gasPrice, timestamp를 받아 DeployItem을 만들어주는 함수.
 //
-// Seesion, Payment 데이터로 Deploy Body를 만들고 이를 Marshal한 값을 Blake2b256 Hash를 하여 Deploy Body Hash 값을 만든다.
-// Deploy Body Hash 값을 포함한 Deploy Header 값을 만들고 이를 Marshal한 값을 Blake2b256 Hash하여 Deploy Header Hash 값을 만든다.
-// Deploy Header Hash 값을 Deploy Item을 만들고 return 해준다.
+// timestamp는 millisecond 단위이며, TTL이나 dependency가 필요한 경우 DeployBuilder 를 사용한다.
 func MakeDeploy(
-	fromAddress []byte,
+	fromAddress storedvalue.Address,
 	sessionType ContractType,
 	sessionData []byte,
 	sessionArgsStr string,
@@ -134,51 +152,18 @@ func MakeDeploy(
 	gasPrice uint64,
 	int64Timestamp int64,
 	chainName string) (deploy *ipc.DeployItem, err error) {
-	timestamp := uint64(int64Timestamp)
-
-	sessionArgs, err := JsonStringToDeployArgs(sessionArgsStr)
-	if err != nil {
-		return nil, err
-	}
-	paymentArgs, err := JsonStringToDeployArgs(paymentArgsStr)
-	if err != nil {
-		return nil, err
-	}
-	deployBody := &consensus.Deploy_Body{
-		Session: MakeDeployCode(sessionType, sessionData, sessionArgs),
-		Payment: MakeDeployCode(paymentType, paymentData, paymentArgs)}
-
-	marshalDeployBody, _ := proto.Marshal(deployBody)
-	bodyHash := Blake2b256(marshalDeployBody)
-
-	deployHeader := &consensus.Deploy_Header{
-		AccountPublicKey: fromAddress,
-		Timestamp:        timestamp,
-		GasPrice:         gasPrice,
-		BodyHash:         bodyHash,
-		ChainName:        chainName}
-
-	marshalDeployHeader, _ := proto.Marshal(deployHeader)
-	headerHash := Blake2b256(marshalDeployHeader)
-
-	sessionAbi, err := AbiDeployArgsTobytes(sessionArgs)
-	if err != nil {
-		return nil, err
-	}
-	paymentAbi, err := AbiDeployArgsTobytes(paymentArgs)
-	if err != nil {
-		return nil, err
+	if int64Timestamp < 0 {
+		return nil, fmt.Errorf("Timestamp must be non-negative, but %d", int64Timestamp)
 	}
 
-	deploy = &ipc.DeployItem{
-		Address:           fromAddress,
-		Session:           MakeDeployPayload(sessionType, sessionData, sessionAbi),
-		Payment:           MakeDeployPayload(paymentType, paymentData, paymentAbi),
-		GasPrice:          gasPrice,
-		AuthorizationKeys: [][]byte{fromAddress},
-		DeployHash:        headerHash}
+	_, deploy, err = NewDeployBuilder(fromAddress, chainName).
+		WithSessionJson(sessionType, sessionData, sessionArgsStr).
+		WithPaymentJson(paymentType, paymentData, paymentArgsStr).
+		WithGasPrice(gasPrice).
+		WithTimestampMillis(uint64(int64Timestamp)).
+		Build()
 
-	return deploy, nil
+	return deploy, err
 }
 
 type ContractType int
@@ -248,9 +233,19 @@ func GenesisConfigMock(
 
 	// load mint_install.wasm, pos_install.wasm
 
-	genesisConfig.MintInstaller = LoadWasmFile(mintInstallWasmPath)
-	genesisConfig.PosInstaller = LoadWasmFile(posInstallWasmPath)
-	genesisConfig.StandardPaymentInstaller = LoadWasmFile(standardPaymentInstallWasmPath)
+	var err error
+	genesisConfig.MintInstaller, err = LoadWasmFile(mintInstallWasmPath)
+	if err != nil {
+		return nil, err
+	}
+	genesisConfig.PosInstaller, err = LoadWasmFile(posInstallWasmPath)
+	if err != nil {
+		return nil, err
+	}
+	genesisConfig.StandardPaymentInstaller, err = LoadWasmFile(standardPaymentInstallWasmPath)
+	if err != nil {
+		return nil, err
+	}
 
 	// GenesisAccount
 	genesisConfig.Accounts = genesisAccount
@@ -289,7 +284,31 @@ func GenesisConfigMock(
 	return &genesisConfig, nil
 }
 
+// MakeLocalKey 는 seed 뒤에 keyBytes의 blake2b256 hash를 붙여 local key bytes를 만드는 함수.
 func MakeLocalKey(seed []byte, keyBytes []byte) []byte {
 	hash := Blake2b256(keyBytes)
-	return append(seed, hash...)
+	res := make([]byte, 0, len(seed)+len(hash))
+	res = append(res, seed...)
+	return append(res, hash...)
+}
+
+// MakeLocalStateKey 는 contract의 seed URef와 Go/CL value로 local storage의 Key를 만드는 함수.
+//
+// value는 storedvalue.ToCLValueInstance 가 지원하는 값(string, []byte, storedvalue.CLTuple, Key 등)이며,
+// CL type에 맞게 serialize 한 bytes로 local key를 만든다.
+func MakeLocalStateKey(seed storedvalue.URef, value interface{}) (*state.Key, error) {
+	if len(seed.GetAddress()) != storedvalue.ADDRESS_LENGTH {
+		return nil, fmt.Errorf("Seed URef length %d, but %d", storedvalue.ADDRESS_LENGTH, len(seed.GetAddress()))
+	}
+
+	instance, err := storedvalue.ToCLValueInstance(value)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := storedvalue.CLValueInstanceValueToBytes(instance.GetClType(), instance.GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return &state.Key{Value: &state.Key_Local_{Local: &state.Key_Local{Hash: MakeLocalKey(seed.GetAddress(), keyBytes)}}}, nil
 }
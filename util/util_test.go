@@ -16,8 +16,23 @@ func TestEncodeToHexString(t *testing.T) {
 }
 
 func TestDecodeHexString(t *testing.T) {
-	res := DecodeHexString("1d526a")
+	res, err := DecodeHexString("1d526a")
+	assert.NoError(t, err)
 	assert.Equal(t, res, []byte{29, 82, 106}, "they should be equal")
+
+	_, err = DecodeHexString("1d526")
+	assert.Error(t, err)
+	_, err = DecodeHexString("zz")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustDecodeHexString("zz") })
+}
+
+func TestLoadWasmFile(t *testing.T) {
+	_, err := LoadWasmFile("./not_exist.wasm")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustLoadWasmFile("./not_exist.wasm") })
 }
 
 func TestBlake2b256_0(t *testing.T) {
@@ -210,7 +225,8 @@ func TestMapAbi(t *testing.T) {
 	storedValue, err, _ := storedValue.FromBytes(src)
 	assert.NoError(t, err)
 
-	value := storedValue.ClValue.ToCLInstanceValue()
+	value, err := storedValue.ClValue.ToCLInstanceValue()
+	assert.NoError(t, err)
 
 	assert.Equal(t, len(value.GetMapValue().GetValues()), 2)
 
@@ -449,3 +465,66 @@ func TestJsonAllType(t *testing.T) {
 	assert.Equal(t, state.CLType_U8, res[26].Value.GetClType().GetFixedListType().GetInner().GetSimpleType())
 	assert.Equal(t, "d70243dd9d0d646fd6df282a8f7a8fa05a6629bec01d8024c3611eb1c1fb9f84", EncodeToHexString(res[26].GetValue().GetValue().GetBytesValue()))
 }
+
+func TestNestedTypeAbi(t *testing.T) {
+	js := `[
+		{
+			"name" : "map_string_i32",
+			"value" : {
+				"cl_type" : {"map_type" : {"key" : {"simple_type" : "STRING"}, "value" : {"simple_type" : "I32"}}},
+				"value" : {"map_value" : {"values" : [{"key" : {"str_value" : "A"}, "value" : {"i32" : 1}}]}}
+			}
+		},
+		{
+			"name" : "tuple2",
+			"value" : {
+				"cl_type" : {"tuple2_type" : {"type0" : {"simple_type" : "U8"}, "type1" : {"option_type" : {"inner" : {"simple_type" : "U32"}}}}},
+				"value" : {"tuple2_value" : {"value_1" : {"u8" : 8}, "value_2" : {"option_value" : {}}}}
+			}
+		}
+	]`
+
+	deployArgs, err := JsonStringToDeployArgs(js)
+	assert.NoError(t, err)
+
+	abi, err := AbiDeployArgsTobytes(deployArgs)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []byte{
+		2, 0, 0, 0,
+		13, 0, 0, 0,
+		1, 0, 0, 0,
+		1, 0, 0, 0, 65,
+		1, 0, 0, 0,
+		17, 10, 1,
+		2, 0, 0, 0,
+		8, 0,
+		19, 3, 13, 4,
+	}, abi)
+}
+
+func TestMakeLocalStateKey(t *testing.T) {
+	seed := storedvalue.NewURef(Blake2b256([]byte("seed")), state.Key_URef_READ_ADD_WRITE)
+	address := Blake2b256([]byte("address"))
+
+	// u32 length + prefix + address 로 직접 만든 byte list와 같아야 한다.
+	keyBytes := append([]byte{33, 0, 0, 0, 32}, address...)
+	key, err := MakeLocalStateKey(seed, append([]byte{32}, address...))
+	assert.NoError(t, err)
+	assert.Equal(t, MakeLocalKey(seed.Address, keyBytes), key.GetLocal().GetHash())
+
+	var purseID [32]byte
+	copy(purseID[:], address)
+	key, err = MakeLocalStateKey(seed, purseID)
+	assert.NoError(t, err)
+	assert.Equal(t, MakeLocalKey(seed.Address, address), key.GetLocal().GetHash())
+
+	key, err = MakeLocalStateKey(seed, storedvalue.CLTuple{"a", uint8(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, MakeLocalKey(seed.Address, []byte{1, 0, 0, 0, 97, 1}), key.GetLocal().GetHash())
+
+	_, err = MakeLocalStateKey(storedvalue.NewURef([]byte{1}, state.Key_URef_READ), "a")
+	assert.Error(t, err)
+	_, err = MakeLocalStateKey(seed, 1.5)
+	assert.Error(t, err)
+}
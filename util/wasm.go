@@ -0,0 +1,538 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+)
+
+// WasmExternalKind 는 wasm import/export 항목의 종류.
+type WasmExternalKind byte
+
+const (
+	WASM_EXTERNAL_FUNCTION WasmExternalKind = 0
+	WASM_EXTERNAL_TABLE    WasmExternalKind = 1
+	WASM_EXTERNAL_MEMORY   WasmExternalKind = 2
+	WASM_EXTERNAL_GLOBAL   WasmExternalKind = 3
+)
+
+const (
+	WASM_VERSION = 1
+
+	WASM_SECTION_CUSTOM   = 0
+	WASM_SECTION_TYPE     = 1
+	WASM_SECTION_IMPORT   = 2
+	WASM_SECTION_FUNCTION = 3
+	WASM_SECTION_MEMORY   = 5
+	WASM_SECTION_EXPORT   = 7
+	WASM_SECTION_CODE     = 10
+
+	WASM_FUNC_TYPE_FORM = 0x60
+
+	// WASM_CALL_EXPORT 는 Execution Engine이 실행하는 contract의 entry point 이름.
+	WASM_CALL_EXPORT = "call"
+
+	// WASM_HOST_MODULE 은 Execution Engine이 host function을 제공하는 import module 이름.
+	WASM_HOST_MODULE = "env"
+)
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+
+// WasmHostFunctions 는 Execution Engine이 "env" module로 제공하는 host function 목록.
+var WasmHostFunctions = map[string]bool{
+	"read_value":                     true,
+	"read_value_local":               true,
+	"load_named_keys":                true,
+	"write":                          true,
+	"write_local":                    true,
+	"add":                            true,
+	"add_local":                      true,
+	"new_uref":                       true,
+	"ret":                            true,
+	"get_key":                        true,
+	"has_key":                        true,
+	"put_key":                        true,
+	"remove_key":                     true,
+	"get_arg_size":                   true,
+	"get_arg":                        true,
+	"store_function":                 true,
+	"store_function_at_hash":         true,
+	"call_contract":                  true,
+	"get_caller":                     true,
+	"is_valid_uref":                  true,
+	"revert":                         true,
+	"add_associated_key":             true,
+	"remove_associated_key":          true,
+	"update_associated_key":          true,
+	"set_action_threshold":           true,
+	"get_blocktime":                  true,
+	"create_purse":                   true,
+	"transfer_to_account":            true,
+	"transfer_from_purse_to_account": true,
+	"transfer_from_purse_to_purse":   true,
+	"get_balance":                    true,
+	"get_phase":                      true,
+	"upgrade_contract_at_uref":       true,
+	"get_system_contract":            true,
+	"get_main_purse":                 true,
+	"read_host_buffer":               true,
+	"gas":                            true,
+}
+
+// WasmImport 는 wasm module의 import 항목.
+type WasmImport struct {
+	Module string
+	Name   string
+	Kind   WasmExternalKind
+}
+
+// WasmExport 는 wasm module의 export 항목.
+type WasmExport struct {
+	Name  string
+	Kind  WasmExternalKind
+	Index uint32
+}
+
+// WasmModule 은 Execution Engine에 보내기 전 검사에 필요한 wasm module 정보.
+//
+// InitialMemPages, MaxMemPages 는 module이 정의하거나 import하는 memory의 page(64kb) 수이며,
+// MaxFunctionFrame 은 function 하나의 parameter와 local 개수 합의 최대값으로
+// Execution Engine의 stack height 계산에서 operand stack을 제외한 하한 값이다.
+type WasmModule struct {
+	Size             int
+	Imports          []WasmImport
+	Exports          []WasmExport
+	HasMemory        bool
+	InitialMemPages  uint32
+	MaxMemPages      uint32
+	HasMaxMem        bool
+	FunctionCount    int
+	MaxFunctionFrame uint32
+}
+
+// ParseWasmModule 은 wasm binary를 parse하여 WasmModule 을 만드는 함수.
+//
+// magic/version 과 section 경계를 검사하며, 잘못된 binary인 경우 byte offset을 포함한 error를 return 한다.
+func ParseWasmModule(code []byte) (*WasmModule, error) {
+	if len(code) < 8 {
+		return nil, fmt.Errorf("Wasm binary more than 8 bytes, but %d", len(code))
+	}
+	if !bytes.Equal(code[:4], wasmMagic) {
+		return nil, fmt.Errorf("Invalid wasm magic : %x", code[:4])
+	}
+	version := uint32(code[4]) | uint32(code[5])<<8 | uint32(code[6])<<16 | uint32(code[7])<<24
+	if version != WASM_VERSION {
+		return nil, fmt.Errorf("Unsupported wasm version : %d", version)
+	}
+
+	module := &WasmModule{Size: len(code)}
+	reader := &wasmReader{src: code, pos: 8}
+
+	var funcTypeParams []uint32
+	var importedFuncTypes []uint32
+	var funcTypeIndices []uint32
+
+	for reader.pos < len(code) {
+		sectionID, err := reader.byte()
+		if err != nil {
+			return nil, err
+		}
+		sectionSize, err := reader.u32()
+		if err != nil {
+			return nil, err
+		}
+		if int(sectionSize) > len(code)-reader.pos {
+			return nil, fmt.Errorf("Section %d at offset %d overflows binary : size %d", sectionID, reader.pos, sectionSize)
+		}
+		section := &wasmReader{src: code[:reader.pos+int(sectionSize)], pos: reader.pos}
+		reader.pos += int(sectionSize)
+
+		switch sectionID {
+		case WASM_SECTION_TYPE:
+			funcTypeParams, err = parseWasmTypeSection(section)
+		case WASM_SECTION_IMPORT:
+			importedFuncTypes, err = parseWasmImportSection(section, module)
+		case WASM_SECTION_FUNCTION:
+			funcTypeIndices, err = section.u32Vector()
+		case WASM_SECTION_MEMORY:
+			err = parseWasmMemorySection(section, module)
+		case WASM_SECTION_EXPORT:
+			err = parseWasmExportSection(section, module)
+		case WASM_SECTION_CODE:
+			err = parseWasmCodeSection(section, module, funcTypeParams, funcTypeIndices)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Section %d : %s", sectionID, err.Error())
+		}
+	}
+
+	module.FunctionCount = len(importedFuncTypes) + len(funcTypeIndices)
+
+	return module, nil
+}
+
+// ValidateWasmModule 은 module이 Execution Engine에서 실행 가능한지 검사하여 문제 목록을 return 하는 함수.
+//
+// call export 존재 여부, host가 제공하지 않는 import, WasmCosts 의 InitialMem, MaxStackHeight,
+// DeployConfig 의 MaxBlockSizeBytes 를 검사한다. costs, deployConfig 가 nil이면 해당 검사는 생략한다.
+func ValidateWasmModule(
+	module *WasmModule, costs *ipc.ChainSpec_CostTable_WasmCosts, deployConfig *ipc.ChainSpec_DeployConfig) []error {
+	problems := []error{}
+
+	hasCall := false
+	for _, export := range module.Exports {
+		if export.Name == WASM_CALL_EXPORT && export.Kind == WASM_EXTERNAL_FUNCTION {
+			hasCall = true
+			break
+		}
+	}
+	if !hasCall {
+		problems = append(problems, fmt.Errorf("Missing %q function export", WASM_CALL_EXPORT))
+	}
+
+	for _, unknownImport := range module.UnknownImports() {
+		problems = append(problems, fmt.Errorf("Import %s.%s is not provided by host", unknownImport.Module, unknownImport.Name))
+	}
+
+	if costs != nil {
+		if module.InitialMemPages > costs.GetInitialMem() {
+			problems = append(problems,
+				fmt.Errorf("Initial memory %d pages exceeds %d pages", module.InitialMemPages, costs.GetInitialMem()))
+		}
+		if module.MaxFunctionFrame > costs.GetMaxStackHeight() {
+			problems = append(problems,
+				fmt.Errorf("Function frame %d exceeds max stack height %d", module.MaxFunctionFrame, costs.GetMaxStackHeight()))
+		}
+	}
+
+	if deployConfig != nil && deployConfig.GetMaxBlockSizeBytes() != 0 &&
+		uint64(module.Size) > uint64(deployConfig.GetMaxBlockSizeBytes()) {
+		problems = append(problems,
+			fmt.Errorf("Wasm size %d bytes exceeds max block size %d bytes", module.Size, deployConfig.GetMaxBlockSizeBytes()))
+	}
+
+	return problems
+}
+
+// ValidateWasm 은 wasm binary를 parse 후 ValidateWasmModule 로 검사하는 함수.
+//
+// binary를 parse 할 수 없으면 그 error 하나만 return 한다.
+func ValidateWasm(
+	code []byte, costs *ipc.ChainSpec_CostTable_WasmCosts, deployConfig *ipc.ChainSpec_DeployConfig) (*WasmModule, []error) {
+	module, err := ParseWasmModule(code)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return module, ValidateWasmModule(module, costs, deployConfig)
+}
+
+// UnknownImports 는 Execution Engine이 제공하지 않는 import 목록을 return 하는 함수.
+func (m *WasmModule) UnknownImports() []WasmImport {
+	unknownImports := []WasmImport{}
+	for _, wasmImport := range m.Imports {
+		if wasmImport.Module == WASM_HOST_MODULE {
+			if wasmImport.Kind == WASM_EXTERNAL_FUNCTION && WasmHostFunctions[wasmImport.Name] {
+				continue
+			}
+			if wasmImport.Kind == WASM_EXTERNAL_MEMORY && wasmImport.Name == "memory" {
+				continue
+			}
+		}
+		unknownImports = append(unknownImports, wasmImport)
+	}
+
+	sort.Slice(unknownImports, func(i, j int) bool {
+		if unknownImports[i].Module != unknownImports[j].Module {
+			return unknownImports[i].Module < unknownImports[j].Module
+		}
+		return unknownImports[i].Name < unknownImports[j].Name
+	})
+
+	return unknownImports
+}
+
+func parseWasmTypeSection(reader *wasmReader) (funcTypeParams []uint32, err error) {
+	count, err := reader.u32()
+	if err != nil {
+		return nil, err
+	}
+	for idx := uint32(0); idx < count; idx++ {
+		form, err := reader.byte()
+		if err != nil {
+			return nil, err
+		}
+		if form != WASM_FUNC_TYPE_FORM {
+			return nil, fmt.Errorf("Invalid function type form 0x%x at offset %d", form, reader.pos-1)
+		}
+		params, err := reader.u32()
+		if err != nil {
+			return nil, err
+		}
+		if err := reader.skip(int(params)); err != nil {
+			return nil, err
+		}
+		results, err := reader.u32()
+		if err != nil {
+			return nil, err
+		}
+		if err := reader.skip(int(results)); err != nil {
+			return nil, err
+		}
+		funcTypeParams = append(funcTypeParams, params)
+	}
+
+	return funcTypeParams, nil
+}
+
+func parseWasmImportSection(reader *wasmReader, module *WasmModule) (importedFuncTypes []uint32, err error) {
+	count, err := reader.u32()
+	if err != nil {
+		return nil, err
+	}
+	for idx := uint32(0); idx < count; idx++ {
+		moduleName, err := reader.name()
+		if err != nil {
+			return nil, err
+		}
+		name, err := reader.name()
+		if err != nil {
+			return nil, err
+		}
+		kind, err := reader.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch WasmExternalKind(kind) {
+		case WASM_EXTERNAL_FUNCTION:
+			typeIndex, err := reader.u32()
+			if err != nil {
+				return nil, err
+			}
+			importedFuncTypes = append(importedFuncTypes, typeIndex)
+		case WASM_EXTERNAL_TABLE:
+			if err := reader.skip(1); err != nil {
+				return nil, err
+			}
+			if _, _, _, err := reader.limits(); err != nil {
+				return nil, err
+			}
+		case WASM_EXTERNAL_MEMORY:
+			initial, maximum, hasMaximum, err := reader.limits()
+			if err != nil {
+				return nil, err
+			}
+			module.setMemory(initial, maximum, hasMaximum)
+		case WASM_EXTERNAL_GLOBAL:
+			if err := reader.skip(2); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("Invalid import kind %d at offset %d", kind, reader.pos-1)
+		}
+
+		module.Imports = append(module.Imports, WasmImport{Module: moduleName, Name: name, Kind: WasmExternalKind(kind)})
+	}
+
+	return importedFuncTypes, nil
+}
+
+func parseWasmMemorySection(reader *wasmReader, module *WasmModule) error {
+	count, err := reader.u32()
+	if err != nil {
+		return err
+	}
+	for idx := uint32(0); idx < count; idx++ {
+		initial, maximum, hasMaximum, err := reader.limits()
+		if err != nil {
+			return err
+		}
+		module.setMemory(initial, maximum, hasMaximum)
+	}
+
+	return nil
+}
+
+func parseWasmExportSection(reader *wasmReader, module *WasmModule) error {
+	count, err := reader.u32()
+	if err != nil {
+		return err
+	}
+	for idx := uint32(0); idx < count; idx++ {
+		name, err := reader.name()
+		if err != nil {
+			return err
+		}
+		kind, err := reader.byte()
+		if err != nil {
+			return err
+		}
+		index, err := reader.u32()
+		if err != nil {
+			return err
+		}
+		module.Exports = append(module.Exports, WasmExport{Name: name, Kind: WasmExternalKind(kind), Index: index})
+	}
+
+	return nil
+}
+
+func parseWasmCodeSection(reader *wasmReader, module *WasmModule, funcTypeParams []uint32, funcTypeIndices []uint32) error {
+	count, err := reader.u32()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(funcTypeIndices) {
+		return fmt.Errorf("Code section has %d bodies, but function section has %d", count, len(funcTypeIndices))
+	}
+
+	for idx := uint32(0); idx < count; idx++ {
+		bodySize, err := reader.u32()
+		if err != nil {
+			return err
+		}
+		bodyEnd := reader.pos + int(bodySize)
+		if bodyEnd > len(reader.src) {
+			return fmt.Errorf("Function body %d at offset %d overflows section", idx, reader.pos)
+		}
+
+		frame := uint64(0)
+		typeIndex := funcTypeIndices[idx]
+		if int(typeIndex) >= len(funcTypeParams) {
+			return fmt.Errorf("Function %d has invalid type index %d", idx, typeIndex)
+		}
+		frame += uint64(funcTypeParams[typeIndex])
+
+		localGroups, err := reader.u32()
+		if err != nil {
+			return err
+		}
+		for group := uint32(0); group < localGroups; group++ {
+			locals, err := reader.u32()
+			if err != nil {
+				return err
+			}
+			if err := reader.skip(1); err != nil {
+				return err
+			}
+			frame += uint64(locals)
+		}
+		if frame > uint64(module.MaxFunctionFrame) {
+			if frame > uint64(^uint32(0)) {
+				frame = uint64(^uint32(0))
+			}
+			module.MaxFunctionFrame = uint32(frame)
+		}
+
+		reader.pos = bodyEnd
+	}
+
+	return nil
+}
+
+func (m *WasmModule) setMemory(initial uint32, maximum uint32, hasMaximum bool) {
+	m.HasMemory = true
+	m.InitialMemPages = initial
+	m.MaxMemPages = maximum
+	m.HasMaxMem = hasMaximum
+}
+
+type wasmReader struct {
+	src []byte
+	pos int
+}
+
+func (r *wasmReader) byte() (byte, error) {
+	if r.pos >= len(r.src) {
+		return 0, fmt.Errorf("Unexpected end of wasm binary at offset %d", r.pos)
+	}
+	b := r.src[r.pos]
+	r.pos++
+
+	return b, nil
+}
+
+func (r *wasmReader) skip(length int) error {
+	if length < 0 || length > len(r.src)-r.pos {
+		return fmt.Errorf("Unexpected end of wasm binary at offset %d", r.pos)
+	}
+	r.pos += length
+
+	return nil
+}
+
+// u32 는 unsigned LEB128 로 encoding 된 u32 를 읽는 함수.
+func (r *wasmReader) u32() (uint32, error) {
+	start := r.pos
+	var res uint64
+	for shift := uint(0); shift < 35; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		res |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			if res > uint64(^uint32(0)) {
+				return 0, fmt.Errorf("LEB128 u32 overflow at offset %d", start)
+			}
+			return uint32(res), nil
+		}
+	}
+
+	return 0, fmt.Errorf("LEB128 u32 too long at offset %d", start)
+}
+
+func (r *wasmReader) u32Vector() ([]uint32, error) {
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	if int(count) > len(r.src)-r.pos {
+		return nil, fmt.Errorf("Vector length %d at offset %d overflows section", count, r.pos)
+	}
+	res := make([]uint32, count)
+	for idx := range res {
+		res[idx], err = r.u32()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (r *wasmReader) name() (string, error) {
+	length, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	start := r.pos
+	if err := r.skip(int(length)); err != nil {
+		return "", err
+	}
+
+	return string(r.src[start:r.pos]), nil
+}
+
+func (r *wasmReader) limits() (initial uint32, maximum uint32, hasMaximum bool, err error) {
+	flags, err := r.byte()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	initial, err = r.u32()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if flags&1 == 1 {
+		maximum, err = r.u32()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		hasMaximum = true
+	}
+
+	return initial, maximum, hasMaximum, nil
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hdac-io/casperlabs-ee-grpc-go-util/protobuf/io/casperlabs/ipc"
+	"github.com/stretchr/testify/assert"
+)
+
+// makeTestWasm 은 memory 1개와 주어진 import, export를 가진 최소한의 wasm module을 만든다.
+func makeTestWasm(importName string, exportName string, memPages byte) []byte {
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	// type section : () -> ()
+	code = append(code, WASM_SECTION_TYPE, 4, 1, WASM_FUNC_TYPE_FORM, 0, 0)
+	// import section : env.<importName> func type 0
+	importEntry := append([]byte{1, 3, 'e', 'n', 'v', byte(len(importName))}, importName...)
+	importEntry = append(importEntry, 0, 0)
+	code = append(code, WASM_SECTION_IMPORT, byte(len(importEntry)))
+	code = append(code, importEntry...)
+	// function section : 1 function of type 0
+	code = append(code, WASM_SECTION_FUNCTION, 2, 1, 0)
+	// memory section
+	code = append(code, WASM_SECTION_MEMORY, 3, 1, 0, memPages)
+	// export section : <exportName> func 1
+	exportEntry := append([]byte{1, byte(len(exportName))}, exportName...)
+	exportEntry = append(exportEntry, 0, 1)
+	code = append(code, WASM_SECTION_EXPORT, byte(len(exportEntry)))
+	code = append(code, exportEntry...)
+	// code section : 1 body with 3 i32 locals, "end"
+	code = append(code, WASM_SECTION_CODE, 6, 1, 4, 1, 3, 0x7f, 0x0b)
+
+	return code
+}
+
+func TestParseWasmModule(t *testing.T) {
+	module, err := ParseWasmModule(makeTestWasm("ret", "call", 17))
+	assert.NoError(t, err)
+	assert.Equal(t, []WasmImport{{Module: "env", Name: "ret", Kind: WASM_EXTERNAL_FUNCTION}}, module.Imports)
+	assert.Equal(t, []WasmExport{{Name: "call", Kind: WASM_EXTERNAL_FUNCTION, Index: 1}}, module.Exports)
+	assert.Equal(t, uint32(17), module.InitialMemPages)
+	assert.Equal(t, uint32(3), module.MaxFunctionFrame)
+	assert.Equal(t, 2, module.FunctionCount)
+
+	assert.Empty(t, ValidateWasmModule(module,
+		&ipc.ChainSpec_CostTable_WasmCosts{InitialMem: 64, MaxStackHeight: 64},
+		&ipc.ChainSpec_DeployConfig{MaxBlockSizeBytes: 1024}))
+}
+
+func TestParseWasmModuleInvalid(t *testing.T) {
+	code := makeTestWasm("ret", "call", 17)
+
+	_, err := ParseWasmModule(code[:6])
+	assert.Error(t, err)
+
+	badMagic := append([]byte{}, code...)
+	badMagic[0] = 1
+	_, err = ParseWasmModule(badMagic)
+	assert.Error(t, err)
+
+	badVersion := append([]byte{}, code...)
+	badVersion[4] = 2
+	_, err = ParseWasmModule(badVersion)
+	assert.Error(t, err)
+
+	_, err = ParseWasmModule(code[:len(code)-3])
+	assert.Error(t, err)
+}
+
+func TestValidateWasmModule(t *testing.T) {
+	module, problems := ValidateWasm(makeTestWasm("print", "main", 17),
+		&ipc.ChainSpec_CostTable_WasmCosts{InitialMem: 16, MaxStackHeight: 2},
+		&ipc.ChainSpec_DeployConfig{MaxBlockSizeBytes: 10})
+
+	assert.NotNil(t, module)
+	assert.Equal(t, []WasmImport{{Module: "env", Name: "print", Kind: WASM_EXTERNAL_FUNCTION}}, module.UnknownImports())
+	assert.Equal(t, 5, len(problems))
+
+	_, problems = ValidateWasm([]byte{1, 2, 3}, nil, nil)
+	assert.Equal(t, 1, len(problems))
+}
+
+func TestValidateWasmContract(t *testing.T) {
+	code, err := LoadWasmFile("../integration/contracts/counter_call.wasm")
+	assert.NoError(t, err)
+
+	genesisConfig, err := GenesisConfigMock("test", nil, MakeProtocolVersion(1, 0, 0),
+		map[string]uint32{"mem-initial-pages": 4096, "max-stack-height": 65536},
+		"../integration/contracts/hdac_mint_install.wasm",
+		"../integration/contracts/pop_install.wasm",
+		"../integration/contracts/standard_payment_install.wasm")
+	assert.NoError(t, err)
+
+	module, problems := ValidateWasm(code, genesisConfig.GetCosts().GetWasm(), genesisConfig.GetDeployConfig())
+	assert.Empty(t, problems)
+	assert.Equal(t, uint32(17), module.InitialMemPages)
+}